@@ -74,5 +74,13 @@ func Node(name string, config *configuration.Configuration, entrypoint []string,
 		},
 	}
 
+	// Gitops webhook listener is published only when configured
+	if config.Ports.Webhook != "" {
+		container.Spec.Ports = append(container.Spec.Ports, v1.ContainersPort{
+			Container: "9443",
+			Host:      config.Ports.Webhook,
+		})
+	}
+
 	return container, nil
 }
@@ -0,0 +1,143 @@
+package admission
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	jsonpatch "github.com/evanphx/json-patch"
+	"github.com/simplecontainer/smr/pkg/configuration"
+	"strings"
+)
+
+var ERROR_DENIED = errors.New("denied by admission policy")
+var ERROR_IMMUTABLE = errors.New("patch must not change kind, group or name")
+
+// New orders policies so webhooks mutate first and built-in policies validate the final definition
+func New(config *configuration.Admission) (*Admission, error) {
+	admission := &Admission{
+		Policies: make([]Policy, 0),
+	}
+
+	if config == nil {
+		return admission, nil
+	}
+
+	for _, webhook := range config.Webhooks {
+		policy, err := NewWebhook(webhook)
+
+		if err != nil {
+			return nil, err
+		}
+
+		admission.Policies = append(admission.Policies, policy)
+	}
+
+	if len(config.RequiredLabels) > 0 {
+		admission.Policies = append(admission.Policies, &RequiredLabels{Labels: config.RequiredLabels})
+	}
+
+	if config.DenyPrivileged {
+		admission.Policies = append(admission.Policies, &NoPrivileged{})
+	}
+
+	if config.Images != nil {
+		images, err := NewImages(config.Images)
+
+		if err != nil {
+			return nil, err
+		}
+
+		if images.Enabled() {
+			admission.Policies = append(admission.Policies, images)
+		}
+	}
+
+	return admission, nil
+}
+
+// Admit runs the definition through every policy and returns it with patches applied
+func (admission *Admission) Admit(ctx context.Context, kind string, definition []byte) ([]byte, error) {
+	if admission == nil {
+		return definition, nil
+	}
+
+	violations := &Violations{}
+
+	for _, policy := range admission.Policies {
+		response, err := policy.Admit(ctx, &Request{Kind: kind, Definition: definition})
+
+		if err != nil {
+			return nil, fmt.Errorf("%s: %w", policy.GetName(), err)
+		}
+
+		if !response.Allowed {
+			if len(response.Violations) == 0 {
+				violations.Add(fmt.Sprintf("%s: definition rejected", policy.GetName()))
+			}
+
+			for _, violation := range response.Violations {
+				violations.Add(fmt.Sprintf("%s: %s", policy.GetName(), violation))
+			}
+
+			continue
+		}
+
+		if len(response.Patch) > 0 {
+			definition, err = Patch(definition, response.Patch)
+
+			if err != nil {
+				return nil, fmt.Errorf("%s: %w", policy.GetName(), err)
+			}
+		}
+	}
+
+	if len(violations.Violations) > 0 {
+		return nil, violations
+	}
+
+	return definition, nil
+}
+
+// Patch applies JSON patch to the definition, definition can't be moved to other kind, group or name
+func Patch(definition []byte, patch []byte) ([]byte, error) {
+	decoded, err := jsonpatch.DecodePatch(patch)
+
+	if err != nil {
+		return nil, fmt.Errorf("invalid patch: %w", err)
+	}
+
+	patched, err := decoded.Apply(definition)
+
+	if err != nil {
+		return nil, fmt.Errorf("failed to apply patch: %w", err)
+	}
+
+	before, after := identity{}, identity{}
+
+	if err = json.Unmarshal(definition, &before); err != nil {
+		return nil, err
+	}
+
+	if err = json.Unmarshal(patched, &after); err != nil {
+		return nil, err
+	}
+
+	if before != after {
+		return nil, ERROR_IMMUTABLE
+	}
+
+	return patched, nil
+}
+
+func (violations *Violations) Add(violation string) {
+	violations.Violations = append(violations.Violations, violation)
+}
+
+func (violations *Violations) Error() string {
+	return fmt.Sprintf("%s: %s", ERROR_DENIED, strings.Join(violations.Violations, "; "))
+}
+
+func (violations *Violations) Unwrap() error {
+	return ERROR_DENIED
+}
@@ -0,0 +1,339 @@
+package admission
+
+import (
+	"context"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"github.com/distribution/reference"
+	"github.com/opencontainers/go-digest"
+	"github.com/opencontainers/image-spec/specs-go"
+	ocispec "github.com/opencontainers/image-spec/specs-go/v1"
+	"github.com/simplecontainer/smr/pkg/configuration"
+	"github.com/simplecontainer/smr/pkg/logger"
+	"github.com/simplecontainer/smr/pkg/static"
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/zap"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestCheck(t *testing.T) {
+	pinned := "sha256:" + strings.Repeat("a", 64)
+
+	tests := []struct {
+		name          string
+		images        *Images
+		image         string
+		tag           string
+		expectedError error
+	}{
+		{"Not pinned", &Images{RequireDigest: true}, "nginx", "1.25", ERROR_NOT_PINNED},
+		{"Pinned", &Images{RequireDigest: true}, "nginx", pinned, nil},
+		{"Tag pinned to digest", &Images{RequireDigest: true}, "nginx", "1.25@" + pinned, nil},
+		{"Docker hub allowed", &Images{Registries: []string{"docker.io/library"}}, "nginx", "1.25", nil},
+		{"Registry allowed", &Images{Registries: []string{"quay.io"}}, "quay.io/team/app", "v1", nil},
+		{"Registry prefix is not a segment", &Images{Registries: []string{"quay.io/team"}}, "quay.io/teams/app", "v1", ERROR_REGISTRY_NOT_ALLOWED},
+		{"Registry not allowed", &Images{Registries: []string{"quay.io"}}, "nginx", "1.25", ERROR_REGISTRY_NOT_ALLOWED},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			_, err := tc.images.Check(context.Background(), tc.image, tc.tag)
+
+			if tc.expectedError == nil {
+				assert.NoError(t, err)
+			} else {
+				assert.ErrorIs(t, err, tc.expectedError)
+			}
+		})
+	}
+}
+
+func TestAdmit(t *testing.T) {
+	logger.Log = zap.NewNop()
+
+	webhook := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		request := &Request{}
+		json.NewDecoder(r.Body).Decode(request)
+
+		switch r.URL.Path {
+		case "/label":
+			json.NewEncoder(w).Encode(&Response{Allowed: true, Patch: json.RawMessage(`[{"op":"add","path":"/meta/labels","value":{"team":"platform"}}]`)})
+		case "/rename":
+			json.NewEncoder(w).Encode(&Response{Allowed: true, Patch: json.RawMessage(`[{"op":"replace","path":"/meta/name","value":"other"}]`)})
+		case "/reject":
+			json.NewEncoder(w).Encode(&Response{Allowed: false, Violations: []string{"nginx is not allowed"}})
+		default:
+			w.WriteHeader(http.StatusInternalServerError)
+		}
+	}))
+	defer webhook.Close()
+
+	hook := func(path string, failOpen bool) Policy {
+		policy, err := NewWebhook(&configuration.AdmissionWebhook{URL: webhook.URL + path, FailOpen: failOpen})
+		assert.NoError(t, err)
+
+		return policy
+	}
+
+	definition := []byte(`{"kind":"containers","meta":{"group":"example","name":"nginx"},"spec":{"image":"nginx","tag":"1.25","privileged":true}}`)
+	labeled := []byte(`{"kind":"containers","meta":{"group":"example","labels":{"team":"platform"},"name":"nginx"},"spec":{"image":"nginx","privileged":true,"tag":"1.25"}}`)
+
+	tests := []struct {
+		name               string
+		kind               string
+		policies           []Policy
+		expected           []byte
+		expectedViolations int
+		expectedError      error
+	}{
+		{"No policies", static.KIND_CONTAINERS, nil, definition, 0, nil},
+		{"Not pinned", static.KIND_CONTAINERS, []Policy{&Images{RequireDigest: true}}, nil, 1, nil},
+		{"Other kinds are not checked", static.KIND_CONFIGURATION, []Policy{&Images{RequireDigest: true}, &NoPrivileged{}}, definition, 0, nil},
+		{"Every violation is reported", static.KIND_CONTAINERS, []Policy{&Images{RequireDigest: true}, &NoPrivileged{}, &RequiredLabels{Labels: []string{"team", "owner"}}}, nil, 4, nil},
+		{"Webhook patch is seen by next policies", static.KIND_CONTAINERS, []Policy{hook("/label", false), &RequiredLabels{Labels: []string{"team"}}}, labeled, 0, nil},
+		{"Webhook rejects", static.KIND_CONTAINERS, []Policy{hook("/reject", false)}, nil, 1, nil},
+		{"Webhook can't rename", static.KIND_CONTAINERS, []Policy{hook("/rename", false)}, nil, 0, ERROR_IMMUTABLE},
+		{"Webhook fails closed", static.KIND_CONTAINERS, []Policy{hook("/fail", false)}, nil, 0, ERROR_WEBHOOK},
+		{"Webhook fails open", static.KIND_CONTAINERS, []Policy{hook("/fail", true)}, definition, 0, nil},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			admission := &Admission{Policies: tc.policies}
+
+			admitted, err := admission.Admit(context.Background(), tc.kind, definition)
+
+			var violations *Violations
+
+			switch {
+			case tc.expectedViolations > 0:
+				assert.True(t, errors.As(err, &violations))
+				assert.ErrorIs(t, err, ERROR_DENIED)
+				assert.Len(t, violations.Violations, tc.expectedViolations)
+			case tc.expectedError != nil:
+				assert.ErrorIs(t, err, tc.expectedError)
+			default:
+				assert.NoError(t, err)
+				assert.JSONEq(t, string(tc.expected), string(admitted))
+			}
+		})
+	}
+}
+
+func TestWebhookSealed(t *testing.T) {
+	logger.Log = zap.NewNop()
+
+	received := make([]string, 0)
+
+	webhook := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		request := &Request{}
+		json.NewDecoder(r.Body).Decode(request)
+
+		received = append(received, string(request.Definition))
+		json.NewEncoder(w).Encode(&Response{Allowed: true})
+	}))
+	defer webhook.Close()
+
+	secret := []byte(`{"kind":"secret","prefix":"simplecontainer.io/v1","meta":{"group":"example","name":"db"},"spec":{"data":{"password":"s3cr3t"}}}`)
+
+	tests := []struct {
+		name             string
+		kinds            []string
+		expectedReceived bool
+	}{
+		{"Sealed kinds are excluded by default", nil, false},
+		{"Sealed kind listed explicitly is redacted", []string{static.KIND_SECRET}, true},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			received = received[:0]
+
+			policy, err := NewWebhook(&configuration.AdmissionWebhook{URL: webhook.URL, Kinds: tc.kinds})
+			assert.NoError(t, err)
+
+			response, err := policy.Admit(context.Background(), &Request{Kind: static.KIND_SECRET, Definition: secret})
+			assert.NoError(t, err)
+			assert.True(t, response.Allowed)
+
+			if !tc.expectedReceived {
+				assert.Empty(t, received)
+				return
+			}
+
+			assert.Len(t, received, 1)
+			assert.NotContains(t, received[0], "s3cr3t")
+			assert.Contains(t, received[0], `"password":"[redacted]"`)
+		})
+	}
+}
+
+func TestVerify(t *testing.T) {
+	signer, _ := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	other, _ := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+
+	tests := []struct {
+		name          string
+		key           *ecdsa.PrivateKey
+		signedDigest  func(subject digest.Digest) digest.Digest
+		expectedError error
+	}{
+		{"Signed by trusted key", signer, func(subject digest.Digest) digest.Digest { return subject }, nil},
+		{"Signed by untrusted key", other, func(subject digest.Digest) digest.Digest { return subject }, ERROR_UNSIGNED_IMAGE},
+		{"Signature of other image", signer, func(subject digest.Digest) digest.Digest { return digest.FromString("other") }, ERROR_UNSIGNED_IMAGE},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			server, subject := registry(t, tc.key, tc.signedDigest)
+			defer server.Close()
+
+			images := &Images{
+				Keys:      []*ecdsa.PublicKey{&signer.PublicKey},
+				PlainHTTP: true,
+				verified:  make(map[string]time.Time),
+			}
+
+			named, err := reference.ParseNormalizedNamed(fmt.Sprintf("%s/example/app:v1", strings.TrimPrefix(server.URL, "http://")))
+			assert.NoError(t, err)
+
+			verified, err := images.Verify(context.Background(), named)
+
+			if tc.expectedError == nil {
+				assert.NoError(t, err)
+				assert.Equal(t, subject, verified)
+				assert.True(t, images.isVerified(subject))
+			} else {
+				assert.ErrorIs(t, err, tc.expectedError)
+			}
+		})
+	}
+}
+
+func TestPin(t *testing.T) {
+	signer, _ := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+
+	server, subject := registry(t, signer, func(subject digest.Digest) digest.Digest { return subject })
+	defer server.Close()
+
+	images := &Images{
+		Keys:      []*ecdsa.PublicKey{&signer.PublicKey},
+		PlainHTTP: true,
+		verified:  make(map[string]time.Time),
+	}
+
+	host := strings.TrimPrefix(server.URL, "http://")
+
+	definition := []byte(fmt.Sprintf(`{"kind":"containers","meta":{"group":"example","name":"app"},"spec":{"image":"%s/example/app","tag":"v1"}}`, host))
+	pinned := []byte(fmt.Sprintf(`{"kind":"containers","meta":{"group":"example","name":"app"},"spec":{"image":"%s/example/app","tag":"%s"}}`, host, subject))
+
+	admitted, err := (&Admission{Policies: []Policy{images}}).Admit(context.Background(), static.KIND_CONTAINERS, definition)
+
+	assert.NoError(t, err)
+	assert.JSONEq(t, string(pinned), string(admitted))
+}
+
+// registry serves single image tagged v1 with cosign signature attached as OCI referrer
+func registry(t *testing.T, key *ecdsa.PrivateKey, signedDigest func(subject digest.Digest) digest.Digest) (*httptest.Server, digest.Digest) {
+	blobs := make(map[digest.Digest][]byte)
+	manifests := make(map[digest.Digest][]byte)
+
+	add := func(store map[digest.Digest][]byte, data []byte) digest.Digest {
+		dgst := digest.FromBytes(data)
+		store[dgst] = data
+		return dgst
+	}
+
+	config := []byte("{}")
+	add(blobs, config)
+
+	image, _ := json.Marshal(ocispec.Manifest{
+		Versioned: specs.Versioned{SchemaVersion: 2},
+		MediaType: ocispec.MediaTypeImageManifest,
+		Config:    ocispec.Descriptor{MediaType: ocispec.MediaTypeImageConfig, Digest: digest.FromBytes(config), Size: int64(len(config))},
+		Layers:    []ocispec.Descriptor{},
+	})
+	subject := add(manifests, image)
+
+	payload := []byte(fmt.Sprintf(`{"critical":{"identity":{"docker-reference":"example/app"},"image":{"docker-manifest-digest":"%s"},"type":"cosign container image signature"},"optional":null}`, signedDigest(subject)))
+	add(blobs, payload)
+
+	hash := sha256.Sum256(payload)
+	sig, err := ecdsa.SignASN1(rand.Reader, key, hash[:])
+	assert.NoError(t, err)
+
+	signature, _ := json.Marshal(ocispec.Manifest{
+		Versioned:    specs.Versioned{SchemaVersion: 2},
+		MediaType:    ocispec.MediaTypeImageManifest,
+		ArtifactType: COSIGN_SIGNATURE_ARTIFACT,
+		Config:       ocispec.DescriptorEmptyJSON,
+		Layers: []ocispec.Descriptor{{
+			MediaType:   COSIGN_SIMPLESIGNING,
+			Digest:      digest.FromBytes(payload),
+			Size:        int64(len(payload)),
+			Annotations: map[string]string{COSIGN_SIGNATURE_ANNOTATION: base64.StdEncoding.EncodeToString(sig)},
+		}},
+		Subject: &ocispec.Descriptor{MediaType: ocispec.MediaTypeImageManifest, Digest: subject, Size: int64(len(image))},
+	})
+	referrer := add(manifests, signature)
+
+	index, _ := json.Marshal(ocispec.Index{
+		Versioned: specs.Versioned{SchemaVersion: 2},
+		MediaType: ocispec.MediaTypeImageIndex,
+		Manifests: []ocispec.Descriptor{{
+			MediaType:    ocispec.MediaTypeImageManifest,
+			ArtifactType: COSIGN_SIGNATURE_ARTIFACT,
+			Digest:       referrer,
+			Size:         int64(len(signature)),
+		}},
+	})
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		path := strings.TrimPrefix(r.URL.Path, "/v2/example/app/")
+
+		serve := func(mediaType string, data []byte) {
+			w.Header().Set("Content-Type", mediaType)
+			w.Header().Set("Docker-Content-Digest", digest.FromBytes(data).String())
+			w.Header().Set("Content-Length", fmt.Sprint(len(data)))
+
+			if r.Method != http.MethodHead {
+				w.Write(data)
+			}
+		}
+
+		switch {
+		case path == "manifests/v1":
+			serve(ocispec.MediaTypeImageManifest, image)
+		case strings.HasPrefix(path, "manifests/sha256:"):
+			if data, ok := manifests[digest.Digest(strings.TrimPrefix(path, "manifests/"))]; ok {
+				serve(ocispec.MediaTypeImageManifest, data)
+				return
+			}
+
+			w.WriteHeader(http.StatusNotFound)
+		case path == "referrers/"+subject.String():
+			serve(ocispec.MediaTypeImageIndex, index)
+		case strings.HasPrefix(path, "blobs/"):
+			if data, ok := blobs[digest.Digest(strings.TrimPrefix(path, "blobs/"))]; ok {
+				serve("application/octet-stream", data)
+				return
+			}
+
+			w.WriteHeader(http.StatusNotFound)
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+
+	return server, subject
+}
@@ -0,0 +1,334 @@
+package admission
+
+import (
+	"context"
+	"crypto/ecdsa"
+	"crypto/sha256"
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/json"
+	"encoding/pem"
+	"errors"
+	"fmt"
+	"github.com/distribution/reference"
+	"github.com/opencontainers/go-digest"
+	ocispec "github.com/opencontainers/image-spec/specs-go/v1"
+	"github.com/simplecontainer/smr/pkg/configuration"
+	v1 "github.com/simplecontainer/smr/pkg/definitions/v1"
+	"github.com/simplecontainer/smr/pkg/kinds/containers/platforms/image"
+	"github.com/simplecontainer/smr/pkg/static"
+	"oras.land/oras-go/v2/content"
+	"oras.land/oras-go/v2/registry/remote"
+	"oras.land/oras-go/v2/registry/remote/auth"
+	"oras.land/oras-go/v2/registry/remote/credentials"
+	"oras.land/oras-go/v2/registry/remote/retry"
+	"os"
+	"strings"
+	"time"
+)
+
+var ERROR_NOT_PINNED = errors.New("image is not pinned by digest")
+var ERROR_REGISTRY_NOT_ALLOWED = errors.New("image registry is not allowed")
+var ERROR_UNSIGNED_IMAGE = errors.New("image has no signature made by trusted key")
+
+func NewImages(config *configuration.ImagesAdmission) (*Images, error) {
+	images := &Images{
+		RequireDigest: config.RequireDigest,
+		Registries:    config.Registries,
+		Keys:          make([]*ecdsa.PublicKey, 0),
+		verified:      make(map[string]time.Time),
+	}
+
+	for _, path := range config.Keys {
+		key, err := loadPublicKey(path)
+
+		if err != nil {
+			return nil, fmt.Errorf("%s: %w", path, err)
+		}
+
+		images.Keys = append(images.Keys, key)
+	}
+
+	// Same credentials docker on the node uses, anonymous access otherwise
+	if store, err := credentials.NewStoreFromDocker(credentials.StoreOptions{}); err == nil {
+		images.Credential = credentials.Credential(store)
+	}
+
+	return images, nil
+}
+
+func (images *Images) Enabled() bool {
+	return images.RequireDigest || len(images.Registries) > 0 || len(images.Keys) > 0
+}
+
+func (images *Images) GetName() string {
+	return "images"
+}
+
+func (images *Images) Admit(ctx context.Context, request *Request) (*Response, error) {
+	response := &Response{Allowed: true}
+
+	if request.Kind != static.KIND_CONTAINERS {
+		return response, nil
+	}
+
+	containers := v1.ContainersDefinition{}
+
+	if err := json.Unmarshal(request.Definition, &containers); err != nil {
+		return nil, err
+	}
+
+	patch := make([]map[string]string, 0)
+
+	specs := []struct {
+		path string
+		spec *v1.ContainersInternal
+	}{{"/spec", containers.Spec}, {"/initContainer", containers.InitContainer}}
+
+	for _, s := range specs {
+		path, spec := s.path, s.spec
+
+		if spec == nil {
+			continue
+		}
+
+		pinned, err := images.Check(ctx, spec.Image, spec.Tag)
+
+		if err != nil {
+			response.Allowed = false
+			response.Violations = append(response.Violations, err.Error())
+			continue
+		}
+
+		// Tag could be moved to other image after verification, pin the digest that was verified
+		if pinned != "" && pinned.String() != spec.Tag {
+			patch = append(patch, map[string]string{"op": "add", "path": path + "/tag", "value": pinned.String()})
+		}
+	}
+
+	if response.Allowed && len(patch) > 0 {
+		var err error
+		response.Patch, err = json.Marshal(patch)
+
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	return response, nil
+}
+
+// Check validates the image reference as the engine will pull it, returns digest of the image if signature was verified
+func (images *Images) Check(ctx context.Context, name string, tag string) (digest.Digest, error) {
+	ref := image.Reference(name, tag)
+
+	named, err := reference.ParseNormalizedNamed(ref)
+
+	if err != nil {
+		return "", fmt.Errorf("invalid image %s: %w", ref, err)
+	}
+
+	_, digested := named.(reference.Digested)
+
+	if images.RequireDigest && !digested {
+		return "", fmt.Errorf("%w: %s", ERROR_NOT_PINNED, ref)
+	}
+
+	if len(images.Registries) > 0 && !images.Allowed(named) {
+		return "", fmt.Errorf("%w: %s", ERROR_REGISTRY_NOT_ALLOWED, ref)
+	}
+
+	if len(images.Keys) > 0 {
+		verified, err := images.Verify(ctx, named)
+
+		if err != nil {
+			return "", fmt.Errorf("%s: %w", ref, err)
+		}
+
+		return verified, nil
+	}
+
+	return "", nil
+}
+
+// Allowed matches normalized repository eg. docker.io/library/nginx against registries and registry namespaces
+func (images *Images) Allowed(named reference.Named) bool {
+	repository := named.Name()
+
+	for _, registry := range images.Registries {
+		registry = strings.Trim(strings.TrimSpace(registry), "/")
+
+		if registry != "" && (repository == registry || strings.HasPrefix(repository, registry+"/")) {
+			return true
+		}
+	}
+
+	return false
+}
+
+// Verify looks for cosign signature among OCI referrers of the image, signatures pushed under legacy
+// sha256-<digest>.sig tag are checked as well, returns digest of the verified image
+func (images *Images) Verify(ctx context.Context, named reference.Named) (digest.Digest, error) {
+	host := reference.Domain(named)
+
+	if host == "docker.io" {
+		host = "registry-1.docker.io"
+	}
+
+	repository, err := remote.NewRepository(fmt.Sprintf("%s/%s", host, reference.Path(named)))
+
+	if err != nil {
+		return "", err
+	}
+
+	repository.PlainHTTP = images.PlainHTTP
+	repository.Client = &auth.Client{
+		Client:     retry.DefaultClient,
+		Cache:      auth.NewCache(),
+		Credential: images.Credential,
+	}
+
+	target := "latest"
+
+	if digested, ok := named.(reference.Digested); ok {
+		target = digested.Digest().String()
+	} else if tagged, ok := named.(reference.Tagged); ok {
+		target = tagged.Tag()
+	}
+
+	descriptor, err := repository.Resolve(ctx, target)
+
+	if err != nil {
+		return "", fmt.Errorf("failed to resolve image: %w", err)
+	}
+
+	if images.isVerified(descriptor.Digest) {
+		return descriptor.Digest, nil
+	}
+
+	signatures := make([]ocispec.Descriptor, 0)
+
+	err = repository.Referrers(ctx, descriptor, COSIGN_SIGNATURE_ARTIFACT, func(referrers []ocispec.Descriptor) error {
+		signatures = append(signatures, referrers...)
+		return nil
+	})
+
+	if err != nil {
+		return "", fmt.Errorf("failed to list image referrers: %w", err)
+	}
+
+	if legacy, err := repository.Resolve(ctx, fmt.Sprintf("%s-%s.sig", descriptor.Digest.Algorithm(), descriptor.Digest.Encoded())); err == nil {
+		signatures = append(signatures, legacy)
+	}
+
+	for _, signature := range signatures {
+		if images.verifySignature(ctx, repository, signature, descriptor.Digest) {
+			images.setVerified(descriptor.Digest)
+			return descriptor.Digest, nil
+		}
+	}
+
+	return "", ERROR_UNSIGNED_IMAGE
+}
+
+func (images *Images) verifySignature(ctx context.Context, repository *remote.Repository, signature ocispec.Descriptor, subject digest.Digest) bool {
+	data, err := content.FetchAll(ctx, repository, signature)
+
+	if err != nil {
+		return false
+	}
+
+	manifest := ocispec.Manifest{}
+
+	if err = json.Unmarshal(data, &manifest); err != nil {
+		return false
+	}
+
+	for _, layer := range manifest.Layers {
+		encoded, ok := layer.Annotations[COSIGN_SIGNATURE_ANNOTATION]
+
+		if layer.MediaType != COSIGN_SIMPLESIGNING || !ok {
+			continue
+		}
+
+		sig, err := base64.StdEncoding.DecodeString(encoded)
+
+		if err != nil {
+			continue
+		}
+
+		payload, err := content.FetchAll(ctx, repository, layer)
+
+		if err != nil {
+			continue
+		}
+
+		if !images.trusted(payload, sig) {
+			continue
+		}
+
+		// Signature must be made for this image, not just any image signed by the same key
+		simple := SimpleSigning{}
+
+		if json.Unmarshal(payload, &simple) == nil && simple.Critical.Image.DockerManifestDigest == subject.String() {
+			return true
+		}
+	}
+
+	return false
+}
+
+func (images *Images) trusted(payload []byte, sig []byte) bool {
+	hash := sha256.Sum256(payload)
+
+	for _, key := range images.Keys {
+		if ecdsa.VerifyASN1(key, hash[:], sig) {
+			return true
+		}
+	}
+
+	return false
+}
+
+func (images *Images) isVerified(digest digest.Digest) bool {
+	images.lock.RLock()
+	defer images.lock.RUnlock()
+
+	verified, ok := images.verified[digest.String()]
+	return ok && time.Since(verified) < VERIFIED_TTL
+}
+
+func (images *Images) setVerified(digest digest.Digest) {
+	images.lock.Lock()
+	defer images.lock.Unlock()
+
+	images.verified[digest.String()] = time.Now()
+}
+
+func loadPublicKey(path string) (*ecdsa.PublicKey, error) {
+	data, err := os.ReadFile(path)
+
+	if err != nil {
+		return nil, err
+	}
+
+	block, _ := pem.Decode(data)
+
+	if block == nil {
+		return nil, errors.New("failed to decode PEM block")
+	}
+
+	key, err := x509.ParsePKIXPublicKey(block.Bytes)
+
+	if err != nil {
+		return nil, err
+	}
+
+	ecdsaKey, ok := key.(*ecdsa.PublicKey)
+
+	if !ok {
+		return nil, errors.New("not an ECDSA public key")
+	}
+
+	return ecdsaKey, nil
+}
@@ -0,0 +1,65 @@
+package admission
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"github.com/simplecontainer/smr/pkg/definitions/commonv1"
+	v1 "github.com/simplecontainer/smr/pkg/definitions/v1"
+	"github.com/simplecontainer/smr/pkg/static"
+)
+
+func (labels *RequiredLabels) GetName() string {
+	return "required-labels"
+}
+
+func (labels *RequiredLabels) Admit(ctx context.Context, request *Request) (*Response, error) {
+	definition := struct {
+		Meta *commonv1.Meta `json:"meta"`
+	}{}
+
+	if err := json.Unmarshal(request.Definition, &definition); err != nil {
+		return nil, err
+	}
+
+	response := &Response{Allowed: true}
+
+	for _, label := range labels.Labels {
+		if definition.Meta == nil || definition.Meta.Labels[label] == "" {
+			response.Allowed = false
+			response.Violations = append(response.Violations, fmt.Sprintf("label %s is required", label))
+		}
+	}
+
+	return response, nil
+}
+
+func (privileged *NoPrivileged) GetName() string {
+	return "no-privileged"
+}
+
+func (privileged *NoPrivileged) Admit(ctx context.Context, request *Request) (*Response, error) {
+	response := &Response{Allowed: true}
+
+	if request.Kind != static.KIND_CONTAINERS {
+		return response, nil
+	}
+
+	containers := v1.ContainersDefinition{}
+
+	if err := json.Unmarshal(request.Definition, &containers); err != nil {
+		return nil, err
+	}
+
+	if containers.Spec != nil && containers.Spec.Privileged {
+		response.Allowed = false
+		response.Violations = append(response.Violations, "privileged containers are not allowed")
+	}
+
+	if containers.InitContainer != nil && containers.InitContainer.Privileged {
+		response.Allowed = false
+		response.Violations = append(response.Violations, "privileged init containers are not allowed")
+	}
+
+	return response, nil
+}
@@ -0,0 +1,89 @@
+package admission
+
+import (
+	"context"
+	"crypto/ecdsa"
+	"encoding/json"
+	"net/http"
+	"oras.land/oras-go/v2/registry/remote/auth"
+	"sync"
+	"time"
+)
+
+const COSIGN_SIGNATURE_ARTIFACT = "application/vnd.dev.cosign.artifact.sig.v1+json"
+const COSIGN_SIMPLESIGNING = "application/vnd.dev.cosign.simplesigning.v1+json"
+const COSIGN_SIGNATURE_ANNOTATION = "dev.cosignproject.cosign/signature"
+
+// VERIFIED_TTL is how long verified image digest is trusted without asking the registry again
+const VERIFIED_TTL = 10 * time.Minute
+
+type Admission struct {
+	Policies []Policy
+}
+
+// Policy admits, rejects or mutates the definition, policies run in order and each one sees patches of previous ones
+type Policy interface {
+	GetName() string
+	Admit(ctx context.Context, request *Request) (*Response, error)
+}
+
+// Request is what webhooks receive
+type Request struct {
+	Kind       string          `json:"kind"`
+	Definition json.RawMessage `json:"definition"`
+}
+
+// Response is what webhooks respond with, patch is JSON patch (RFC 6902) applied to the admitted definition
+type Response struct {
+	Allowed    bool            `json:"allowed"`
+	Violations []string        `json:"violations,omitempty"`
+	Patch      json.RawMessage `json:"patch,omitempty"`
+}
+
+type Images struct {
+	RequireDigest bool
+	Registries    []string
+	Keys          []*ecdsa.PublicKey
+	PlainHTTP     bool
+	Credential    auth.CredentialFunc
+	verified      map[string]time.Time
+	lock          sync.RWMutex
+}
+
+type RequiredLabels struct {
+	Labels []string
+}
+
+type NoPrivileged struct{}
+
+type Webhook struct {
+	Name     string
+	URL      string
+	Kinds    []string
+	FailOpen bool
+	Client   *http.Client
+}
+
+// Violations lists every rule the definition broke so all of them can be fixed at once
+type Violations struct {
+	Violations []string `json:"violations"`
+}
+
+// SimpleSigning is the payload cosign signs, only fields needed for verification are decoded
+type SimpleSigning struct {
+	Critical struct {
+		Image struct {
+			DockerManifestDigest string `json:"docker-manifest-digest"`
+		} `json:"image"`
+		Type string `json:"type"`
+	} `json:"critical"`
+}
+
+// identity are fields patches are not allowed to change
+type identity struct {
+	Kind string `json:"kind"`
+	Meta struct {
+		Group string `json:"group"`
+		Name  string `json:"name"`
+	} `json:"meta"`
+}
@@ -0,0 +1,150 @@
+package admission
+
+import (
+	"bytes"
+	"context"
+	"crypto/tls"
+	"crypto/x509"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"github.com/simplecontainer/smr/pkg/audit"
+	"github.com/simplecontainer/smr/pkg/configuration"
+	"github.com/simplecontainer/smr/pkg/definitions"
+	"github.com/simplecontainer/smr/pkg/logger"
+	"go.uber.org/zap"
+	"io"
+	"net/http"
+	"os"
+	"slices"
+)
+
+var ERROR_WEBHOOK = errors.New("admission webhook failed")
+
+func NewWebhook(config *configuration.AdmissionWebhook) (*Webhook, error) {
+	if config.URL == "" {
+		return nil, errors.New("admission webhook url is required")
+	}
+
+	timeout := config.Timeout
+
+	if timeout <= 0 {
+		timeout = configuration.DEFAULT_ADMISSION_WEBHOOK_TIMEOUT
+	}
+
+	transport := http.DefaultTransport.(*http.Transport).Clone()
+
+	if config.CA != "" {
+		data, err := os.ReadFile(config.CA)
+
+		if err != nil {
+			return nil, err
+		}
+
+		pool := x509.NewCertPool()
+
+		if !pool.AppendCertsFromPEM(data) {
+			return nil, fmt.Errorf("failed to parse CA of admission webhook %s", config.URL)
+		}
+
+		transport.TLSClientConfig = &tls.Config{RootCAs: pool, MinVersion: tls.VersionTLS12}
+	}
+
+	name := config.Name
+
+	if name == "" {
+		name = config.URL
+	}
+
+	return &Webhook{
+		Name:     name,
+		URL:      config.URL,
+		Kinds:    config.Kinds,
+		FailOpen: config.FailOpen,
+		Client:   &http.Client{Transport: transport, Timeout: timeout},
+	}, nil
+}
+
+func (webhook *Webhook) GetName() string {
+	return webhook.Name
+}
+
+// Admit posts the request to the webhook, non 200 responses are treated as webhook failures not as rejections
+func (webhook *Webhook) Admit(ctx context.Context, request *Request) (*Response, error) {
+	if !webhook.Handles(request.Kind) {
+		return &Response{Allowed: true}, nil
+	}
+
+	// Admission runs before the definition is sealed so payload of sealed kinds never leaves the node
+	if definitions.IsSealed(request.Kind) {
+		redacted, err := definitions.Redact(request.Kind, request.Definition, audit.REDACTED)
+
+		if err != nil {
+			return nil, err
+		}
+
+		request = &Request{Kind: request.Kind, Definition: redacted}
+	}
+
+	body, err := json.Marshal(request)
+
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, webhook.URL, bytes.NewReader(body))
+
+	if err != nil {
+		return nil, err
+	}
+
+	req.Header.Set("Content-Type", "application/json")
+
+	resp, err := webhook.Client.Do(req)
+
+	if err != nil {
+		return webhook.failed(err)
+	}
+
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return webhook.failed(fmt.Errorf("%w: responded with status %d", ERROR_WEBHOOK, resp.StatusCode))
+	}
+
+	data, err := io.ReadAll(io.LimitReader(resp.Body, 1<<20))
+
+	if err != nil {
+		return webhook.failed(err)
+	}
+
+	response := &Response{}
+
+	if err = json.Unmarshal(data, response); err != nil {
+		return webhook.failed(fmt.Errorf("%w: invalid response: %s", ERROR_WEBHOOK, err))
+	}
+
+	return response, nil
+}
+
+// Handles reports whether the webhook receives the kind, sealed kinds are sent only if listed explicitly
+func (webhook *Webhook) Handles(kind string) bool {
+	if len(webhook.Kinds) == 0 {
+		return !definitions.IsSealed(kind)
+	}
+
+	return slices.Contains(webhook.Kinds, kind)
+}
+
+func (webhook *Webhook) failed(err error) (*Response, error) {
+	if !errors.Is(err, ERROR_WEBHOOK) {
+		err = fmt.Errorf("%w: %s", ERROR_WEBHOOK, err)
+	}
+
+	if webhook.FailOpen {
+		logger.Log.Warn("admission webhook failed, definition admitted", zap.String("webhook", webhook.Name), zap.Error(err))
+		return &Response{Allowed: true}, nil
+	}
+
+	return nil, err
+}
@@ -0,0 +1,41 @@
+package api
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"github.com/simplecontainer/smr/pkg/admission"
+	"github.com/simplecontainer/smr/pkg/authentication"
+	"github.com/simplecontainer/smr/pkg/contracts/iresponse"
+	"github.com/simplecontainer/smr/pkg/kinds/common"
+	"github.com/simplecontainer/smr/pkg/packer/signature"
+	"net/http"
+	"time"
+)
+
+// admit checks pack trust and runs admission policies, returns the definition mutated by them
+func (a *Api) admit(ctx context.Context, user *authentication.User, kind string, definition []byte) ([]byte, error) {
+	if err := a.unsigned(user); err != nil {
+		return nil, err
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, 30*time.Second)
+	defer cancel()
+
+	return a.Admission.Admit(ctx, kind, definition)
+}
+
+func rejected(err error) iresponse.Response {
+	var violations *admission.Violations
+
+	if errors.Is(err, signature.ERROR_UNSIGNED) {
+		return common.Response(http.StatusForbidden, "definition rejected by trust policy", err, nil)
+	}
+
+	if errors.As(err, &violations) {
+		data, _ := json.Marshal(violations)
+		return common.Response(http.StatusForbidden, "definition rejected by admission policy", err, data)
+	}
+
+	return common.Response(http.StatusBadRequest, "failed to run admission policy", err, nil)
+}
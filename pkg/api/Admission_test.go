@@ -0,0 +1,117 @@
+package api
+
+import (
+	"bytes"
+	"context"
+	"crypto/tls"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"errors"
+	"github.com/gin-gonic/gin"
+	"github.com/simplecontainer/smr/pkg/admission"
+	"github.com/simplecontainer/smr/pkg/authentication"
+	"github.com/simplecontainer/smr/pkg/configuration"
+	"github.com/simplecontainer/smr/pkg/contracts/ievents"
+	"github.com/simplecontainer/smr/pkg/contracts/ikinds"
+	"github.com/simplecontainer/smr/pkg/contracts/iresponse"
+	"github.com/simplecontainer/smr/pkg/contracts/ishared"
+	"github.com/simplecontainer/smr/pkg/keys"
+	"github.com/simplecontainer/smr/pkg/kinds/common"
+	"github.com/simplecontainer/smr/pkg/manager"
+	"github.com/simplecontainer/smr/pkg/static"
+	"github.com/stretchr/testify/assert"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+type recorder struct {
+	applied [][]byte
+}
+
+func (r *recorder) Start() error { return nil }
+
+func (r *recorder) Apply(user *authentication.User, definition []byte, agent string) (iresponse.Response, error) {
+	r.applied = append(r.applied, definition)
+	return common.Response(http.StatusOK, "", nil, nil), nil
+}
+
+func (r *recorder) State(user *authentication.User, definition []byte, agent string) (iresponse.Response, error) {
+	return common.Response(http.StatusOK, "", nil, nil), nil
+}
+
+func (r *recorder) Delete(user *authentication.User, definition []byte, agent string) (iresponse.Response, error) {
+	return common.Response(http.StatusOK, "", nil, nil), nil
+}
+
+func (r *recorder) GetShared() ishared.Shared { return nil }
+
+func (r *recorder) Event(event ievents.Event) error { return nil }
+
+func TestAdmit(t *testing.T) {
+	a := &Api{
+		Manager:   &manager.Manager{},
+		Admission: &admission.Admission{Policies: []admission.Policy{&admission.Images{RequireDigest: true}}},
+	}
+
+	tests := []struct {
+		name       string
+		definition []byte
+	}{
+		{"Not pinned", []byte(`{"kind":"containers","meta":{"group":"example","name":"nginx"},"spec":{"image":"nginx","tag":"1.25"}}`)},
+		{"Replay flag doesn't skip admission", []byte(`{"kind":"containers","meta":{"group":"example","name":"nginx"},"spec":{"image":"nginx","tag":"1.25"},"state":{"options":[{"Name":"replay","Value":"true"}]}}`)},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			admitted, err := a.admit(context.Background(), &authentication.User{}, static.KIND_CONTAINERS, tc.definition)
+
+			var violations *admission.Violations
+
+			assert.Nil(t, admitted)
+			assert.True(t, errors.As(err, &violations))
+			assert.Equal(t, http.StatusForbidden, rejected(err).HttpStatus)
+		})
+	}
+}
+
+func TestKindAdmission(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	definition := []byte(`{"kind":"containers","prefix":"simplecontainer.io/v1","meta":{"group":"example","name":"nginx"},"spec":{"image":"nginx","tag":"1.25"}}`)
+
+	tests := []struct {
+		name           string
+		units          []string
+		expectedStatus int
+		expectedApply  int
+	}{
+		{"Committed definition applied by node", []string{keys.NODE_UNIT}, http.StatusOK, 1},
+		{"User is admitted", nil, http.StatusForbidden, 0},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			kind := &recorder{}
+
+			a := &Api{
+				Config:        &configuration.Configuration{NodeName: "smr-node-1"},
+				Manager:       &manager.Manager{},
+				Admission:     &admission.Admission{Policies: []admission.Policy{&admission.Images{RequireDigest: true}}},
+				KindsRegistry: map[string]ikinds.Kind{static.KIND_CONTAINERS: kind},
+			}
+
+			w := httptest.NewRecorder()
+			c, _ := gin.CreateTestContext(w)
+
+			c.Request = httptest.NewRequest(http.MethodPost, "/api/v1/attempt/apply", bytes.NewReader(definition))
+			c.Request.TLS = &tls.ConnectionState{PeerCertificates: []*x509.Certificate{{Subject: pkix.Name{CommonName: "smr-node-1", OrganizationalUnit: tc.units}}}}
+			c.Params = gin.Params{{Key: "action", Value: "apply"}}
+
+			a.Kind(c)
+
+			assert.Equal(t, tc.expectedStatus, w.Code)
+			assert.Len(t, kind.applied, tc.expectedApply)
+		})
+	}
+}
@@ -12,8 +12,10 @@ import (
 	"github.com/simplecontainer/smr/pkg/logger"
 	"github.com/simplecontainer/smr/pkg/manager"
 	"github.com/simplecontainer/smr/pkg/node"
+	"github.com/simplecontainer/smr/pkg/packer/trust"
 	"github.com/simplecontainer/smr/pkg/raft"
 	"github.com/simplecontainer/smr/pkg/relations"
+	"github.com/simplecontainer/smr/pkg/revocation"
 	"github.com/simplecontainer/smr/pkg/wss"
 	clientv3 "go.etcd.io/etcd/client/v3"
 	"go.uber.org/zap"
@@ -33,6 +35,7 @@ func NewApi(config *configuration.Configuration) *Api {
 		Kinds:         relations.NewDefinitionRelationRegistry(),
 		KindsRegistry: nil,
 		Manager:       &manager.Manager{},
+		Revocations:   revocation.New(),
 	}
 
 	api.Manager.Version = api.Version
@@ -43,6 +46,7 @@ func NewApi(config *configuration.Configuration) *Api {
 	api.Manager.DnsCache = api.DnsCache
 	api.Manager.Http = clients.NewHttpClients()
 	api.Manager.Wss = api.Wss
+	api.Manager.Trust = trust.New()
 
 	api.Kinds.InTree()
 
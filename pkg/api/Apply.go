@@ -3,6 +3,7 @@ package api
 import (
 	"errors"
 	"github.com/gin-gonic/gin"
+	"github.com/simplecontainer/smr/pkg/audit"
 	"github.com/simplecontainer/smr/pkg/authentication"
 	"github.com/simplecontainer/smr/pkg/contracts/iresponse"
 	v1 "github.com/simplecontainer/smr/pkg/definitions/v1"
@@ -30,18 +31,51 @@ func (a *Api) Kind(c *gin.Context) {
 			} else {
 				var response iresponse.Response
 
+				entry := audit.Entry{
+					Action: c.Param("action"),
+					Kind:   dummy.GetKind(),
+				}
+
+				if dummy.GetMeta() != nil {
+					entry.Group = dummy.GetMeta().Group
+					entry.Name = dummy.GetMeta().Name
+				}
+
+				user := authentication.NewUser(c.Request.TLS)
+
 				switch c.Param("action") {
 				case "apply":
-					response, err = kindObj.Apply(authentication.NewUser(c.Request.TLS), definition, a.Config.NodeName)
+					// Nodes apply definitions that were admitted by Propose before they were committed, running policies
+					// again would repeat patches and webhooks on every node and diverge if one of them rejects
+					if !user.Node {
+						definition, err = a.admit(c.Request.Context(), user, entry.Kind, definition)
+
+						if err != nil {
+							response = rejected(err)
+							break
+						}
+					}
+
+					entry.Diff = a.diff(c.Request.Context(), entry.Kind, entry.Group, entry.Name, definition)
+
+					response, err = kindObj.Apply(user, definition, a.Config.NodeName)
 					break
 				case "state":
-					response, err = kindObj.State(authentication.NewUser(c.Request.TLS), definition, a.Config.NodeName)
+					response, err = kindObj.State(user, definition, a.Config.NodeName)
 					break
 				case "remove":
-					response, err = kindObj.Delete(authentication.NewUser(c.Request.TLS), definition, a.Config.NodeName)
+					response, err = kindObj.Delete(user, definition, a.Config.NodeName)
 					break
 				}
 
+				entry.Status = response.HttpStatus
+
+				if err != nil {
+					entry.Error = err.Error()
+				}
+
+				a.record(c, entry)
+
 				c.JSON(response.HttpStatus, response)
 			}
 		}
@@ -0,0 +1,171 @@
+package api
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"github.com/gin-gonic/gin"
+	"github.com/simplecontainer/smr/pkg/audit"
+	"github.com/simplecontainer/smr/pkg/authentication"
+	"github.com/simplecontainer/smr/pkg/definitions"
+	"github.com/simplecontainer/smr/pkg/f"
+	"github.com/simplecontainer/smr/pkg/kinds/common"
+	"github.com/simplecontainer/smr/pkg/logger"
+	"github.com/simplecontainer/smr/pkg/network"
+	"github.com/simplecontainer/smr/pkg/static"
+	"github.com/wI2L/jsondiff"
+	"go.uber.org/zap"
+	"net/http"
+	"strings"
+	"time"
+)
+
+// Audit returns entries from the audit trail of this node filtered by since, user and kind query parameters,
+// trail isn't replicated so calls served by other nodes are only visible when querying those nodes
+func (a *Api) Audit(c *gin.Context) {
+	if a.AuditTrail == nil {
+		c.JSON(http.StatusInternalServerError, common.Response(http.StatusInternalServerError, "", errors.New("audit trail is not enabled"), nil))
+		return
+	}
+
+	filter := audit.Filter{
+		User: c.Query("user"),
+		Kind: c.Query("kind"),
+	}
+
+	if since := c.Query("since"); since != "" {
+		var err error
+		filter.Since, err = parseSince(since, time.Now())
+
+		if err != nil {
+			c.JSON(http.StatusBadRequest, common.Response(http.StatusBadRequest, "invalid since, use duration (24h) or RFC3339 time", err, nil))
+			return
+		}
+	}
+
+	entries, err := a.AuditTrail.Query(filter)
+
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, common.Response(http.StatusInternalServerError, "failed to read audit trail", err, nil))
+		return
+	}
+
+	c.JSON(http.StatusOK, common.Response(http.StatusOK, "", nil, network.ToJSON(entries)))
+}
+
+// record writes the entry on behalf of the calling user, failing to audit is logged but doesn't fail the call
+func (a *Api) record(c *gin.Context, entry audit.Entry) {
+	if a.AuditTrail == nil {
+		return
+	}
+
+	if c.Request.TLS != nil {
+		user := authentication.NewUser(c.Request.TLS)
+
+		entry.User = user.Username
+		entry.Domain = user.Domain
+	}
+
+	entry.Node = a.Config.NodeName
+
+	if err := a.AuditTrail.Record(entry); err != nil {
+		logger.Log.Error("failed to write audit entry", zap.Error(err))
+	}
+}
+
+// recordUser writes the entry unless call came from cluster node
+func (a *Api) recordUser(c *gin.Context, entry audit.Entry) {
+	if a.fromNode(c) {
+		return
+	}
+
+	a.record(c, entry)
+}
+
+// fromNode reports if the call is made by cluster node, controllers writing state aren't user changes worth auditing
+func (a *Api) fromNode(c *gin.Context) bool {
+	if c.Request.TLS == nil {
+		return false
+	}
+
+	return authentication.NewUser(c.Request.TLS).Node
+}
+
+// diff compares definition against the stored one, spec values of sealed kinds are redacted to keep secrets out of the trail
+func (a *Api) diff(ctx context.Context, kind string, group string, name string, definition []byte) json.RawMessage {
+	existing := []byte("{}")
+
+	if a.Etcd != nil {
+		format := f.New(static.SMR_PREFIX, static.CATEGORY_KIND, kind, group, name)
+		opts := f.DefaultToStringOpts()
+		opts.AddPrefixSlash = true
+
+		response, err := a.Etcd.Get(ctx, format.ToStringWithOpts(opts))
+
+		if err == nil && len(response.Kvs) > 0 {
+			existing = unseal(static.CATEGORY_KIND, kind, response.Kvs[0].Value)
+		}
+	}
+
+	patch, err := jsondiff.CompareJSON(desired(existing), desired(definition))
+
+	if err != nil {
+		return nil
+	}
+
+	changes := make(jsondiff.Patch, 0)
+	sealed := definitions.IsSealed(kind)
+
+	for _, operation := range patch {
+		if sealed && strings.HasPrefix(operation.Path, "/spec") && operation.Value != nil {
+			operation.Value = audit.REDACTED
+		}
+
+		changes = append(changes, operation)
+	}
+
+	if len(changes) == 0 {
+		return nil
+	}
+
+	bytes, err := json.Marshal(changes)
+
+	if err != nil {
+		return nil
+	}
+
+	return bytes
+}
+
+// desired strips runtime and state which are maintained by the cluster and aren't what user changed
+func desired(definition []byte) []byte {
+	object := make(map[string]any)
+
+	if json.Unmarshal(definition, &object) != nil {
+		return definition
+	}
+
+	delete(object, "state")
+
+	if meta, ok := object["meta"].(map[string]any); ok {
+		delete(meta, "runtime")
+	}
+
+	bytes, err := json.Marshal(object)
+
+	if err != nil {
+		return definition
+	}
+
+	return bytes
+}
+
+func parseSince(since string, now time.Time) (time.Time, error) {
+	duration, err := time.ParseDuration(since)
+
+	if err == nil {
+		return now.Add(-duration), nil
+	}
+
+	return time.Parse(time.RFC3339, since)
+}
@@ -0,0 +1,37 @@
+package api
+
+import (
+	"context"
+	"github.com/simplecontainer/smr/pkg/audit"
+	"github.com/simplecontainer/smr/pkg/static"
+	"github.com/stretchr/testify/assert"
+	"testing"
+	"time"
+)
+
+func TestDiff(t *testing.T) {
+	a := &Api{}
+
+	secret := a.diff(context.Background(), static.KIND_SECRET, "test", "db", []byte(`{"meta":{"name":"db","runtime":{"node":1}},"spec":{"data":{"password":"s3cr3t"}}}`))
+	assert.NotContains(t, string(secret), "s3cr3t")
+	assert.Contains(t, string(secret), audit.REDACTED)
+	assert.NotContains(t, string(secret), "/meta/runtime")
+
+	configuration := a.diff(context.Background(), static.KIND_CONFIGURATION, "test", "db", []byte(`{"spec":{"data":{"host":"db.private"}}}`))
+	assert.Contains(t, string(configuration), "db.private")
+}
+
+func TestParseSince(t *testing.T) {
+	now := time.Date(2025, 1, 2, 12, 0, 0, 0, time.UTC)
+
+	since, err := parseSince("24h", now)
+	assert.NoError(t, err)
+	assert.Equal(t, now.Add(-24*time.Hour), since)
+
+	since, err = parseSince("2025-01-01T00:00:00Z", now)
+	assert.NoError(t, err)
+	assert.Equal(t, time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC), since)
+
+	_, err = parseSince("yesterday", now)
+	assert.Error(t, err)
+}
@@ -0,0 +1,281 @@
+package api
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"github.com/gin-gonic/gin"
+	"github.com/simplecontainer/smr/pkg/authentication"
+	"github.com/simplecontainer/smr/pkg/clients"
+	"github.com/simplecontainer/smr/pkg/keys"
+	"github.com/simplecontainer/smr/pkg/kinds/common"
+	"github.com/simplecontainer/smr/pkg/logger"
+	"github.com/simplecontainer/smr/pkg/metrics"
+	"github.com/simplecontainer/smr/pkg/network"
+	"github.com/simplecontainer/smr/pkg/static"
+	"go.uber.org/zap"
+	"io"
+	"net/http"
+	"syscall"
+	"time"
+)
+
+const PHASE_STAGE = "stage"
+const PHASE_PROMOTE = "promote"
+const PHASE_FINISH = "finish"
+
+// RenewCertificates runs on every node and re-signs its server and client certificate once two thirds of validity passed
+func (a *Api) RenewCertificates() {
+	ticker := time.NewTicker(time.Minute)
+	defer ticker.Stop()
+
+	a.checkCertificates()
+
+	for range ticker.C {
+		a.checkCertificates()
+	}
+}
+
+func (a *Api) checkCertificates() {
+	now := time.Now()
+	client := a.Keys.Clients[a.Config.NodeName]
+
+	if keys.Renewable(a.Keys.Server.Certificate, now) || (client != nil && keys.Renewable(client.Certificate, now)) {
+		err := a.renewCertificates()
+
+		if err != nil {
+			logger.Log.Error("failed to renew certificates", zap.Error(err))
+		} else {
+			logger.Log.Info("renewed node certificates", zap.Time("notAfter", a.Keys.Server.Certificate.NotAfter))
+		}
+	}
+
+	a.exportCertificates()
+}
+
+// renewCertificates signs node certificates with the current CA, keys stay the same so contexts and peers keep working
+func (a *Api) renewCertificates() error {
+	ca := a.Keys.GetTrust().CA
+
+	err := a.Keys.Server.Renew(ca, a.Config.Certificates.Expiry)
+
+	if err != nil {
+		return err
+	}
+
+	err = a.Keys.Server.Write(static.SMR_SSH_HOME, a.Config.NodeName)
+
+	if err != nil {
+		return err
+	}
+
+	client, ok := a.Keys.Clients[a.Config.NodeName]
+
+	if ok {
+		err = client.Renew(ca, a.Config.Certificates.Expiry)
+
+		if err != nil {
+			return err
+		}
+
+		err = client.Write(static.SMR_SSH_HOME, a.Config.NodeName)
+
+		if err != nil {
+			return err
+		}
+
+		err = a.Keys.GeneratePemBundle(static.SMR_SSH_HOME, a.Config.NodeName, client)
+
+		if err != nil {
+			return err
+		}
+	}
+
+	a.Keys.Reloader.ReloadC <- syscall.SIGHUP
+
+	a.Manager.Http, err = clients.GenerateHttpClients(a.Keys, a.Config.HostPort, a.Cluster)
+	return err
+}
+
+func (a *Api) exportCertificates() {
+	metrics.CertificateExpiry.Get().Reset()
+
+	for _, info := range a.Keys.Info(a.Config.NodeName) {
+		metrics.CertificateExpiry.Set(float64(info.NotAfter.Unix()), info.Node, info.Type, info.Name, info.Status)
+	}
+}
+
+// ListCertificates returns certificates of every node, the local query only answers for this node
+func (a *Api) ListCertificates(c *gin.Context) {
+	infos := a.Keys.Info(a.Config.NodeName)
+
+	if c.Query("local") == "" && a.Cluster != nil && a.Cluster.Started {
+		for _, n := range a.Cluster.Cluster.Nodes {
+			if n.NodeID == a.Cluster.Node.NodeID {
+				continue
+			}
+
+			response := network.Send(
+				a.Manager.Http.Clients[a.Manager.User.Username].Http,
+				fmt.Sprintf("%s/api/v1/cluster/certificates?local=true", n.API),
+				http.MethodGet,
+				nil,
+			)
+
+			remote := make([]keys.Info, 0)
+
+			if !response.Success || json.Unmarshal(response.Data, &remote) != nil {
+				logger.Log.Error("failed to fetch certificates from node", zap.String("node", n.NodeName), zap.String("error", response.ErrorExplanation))
+				continue
+			}
+
+			infos = append(infos, remote...)
+		}
+	}
+
+	data, err := json.Marshal(infos)
+
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, common.Response(http.StatusInternalServerError, "", err, nil))
+		return
+	}
+
+	c.JSON(http.StatusOK, common.Response(http.StatusOK, "", nil, data))
+}
+
+// RolloverCA moves CA rollover to the next phase on every node: stage trusts the new CA, promote signs with it
+// and renews node certificates, finish stops trusting the old CA
+func (a *Api) RolloverCA(c *gin.Context) {
+	if a.Cluster == nil || !a.Cluster.Started {
+		c.JSON(http.StatusBadRequest, common.Response(http.StatusBadRequest, "", errors.New("cluster is not started"), nil))
+		return
+	}
+
+	var err error
+
+	switch c.Param("phase") {
+	case PHASE_STAGE:
+		err = a.Keys.StageCA()
+	case PHASE_PROMOTE:
+		err = a.Keys.PromoteCA()
+	case PHASE_FINISH:
+		err = a.Keys.FinishCA()
+	default:
+		err = fmt.Errorf("unknown phase %s, expected one of: %s, %s, %s", c.Param("phase"), PHASE_STAGE, PHASE_PROMOTE, PHASE_FINISH)
+	}
+
+	if err != nil {
+		c.JSON(http.StatusBadRequest, common.Response(http.StatusBadRequest, "", err, nil))
+		return
+	}
+
+	err = a.applyTrust(c.Param("phase") == PHASE_PROMOTE)
+
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, common.Response(http.StatusInternalServerError, "failed to apply rollover locally", err, nil))
+		return
+	}
+
+	err = a.distributeTrust()
+
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, common.Response(http.StatusInternalServerError, "failed to apply rollover on all nodes, repeat the phase once nodes are reachable", err, nil))
+		return
+	}
+
+	explanation := map[string]string{
+		PHASE_STAGE:   "new CA is staged and trusted on every node, run promote to start signing with it",
+		PHASE_PROMOTE: "new CA is signing and node certificates are renewed, run finish once user certificates are renewed with smrctl users renew",
+		PHASE_FINISH:  "old CA is no longer trusted, rollover is finished",
+	}
+
+	c.JSON(http.StatusOK, common.Response(http.StatusOK, explanation[c.Param("phase")], nil, nil))
+}
+
+// SyncCertificates accepts CAs from the node running rollover
+func (a *Api) SyncCertificates(c *gin.Context) {
+	user := authentication.NewUser(c.Request.TLS)
+
+	if !user.Node {
+		c.JSON(http.StatusForbidden, common.Response(http.StatusForbidden, "forbidden", errors.New("only cluster nodes can share certificate authorities"), nil))
+		return
+	}
+
+	data, err := io.ReadAll(c.Request.Body)
+
+	if err != nil {
+		c.JSON(http.StatusBadRequest, common.Response(http.StatusBadRequest, "", err, nil))
+		return
+	}
+
+	trust := &keys.Trust{}
+
+	if err = json.Unmarshal(data, trust); err != nil {
+		c.JSON(http.StatusBadRequest, common.Response(http.StatusBadRequest, "invalid certificate authorities", err, nil))
+		return
+	}
+
+	changed, err := a.Keys.SetTrust(trust)
+
+	if err == nil {
+		err = a.applyTrust(changed)
+	}
+
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, common.Response(http.StatusInternalServerError, "failed to apply certificate authorities", err, nil))
+		return
+	}
+
+	c.JSON(http.StatusOK, common.Response(http.StatusOK, "certificate authorities synced", nil, nil))
+}
+
+// applyTrust persists CAs and renews node certificates when signing CA changed
+func (a *Api) applyTrust(renew bool) error {
+	err := a.Keys.WriteTrust(static.SMR_SSH_HOME)
+
+	if err != nil {
+		return err
+	}
+
+	if renew {
+		err = a.renewCertificates()
+	} else {
+		a.Manager.Http, err = clients.GenerateHttpClients(a.Keys, a.Config.HostPort, a.Cluster)
+	}
+
+	if err != nil {
+		return err
+	}
+
+	a.exportCertificates()
+	return nil
+}
+
+func (a *Api) distributeTrust() error {
+	data, err := json.Marshal(a.Keys.GetTrust())
+
+	if err != nil {
+		return err
+	}
+
+	errs := make([]error, 0)
+
+	for _, n := range a.Cluster.Cluster.Nodes {
+		if n.NodeID == a.Cluster.Node.NodeID {
+			continue
+		}
+
+		response := network.Send(
+			a.Manager.Http.Clients[a.Manager.User.Username].Http,
+			fmt.Sprintf("%s/api/v1/cluster/certificates", n.API),
+			http.MethodPost,
+			data,
+		)
+
+		if !response.Success {
+			errs = append(errs, fmt.Errorf("%s: %s", n.NodeName, response.ErrorExplanation))
+		}
+	}
+
+	return errors.Join(errs...)
+}
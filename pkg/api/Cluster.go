@@ -1,8 +1,6 @@
 package api
 
 import (
-	"crypto/tls"
-	"crypto/x509"
 	"encoding/json"
 	"errors"
 	"fmt"
@@ -81,6 +79,7 @@ func (a *Api) StartCluster(c *gin.Context) {
 		a.Cluster = cluster.New()
 		a.Cluster.Node = a.Cluster.Cluster.NewNode(a.Config.NodeName, parsed.String(), fmt.Sprintf("https://%s:%s", parsed.Hostname(), a.Config.HostPort.Port))
 		a.Cluster.Node.Version = a.Version
+		a.Cluster.Node.Labels = a.Config.Labels
 
 		a.Cluster.Cluster.Add(a.Cluster.Node)
 
@@ -91,6 +90,8 @@ func (a *Api) StartCluster(c *gin.Context) {
 		}
 	} else {
 		a.Cluster.Node.State.ResetControl()
+		a.Cluster.Node.Resources = node.NewResources()
+		a.Cluster.Node.Labels = a.Config.Labels
 		a.Cluster.Node.Version = a.Version
 		peers = a.Cluster.Peers()
 	}
@@ -164,14 +165,7 @@ func (a *Api) StartCluster(c *gin.Context) {
 
 	a.Manager.Cluster = a.Cluster
 
-	CAPool := x509.NewCertPool()
-	CAPool.AddCert(a.Keys.CA.Certificate)
-
-	tlsConfig := &tls.Config{
-		ClientAuth:     tls.RequireAndVerifyClientCert,
-		ClientCAs:      CAPool,
-		GetCertificate: a.Keys.Reloader.GetCertificateFunc(),
-	}
+	tlsConfig := a.Keys.TLSConfig(a.Revocations.VerifyPeerCertificate)
 
 	a.Cluster.Regenerate(a.Config, a.Keys)
 	a.Keys.Reloader.ReloadC <- syscall.SIGHUP
@@ -196,7 +190,10 @@ func (a *Api) StartCluster(c *gin.Context) {
 	a.SaveClusterConfiguration()
 
 	go a.ListenNode()
+	go a.MonitorNodes()
+	go a.RenewCertificates()
 	go events.Listen(a.Manager.KindsRegistry, a.Replication.EventsC, a.Replication.Informer, a.Wss)
+	go a.SeedPoliciesOnLeader()
 
 	err = flannel.Setup(c, a.Etcd, cmd.Data()["cidr"], cmd.Data()["backend"])
 
@@ -5,13 +5,17 @@ import (
 	"encoding/json"
 	"fmt"
 	"github.com/gin-gonic/gin"
+	"github.com/simplecontainer/smr/pkg/audit"
 	"github.com/simplecontainer/smr/pkg/control"
 	"github.com/simplecontainer/smr/pkg/kinds/common"
 	"github.com/simplecontainer/smr/pkg/logger"
 	"github.com/simplecontainer/smr/pkg/network"
+	"github.com/simplecontainer/smr/pkg/static"
 	"go.uber.org/zap"
 	"io"
 	"net/http"
+	"strconv"
+	"strings"
 )
 
 func (a *Api) Control(c *gin.Context) {
@@ -30,6 +34,19 @@ func (a *Api) Control(c *gin.Context) {
 		return
 	}
 
+	names := make([]string, 0)
+
+	for _, cmd := range batch.GetCommands() {
+		names = append(names, cmd.Name())
+	}
+
+	entry := audit.Entry{
+		Action: "control",
+		Kind:   static.KIND_NODE,
+		Name:   strconv.FormatUint(batch.GetNodeID(), 10),
+		Detail: strings.Join(names, ","),
+	}
+
 	if a.Cluster.Node.NodeID == batch.GetNodeID() {
 		go func() {
 			for _, cmd := range batch.GetCommands() {
@@ -53,11 +70,17 @@ func (a *Api) Control(c *gin.Context) {
 			logger.Log.Info("control batch finished with success")
 		}()
 
+		entry.Status = http.StatusOK
+		a.record(c, entry)
+
 		c.JSON(http.StatusOK, common.Response(http.StatusOK, "controls batch applied", nil, nil))
 	} else {
 		target := a.Cluster.Cluster.FindById(batch.GetNodeID())
 
 		if target == nil {
+			entry.Status, entry.Error = http.StatusNotFound, "node not found"
+			a.record(c, entry)
+
 			c.JSON(http.StatusNotFound, common.Response(http.StatusNotFound, "node not found", nil, nil))
 			return
 		}
@@ -69,6 +92,9 @@ func (a *Api) Control(c *gin.Context) {
 			data,
 		)
 
+		entry.Status, entry.Error = response.HttpStatus, response.ErrorExplanation
+		a.record(c, entry)
+
 		c.JSON(response.HttpStatus, response)
 	}
 }
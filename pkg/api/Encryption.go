@@ -0,0 +1,262 @@
+package api
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"github.com/gin-gonic/gin"
+	"github.com/simplecontainer/smr/pkg/authentication"
+	"github.com/simplecontainer/smr/pkg/definitions"
+	"github.com/simplecontainer/smr/pkg/encrypt"
+	"github.com/simplecontainer/smr/pkg/f"
+	"github.com/simplecontainer/smr/pkg/kinds/common"
+	"github.com/simplecontainer/smr/pkg/logger"
+	"github.com/simplecontainer/smr/pkg/network"
+	"github.com/simplecontainer/smr/pkg/static"
+	"go.etcd.io/etcd/api/v3/mvccpb"
+	clientv3 "go.etcd.io/etcd/client/v3"
+	"go.uber.org/zap"
+	"io"
+	"net/http"
+	"time"
+)
+
+// RotateEncryption stages new cluster key on every node, promotes it and re-encrypts stored objects
+func (a *Api) RotateEncryption(c *gin.Context) {
+	if a.Cluster == nil || !a.Cluster.Started {
+		c.JSON(http.StatusBadRequest, common.Response(http.StatusBadRequest, "", errors.New("cluster is not started"), nil))
+		return
+	}
+
+	if encrypt.Ring == nil {
+		c.JSON(http.StatusInternalServerError, common.Response(http.StatusInternalServerError, "", errors.New("encryption at rest is not enabled"), nil))
+		return
+	}
+
+	id, err := encrypt.Ring.Add()
+
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, common.Response(http.StatusInternalServerError, "failed to generate key", err, nil))
+		return
+	}
+
+	// Every node must be able to open envelopes sealed with the new key before anyone starts sealing with it
+	err = a.distributeEncryption()
+
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, common.Response(http.StatusInternalServerError, "failed to stage key on all nodes, key is not promoted", err, nil))
+		return
+	}
+
+	err = encrypt.Ring.Promote(id)
+
+	if err == nil {
+		err = a.distributeEncryption()
+	}
+
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, common.Response(http.StatusInternalServerError, "failed to promote key on all nodes", err, nil))
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+	defer cancel()
+
+	count, err := a.reencrypt(ctx)
+
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, common.Response(http.StatusInternalServerError, "failed to re-encrypt objects", err, nil))
+		return
+	}
+
+	c.JSON(http.StatusOK, common.Response(http.StatusOK, fmt.Sprintf("encryption key %s is primary, re-encrypted %d objects", id, count), nil, nil))
+}
+
+// SyncEncryption accepts key ring from the node running rotation and re-encrypts local store if primary changed
+func (a *Api) SyncEncryption(c *gin.Context) {
+	user := authentication.NewUser(c.Request.TLS)
+
+	if !user.Node {
+		c.JSON(http.StatusForbidden, common.Response(http.StatusForbidden, "forbidden", errors.New("only cluster nodes can share encryption keys"), nil))
+		return
+	}
+
+	if encrypt.Ring == nil {
+		c.JSON(http.StatusInternalServerError, common.Response(http.StatusInternalServerError, "", errors.New("encryption at rest is not enabled"), nil))
+		return
+	}
+
+	data, err := io.ReadAll(c.Request.Body)
+
+	if err != nil {
+		c.JSON(http.StatusBadRequest, common.Response(http.StatusBadRequest, "", err, nil))
+		return
+	}
+
+	ring := encrypt.NewKeyRing()
+
+	if err = json.Unmarshal(data, ring); err != nil {
+		c.JSON(http.StatusBadRequest, common.Response(http.StatusBadRequest, "invalid key ring", err, nil))
+		return
+	}
+
+	encrypt.Ring.Merge(ring)
+
+	if err = encrypt.Ring.Write(static.SMR_SSH_HOME); err != nil {
+		c.JSON(http.StatusInternalServerError, common.Response(http.StatusInternalServerError, "failed to persist key ring", err, nil))
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+	defer cancel()
+
+	count, err := a.reencrypt(ctx)
+
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, common.Response(http.StatusInternalServerError, "failed to re-encrypt objects", err, nil))
+		return
+	}
+
+	c.JSON(http.StatusOK, common.Response(http.StatusOK, fmt.Sprintf("key ring synced, re-encrypted %d objects", count), nil, nil))
+}
+
+// distributeEncryption persists the ring locally and pushes it to other nodes over mTLS so keys never enter raft
+func (a *Api) distributeEncryption() error {
+	err := encrypt.Ring.Write(static.SMR_SSH_HOME)
+
+	if err != nil {
+		return err
+	}
+
+	data, err := json.Marshal(encrypt.Ring)
+
+	if err != nil {
+		return err
+	}
+
+	errs := make([]error, 0)
+
+	for _, n := range a.Cluster.Cluster.Nodes {
+		if n.NodeID == a.Cluster.Node.NodeID {
+			continue
+		}
+
+		response := network.Send(
+			a.Manager.Http.Clients[a.Manager.User.Username].Http,
+			fmt.Sprintf("%s/api/v1/cluster/encryption", n.API),
+			http.MethodPost,
+			data,
+		)
+
+		if !response.Success {
+			errs = append(errs, fmt.Errorf("%s: %s", n.NodeName, response.ErrorExplanation))
+		}
+	}
+
+	return errors.Join(errs...)
+}
+
+// reencrypt seals objects of sealed kinds in the local store with the primary key, plaintext ones included
+func (a *Api) reencrypt(ctx context.Context) (int, error) {
+	count := 0
+
+	for kind := range a.KindsRegistry {
+		if !definitions.IsSealed(kind) {
+			continue
+		}
+
+		for _, category := range []string{static.CATEGORY_KIND, static.CATEGORY_REVISION} {
+			format := f.New(static.SMR_PREFIX, category, kind)
+			opts := f.DefaultToStringOpts()
+			opts.AddPrefixSlash = true
+			opts.AddTrailingSlash = true
+
+			response, err := a.Etcd.Get(ctx, format.ToStringWithOpts(opts), clientv3.WithPrefix())
+
+			if err != nil {
+				return count, err
+			}
+
+			for _, kv := range response.Kvs {
+				var resealed []byte
+
+				if category == static.CATEGORY_REVISION {
+					resealed, err = transformHistory(kind, kv.Value, definitions.Reseal)
+				} else {
+					resealed, err = definitions.Reseal(kind, kv.Value, encrypt.Ring)
+				}
+
+				if err != nil {
+					return count, fmt.Errorf("%s: %w", string(kv.Key), err)
+				}
+
+				if bytes.Equal(resealed, kv.Value) {
+					continue
+				}
+
+				if _, err = a.Etcd.Put(ctx, string(kv.Key), string(resealed)); err != nil {
+					return count, err
+				}
+
+				count++
+			}
+		}
+	}
+
+	return count, nil
+}
+
+// unseal opens payload read from the store so clients and kinds only see plaintext
+func unseal(category string, kind string, value []byte) []byte {
+	var opened []byte
+	var err error
+
+	switch category {
+	case static.CATEGORY_KIND:
+		opened, err = definitions.Open(kind, value, encrypt.Ring)
+	case static.CATEGORY_REVISION:
+		opened, err = transformHistory(kind, value, definitions.Open)
+	default:
+		return value
+	}
+
+	if err != nil {
+		logger.Log.Error("failed to decrypt object", zap.String("kind", kind), zap.Error(err))
+		return value
+	}
+
+	return opened
+}
+
+func unsealAll(category string, kind string, kvs []*mvccpb.KeyValue) {
+	for _, kv := range kvs {
+		kv.Value = unseal(category, kind, kv.Value)
+	}
+}
+
+// transformHistory applies fn on every revision since history holds definitions sealed one by one
+func transformHistory(kind string, value []byte, fn func(string, []byte, *encrypt.KeyRing) ([]byte, error)) ([]byte, error) {
+	if !definitions.IsSealed(kind) {
+		return value, nil
+	}
+
+	history := make([]common.Revision, 0)
+
+	if err := json.Unmarshal(value, &history); err != nil {
+		return nil, err
+	}
+
+	for i := range history {
+		transformed, err := fn(kind, history[i].Definition, encrypt.Ring)
+
+		if err != nil {
+			return nil, err
+		}
+
+		history[i].Definition = transformed
+	}
+
+	return json.Marshal(history)
+}
@@ -7,6 +7,7 @@ import (
 	"github.com/gin-gonic/gin"
 	"github.com/gorilla/websocket"
 	"github.com/mattn/go-shellwords"
+	"github.com/simplecontainer/smr/pkg/audit"
 	"github.com/simplecontainer/smr/pkg/clients"
 	"github.com/simplecontainer/smr/pkg/exec"
 	"github.com/simplecontainer/smr/pkg/f"
@@ -20,6 +21,7 @@ import (
 	"net/http"
 	"strconv"
 	"strings"
+	"time"
 )
 
 var wssUpgrader = websocket.Upgrader{
@@ -49,6 +51,19 @@ func (a *Api) Exec(c *gin.Context) {
 
 	format := f.New(prefix, version, category, kind, group, name)
 
+	// Recorded once session ends so result reflects how exec finished
+	entry := audit.Entry{
+		Time:   time.Now().UTC(),
+		Action: "exec",
+		Kind:   kind,
+		Group:  group,
+		Name:   name,
+		Detail: fmt.Sprintf("command=%s interactive=%t", command, interactive),
+		Status: http.StatusOK,
+	}
+
+	defer func() { a.record(c, entry) }()
+
 	logger.Log.Info("exec request initiated",
 		zap.String("container", format.ToString()),
 		zap.String("command", command),
@@ -57,6 +72,7 @@ func (a *Api) Exec(c *gin.Context) {
 	conn, err := wssUpgrader.Upgrade(c.Writer, c.Request, nil)
 	if err != nil {
 		logger.Log.Error("failed to upgrade websocket", zap.Error(err))
+		entry.Status, entry.Error = http.StatusInternalServerError, err.Error()
 		c.JSON(http.StatusInternalServerError, gin.H{"error": "failed to upgrade websocket"})
 		return
 	}
@@ -65,6 +81,7 @@ func (a *Api) Exec(c *gin.Context) {
 	container := a.KindsRegistry[static.KIND_CONTAINERS].GetShared().(*shared.Shared).Registry.Find(static.SMR_PREFIX, group, name)
 	if container == nil {
 		logger.Log.Warn("container not found", zap.String("container", fmt.Sprintf("%s/%s", group, name)))
+		entry.Status, entry.Error = http.StatusNotFound, "container not found"
 		sendWebSocketTextAndClose(conn, "container not found")
 		return
 	}
@@ -72,6 +89,7 @@ func (a *Api) Exec(c *gin.Context) {
 	if container.IsGhost() {
 		httpClient, ok := a.Manager.Http.Clients[container.GetRuntime().Node.NodeName]
 		if !ok {
+			entry.Status, entry.Error = http.StatusNotFound, "container node not found"
 			sendWebSocketTextAndClose(conn, fmt.Sprintf("node for %s '%s/%s' not found", static.KIND_CONTAINERS, group, name))
 			return
 		}
@@ -81,6 +99,7 @@ func (a *Api) Exec(c *gin.Context) {
 		err = remoteExec(c, conn, url, httpClient)
 
 		if err != nil && !errors.Is(err, io.EOF) {
+			entry.Error = err.Error()
 			logger.Log.Debug("remote exec closed with error", zap.Error(err))
 		} else {
 			logger.Log.Debug("remote exec closed with success")
@@ -89,6 +108,7 @@ func (a *Api) Exec(c *gin.Context) {
 		err = localExec(c, conn, container, command, interactive, height, width)
 
 		if err != nil && !errors.Is(err, io.EOF) {
+			entry.Error = err.Error()
 			logger.Log.Debug("local exec session closed with error", zap.Error(err))
 		} else {
 			logger.Log.Debug("local exec session closed with success")
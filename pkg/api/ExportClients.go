@@ -3,10 +3,13 @@ package api
 import (
 	"encoding/hex"
 	"encoding/json"
+	"errors"
 	"github.com/gin-gonic/gin"
+	"github.com/simplecontainer/smr/pkg/authentication"
 	"github.com/simplecontainer/smr/pkg/contracts/iresponse"
 	"github.com/simplecontainer/smr/pkg/encrypt"
 	"github.com/simplecontainer/smr/pkg/keys"
+	"github.com/simplecontainer/smr/pkg/kinds/common"
 	"github.com/simplecontainer/smr/pkg/network"
 	"net/http"
 )
@@ -54,3 +57,35 @@ func (a *Api) ExportClients(c *gin.Context) {
 		Data:             network.ToJSON(encrypted),
 	})
 }
+
+// ExportEncryption hands the key ring over to the node joining the cluster, clients exports never carry it
+func (a *Api) ExportEncryption(c *gin.Context) {
+	user := authentication.NewUser(c.Request.TLS)
+
+	if !user.Node {
+		c.JSON(http.StatusForbidden, common.Response(http.StatusForbidden, "forbidden", errors.New("only cluster nodes can fetch encryption keys"), nil))
+		return
+	}
+
+	if a.Keys.Encryption == nil {
+		c.JSON(http.StatusNotFound, common.Response(http.StatusNotFound, "", errors.New("encryption at rest is not enabled"), nil))
+		return
+	}
+
+	bytes, err := json.Marshal(a.Keys.Encryption)
+
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, common.Response(http.StatusInternalServerError, "", err, nil))
+		return
+	}
+
+	var ciphertext string
+	ciphertext, err = encrypt.Encrypt(string(bytes), hex.EncodeToString(a.Keys.Clients[a.User.Username].PrivateKeyBytes[:32]))
+
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, common.Response(http.StatusInternalServerError, "", err, nil))
+		return
+	}
+
+	c.JSON(http.StatusOK, common.Response(http.StatusOK, "encryption keys exported with success", nil, network.ToJSON(keys.Encrypted{Keys: ciphertext})))
+}
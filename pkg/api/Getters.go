@@ -1,6 +1,8 @@
 package api
 
 import (
+	"github.com/simplecontainer/smr/pkg/admission"
+	"github.com/simplecontainer/smr/pkg/audit"
 	"github.com/simplecontainer/smr/pkg/authentication"
 	"github.com/simplecontainer/smr/pkg/cluster"
 	"github.com/simplecontainer/smr/pkg/configuration"
@@ -10,6 +12,7 @@ import (
 	"github.com/simplecontainer/smr/pkg/keys"
 	"github.com/simplecontainer/smr/pkg/manager"
 	"github.com/simplecontainer/smr/pkg/relations"
+	"github.com/simplecontainer/smr/pkg/revocation"
 	"github.com/simplecontainer/smr/pkg/version"
 	"github.com/simplecontainer/smr/pkg/wss"
 	clientv3 "go.etcd.io/etcd/client/v3"
@@ -61,3 +64,12 @@ func (a *Api) SetManager(m *manager.Manager) { a.Manager = m }
 
 func (a *Api) GetVersion() *version.Version  { return a.Version }
 func (a *Api) SetVersion(v *version.Version) { a.Version = v }
+
+func (a *Api) GetAuditTrail() *audit.Audit  { return a.AuditTrail }
+func (a *Api) SetAuditTrail(t *audit.Audit) { a.AuditTrail = t }
+
+func (a *Api) GetRevocations() *revocation.List  { return a.Revocations }
+func (a *Api) SetRevocations(r *revocation.List) { a.Revocations = r }
+
+func (a *Api) GetAdmission() *admission.Admission  { return a.Admission }
+func (a *Api) SetAdmission(p *admission.Admission) { a.Admission = p }
@@ -0,0 +1,147 @@
+package api
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"github.com/gin-gonic/gin"
+	"github.com/simplecontainer/smr/pkg/audit"
+	v1 "github.com/simplecontainer/smr/pkg/definitions/v1"
+	"github.com/simplecontainer/smr/pkg/events/events"
+	"github.com/simplecontainer/smr/pkg/f"
+	"github.com/simplecontainer/smr/pkg/kinds/common"
+	"github.com/simplecontainer/smr/pkg/kinds/gitops/webhook"
+	"github.com/simplecontainer/smr/pkg/logger"
+	"github.com/simplecontainer/smr/pkg/secrets"
+	"github.com/simplecontainer/smr/pkg/static"
+	clientv3 "go.etcd.io/etcd/client/v3"
+	"go.uber.org/zap"
+	"io"
+	"net/http"
+	"time"
+)
+
+// GitopsWebhook triggers sync of gitops definitions tracking the pushed branch, polling stays as fallback for
+// missed deliveries
+func (a *Api) GitopsWebhook(c *gin.Context) {
+	if a.Cluster == nil || !a.Cluster.Started || a.Etcd == nil {
+		c.JSON(http.StatusServiceUnavailable, common.Response(http.StatusServiceUnavailable, "", errors.New("cluster is not started"), nil))
+		return
+	}
+
+	body, err := io.ReadAll(io.LimitReader(c.Request.Body, 10<<20))
+
+	if err != nil {
+		c.JSON(http.StatusBadRequest, common.Response(http.StatusBadRequest, "", err, nil))
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(c.Request.Context(), 10*time.Second)
+	defer cancel()
+
+	secret := ""
+
+	if a.Config.Gitops != nil && a.Config.Gitops.WebhookSecret != "" {
+		secret, err = secrets.Value(ctx, a.Config.Gitops.WebhookSecret)
+
+		if err != nil {
+			logger.Log.Error("failed to resolve gitops webhook secret", zap.Error(err))
+			c.JSON(http.StatusInternalServerError, common.Response(http.StatusInternalServerError, "failed to resolve webhook secret", nil, nil))
+			return
+		}
+	}
+
+	push, err := webhook.Parse(c.Request.Header, body, secret, c.Request.TLS != nil)
+
+	if err != nil {
+		switch {
+		case errors.Is(err, webhook.ERROR_INSECURE):
+			c.JSON(http.StatusForbidden, common.Response(http.StatusForbidden, "deliver token authenticated webhook to the TLS listener enabled by port.webhook", err, nil))
+		case errors.Is(err, webhook.ERROR_SIGNATURE):
+			c.JSON(http.StatusUnauthorized, common.Response(http.StatusUnauthorized, "", err, nil))
+		case errors.Is(err, webhook.ERROR_IGNORED):
+			c.JSON(http.StatusOK, common.Response(http.StatusOK, err.Error(), nil, nil))
+		default:
+			c.JSON(http.StatusBadRequest, common.Response(http.StatusBadRequest, "", err, nil))
+		}
+
+		return
+	}
+
+	opts := f.DefaultToStringOpts()
+	opts.AddPrefixSlash = true
+	opts.AddTrailingSlash = true
+
+	response, err := a.Etcd.Get(ctx, f.New(static.SMR_PREFIX, static.CATEGORY_KIND, static.KIND_GITOPS).ToStringWithOpts(opts), clientv3.WithPrefix())
+
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, common.Response(http.StatusInternalServerError, "", err, nil))
+		return
+	}
+
+	synced := make([]string, 0)
+
+	for _, kv := range response.Kvs {
+		definition := v1.GitopsDefinition{}
+
+		if json.Unmarshal(unseal(static.CATEGORY_KIND, static.KIND_GITOPS, kv.Value), &definition) != nil || definition.Spec == nil || definition.Meta == nil {
+			continue
+		}
+
+		if !watches(push, definition.Spec) {
+			continue
+		}
+
+		// Manually synced gitops only fetch the push so drift shows up, same as smrctl refresh
+		event := events.New(events.EVENT_REFRESH, static.KIND_GITOPS, static.SMR_PREFIX, static.KIND_GITOPS, definition.Meta.Group, definition.Meta.Name, nil)
+
+		if definition.Spec.AutomaticSync {
+			event = events.New(events.EVENT_SYNC, static.KIND_GITOPS, static.SMR_PREFIX, static.KIND_GITOPS, definition.Meta.Group, definition.Meta.Name, nil)
+		}
+
+		if err = event.Propose(a.Cluster.KVStore, a.Cluster.Node.NodeID); err != nil {
+			logger.Log.Error("failed to propose gitops sync", zap.String("gitops", common.GroupIdentifier(definition.Meta.Group, definition.Meta.Name)), zap.Error(err))
+			continue
+		}
+
+		synced = append(synced, common.GroupIdentifier(definition.Meta.Group, definition.Meta.Name))
+	}
+
+	a.record(c, audit.Entry{
+		Action: events.EVENT_SYNC,
+		Kind:   static.KIND_GITOPS,
+		User:   fmt.Sprintf("webhook:%s", push.Provider),
+		Detail: fmt.Sprintf("push to %s of %v triggered %v", push.Branch, push.Repositories, synced),
+		Status: http.StatusOK,
+	})
+
+	data, _ := json.Marshal(synced)
+
+	c.JSON(http.StatusOK, common.Response(http.StatusOK, fmt.Sprintf("triggered %d gitops", len(synced)), nil, data))
+}
+
+// watches is true if the push is to the base repository of the gitops or to one of its overlays
+func watches(push *webhook.Push, spec *v1.GitopsSpec) bool {
+	if push.Matches(spec.RepoURL, spec.Revision) {
+		return true
+	}
+
+	for _, overlay := range spec.Overlays {
+		repository, revision := overlay.RepoURL, overlay.Revision
+
+		if repository == "" {
+			repository = spec.RepoURL
+		}
+
+		if revision == "" {
+			revision = spec.Revision
+		}
+
+		if push.Matches(repository, revision) {
+			return true
+		}
+	}
+
+	return false
+}
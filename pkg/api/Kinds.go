@@ -5,10 +5,15 @@ import (
 	"errors"
 	"fmt"
 	"github.com/gin-gonic/gin"
+	"github.com/simplecontainer/smr/pkg/audit"
+	"github.com/simplecontainer/smr/pkg/definitions"
+	"github.com/simplecontainer/smr/pkg/encrypt"
 	"github.com/simplecontainer/smr/pkg/f"
 	"github.com/simplecontainer/smr/pkg/kinds/common"
 	"github.com/simplecontainer/smr/pkg/metrics"
 	"github.com/simplecontainer/smr/pkg/network"
+	"github.com/simplecontainer/smr/pkg/selector"
+	"github.com/simplecontainer/smr/pkg/static"
 	"github.com/wI2L/jsondiff"
 	clientv3 "go.etcd.io/etcd/client/v3"
 	"io"
@@ -56,12 +61,22 @@ func (a *Api) ListKind(c *gin.Context) {
 	kind := c.Param("kind")
 	group := c.Param("group")
 
+	s, ok := parseSelector(c)
+	if !ok {
+		return
+	}
+
 	format := f.New(prefix, version, category, kind, group)
 	opts := f.DefaultToStringOpts()
 	opts.AddPrefixSlash = true
 	opts.AddTrailingSlash = true
 	response, err := a.Etcd.Get(c.Request.Context(), format.ToStringWithOpts(opts), clientv3.WithPrefix(), clientv3.WithSort(clientv3.SortByKey, clientv3.SortAscend))
-	send(c, response, err, nil)
+
+	if err == nil {
+		unsealAll(category, kind, response.Kvs)
+	}
+
+	send(c, s, response, err, nil)
 }
 
 // GetKind godoc
@@ -96,8 +111,14 @@ func (a *Api) GetKind(c *gin.Context) {
 		return
 	}
 
+	value := response.Kvs[0].Value
+
+	if field == "" {
+		value = unseal(category, kind, value)
+	}
+
 	var bytes json.RawMessage
-	bytes, err = json.RawMessage(response.Kvs[0].Value).MarshalJSON()
+	bytes, err = json.RawMessage(value).MarshalJSON()
 	if err != nil {
 		c.JSON(http.StatusInternalServerError, common.Response(http.StatusInternalServerError, "", err, nil))
 		return
@@ -135,6 +156,15 @@ func (a *Api) ProposeKind(c *gin.Context) {
 	name := c.Param("name")
 	field := c.Param("field")
 
+	if category == static.CATEGORY_KIND && field == "" {
+		data, err = definitions.Seal(kind, data, encrypt.Ring)
+
+		if err != nil {
+			c.JSON(http.StatusBadRequest, common.Response(http.StatusBadRequest, "failed to encrypt object", err, nil))
+			return
+		}
+	}
+
 	format := f.New(prefix, version, category, kind, group, name, field)
 	a.Cluster.KVStore.Propose(format.ToStringWithUUID(), data, a.Cluster.Node.NodeID)
 
@@ -170,16 +200,41 @@ func (a *Api) SetKind(c *gin.Context) {
 	name := c.Param("name")
 	field := c.Param("field")
 
+	entry := audit.Entry{Action: "set", Kind: kind, Group: group, Name: name, Detail: category}
+	stored := data
+
+	if category == static.CATEGORY_KIND && field == "" {
+		if !a.fromNode(c) {
+			entry.Diff = a.diff(c.Request.Context(), kind, group, name, data)
+		}
+
+		stored, err = definitions.Seal(kind, data, encrypt.Ring)
+
+		if err != nil {
+			entry.Status, entry.Error = http.StatusInternalServerError, err.Error()
+			a.recordUser(c, entry)
+
+			c.JSON(http.StatusInternalServerError, common.Response(http.StatusInternalServerError, "failed to encrypt object", err, nil))
+			return
+		}
+	}
+
 	format := f.New(prefix, version, category, kind, group, name, field)
 	opts := f.DefaultToStringOpts()
 	opts.AddPrefixSlash = true
-	_, err = a.Etcd.Put(c.Request.Context(), format.ToStringWithOpts(opts), string(data))
+	_, err = a.Etcd.Put(c.Request.Context(), format.ToStringWithOpts(opts), string(stored))
 
 	if err != nil {
+		entry.Status, entry.Error = http.StatusInternalServerError, err.Error()
+		a.recordUser(c, entry)
+
 		c.JSON(http.StatusInternalServerError, common.Response(http.StatusInternalServerError, "", err, nil))
 		return
 	}
 
+	entry.Status = http.StatusOK
+	a.recordUser(c, entry)
+
 	if !a.Cluster.Replay {
 		a.Cluster.KVStore.CommittedKeys.Store(format.ToStringWithOpts(opts), true)
 	}
@@ -215,11 +270,18 @@ func (a *Api) DeleteKind(c *gin.Context) {
 	opts.AddPrefixSlash = true
 	_, err := a.Etcd.Delete(c.Request.Context(), format.ToStringWithOpts(opts))
 
+	entry := audit.Entry{Action: "delete", Kind: kind, Group: group, Name: name, Detail: category, Status: http.StatusOK}
+
 	if err != nil {
+		entry.Status, entry.Error = http.StatusInternalServerError, err.Error()
+		a.recordUser(c, entry)
+
 		c.JSON(http.StatusInternalServerError, common.Response(http.StatusInternalServerError, "", err, nil))
 		return
 	}
 
+	a.recordUser(c, entry)
+
 	c.JSON(http.StatusOK, common.Response(http.StatusOK, "object deleted", nil, nil))
 }
 
@@ -261,7 +323,13 @@ func (a *Api) CompareKind(c *gin.Context) {
 		return
 	}
 
-	changelog, _ := jsondiff.CompareJSON(data, response.Kvs[0].Value)
+	value := response.Kvs[0].Value
+
+	if field == "" {
+		value = unseal(category, kind, value)
+	}
+
+	changelog, _ := jsondiff.CompareJSON(data, value)
 
 	var bytes []byte
 	bytes, err = json.Marshal(changelog)
@@ -274,7 +342,7 @@ func (a *Api) CompareKind(c *gin.Context) {
 	c.JSON(http.StatusOK, common.Response(http.StatusOK, "", nil, bytes))
 }
 
-func send(c *gin.Context, response *clientv3.GetResponse, err error, additionalData interface{}) {
+func send(c *gin.Context, s *selector.Selector, response *clientv3.GetResponse, err error, additionalData interface{}) {
 	if err != nil {
 		c.JSON(http.StatusInternalServerError, common.Response(http.StatusInternalServerError, "", err, nil))
 		return
@@ -285,5 +353,5 @@ func send(c *gin.Context, response *clientv3.GetResponse, err error, additionalD
 		kinds = append(kinds, kv.Value)
 	}
 
-	c.JSON(http.StatusOK, common.Response(http.StatusOK, "", nil, network.ToJSON(kinds)))
+	c.JSON(http.StatusOK, common.Response(http.StatusOK, "", nil, network.ToJSON(filter(s, kinds))))
 }
@@ -0,0 +1,100 @@
+package api
+
+import (
+	"github.com/simplecontainer/smr/pkg/configuration"
+	"github.com/simplecontainer/smr/pkg/events/events"
+	"github.com/simplecontainer/smr/pkg/kinds/node/shared"
+	"github.com/simplecontainer/smr/pkg/logger"
+	"github.com/simplecontainer/smr/pkg/node"
+	"github.com/simplecontainer/smr/pkg/static"
+	"go.uber.org/zap"
+	"time"
+)
+
+// MonitorNodes runs on every node but only the leader acts: it marks members that stopped heartbeating
+// as unreachable so their replicas get rescheduled, and marks them reachable again once they return - reachability
+// is kept in memory only so new leader and new members get unreachable members announced again
+func (a *Api) MonitorNodes() {
+	ticker := time.NewTicker(5 * time.Second)
+	defer ticker.Stop()
+
+	inactive := make(map[uint64]time.Time)
+	members := make(map[uint64]*node.Node)
+
+	for range ticker.C {
+		if a.Cluster.RaftNode == nil || !a.Cluster.RaftNode.IsLeader.Load() {
+			inactive = make(map[uint64]time.Time)
+			members = make(map[uint64]*node.Node)
+			continue
+		}
+
+		current := make(map[uint64]*node.Node)
+		announce := len(members) == 0
+
+		for _, n := range a.Cluster.Cluster.Nodes {
+			current[n.NodeID] = n
+
+			if _, ok := members[n.NodeID]; !ok {
+				announce = true
+			}
+
+			if n.NodeID == a.Cluster.Node.NodeID {
+				continue
+			}
+
+			if !a.Cluster.RaftNode.ActiveSince(n.NodeID).IsZero() {
+				delete(inactive, n.NodeID)
+
+				if !n.Reachable() {
+					a.dispatchNodeEvent(events.EVENT_NODE_REACHABLE, n)
+				}
+
+				continue
+			}
+
+			if _, ok := inactive[n.NodeID]; !ok {
+				inactive[n.NodeID] = time.Now()
+			}
+
+			if n.Reachable() && time.Since(inactive[n.NodeID]) > configuration.Timeout.NodeFailureTimeout {
+				a.dispatchNodeEvent(events.EVENT_NODE_UNREACHABLE, n)
+			}
+		}
+
+		// Members removed from the cluster leave their replicas behind as well
+		for id, n := range members {
+			if _, ok := current[id]; !ok {
+				delete(inactive, id)
+				a.dispatchNodeEvent(events.EVENT_NODE_UNREACHABLE, n)
+			}
+		}
+
+		if announce {
+			a.announceUnreachable()
+		}
+
+		members = current
+	}
+}
+
+// announceUnreachable dispatches unreachable event for every member leader considers unreachable so nodes that
+// missed the original event place replicas the same way
+func (a *Api) announceUnreachable() {
+	for _, n := range a.Cluster.Cluster.Nodes {
+		if !n.Reachable() {
+			a.dispatchNodeEvent(events.EVENT_NODE_UNREACHABLE, n)
+		}
+	}
+}
+
+func (a *Api) dispatchNodeEvent(event string, n *node.Node) {
+	e, err := events.NewNodeEvent(event, n)
+
+	if err != nil {
+		logger.Log.Error("failed to create node event", zap.String("event", event), zap.Error(err))
+		return
+	}
+
+	logger.Log.Info("dispatched node event", zap.String("event", event), zap.Uint64("node", n.NodeID))
+	events.Dispatch(e, a.KindsRegistry[static.KIND_NODE].GetShared().(*shared.Shared), a.Cluster.Node.NodeID)
+}
@@ -1,37 +1,458 @@
 package api
 
 import (
+	"context"
+	"encoding/json"
+	"errors"
 	"fmt"
 	"github.com/gin-gonic/gin"
+	"github.com/simplecontainer/smr/pkg/audit"
 	"github.com/simplecontainer/smr/pkg/authentication"
 	"github.com/simplecontainer/smr/pkg/clients"
+	"github.com/simplecontainer/smr/pkg/f"
+	"github.com/simplecontainer/smr/pkg/keys"
 	"github.com/simplecontainer/smr/pkg/kinds/common"
+	"github.com/simplecontainer/smr/pkg/objects"
+	"github.com/simplecontainer/smr/pkg/rbac"
+	"github.com/simplecontainer/smr/pkg/revocation"
+	"github.com/simplecontainer/smr/pkg/static"
+	clientv3 "go.etcd.io/etcd/client/v3"
 	"net/http"
+	"os"
 	"path/filepath"
+	"slices"
 	"strings"
+	"time"
 )
 
 func (a *Api) CreateUser(c *gin.Context) {
+	username := filepath.Clean(c.Param("username"))
+	entry := audit.Entry{Action: "create", Kind: rbac.KIND_USER, Name: username}
+
+	if a.Cluster == nil || !a.Cluster.Started {
+		c.JSON(http.StatusBadRequest, common.Response(http.StatusBadRequest, "", errors.New("cluster is not started"), nil))
+		return
+	}
+
+	if a.isNodeName(username) || keys.Reserved(username) {
+		entry.Status, entry.Error = http.StatusBadRequest, "reserved username"
+		a.record(c, entry)
+
+		c.JSON(http.StatusBadRequest, common.Response(http.StatusBadRequest, "", fmt.Errorf("username %s is reserved", username), nil))
+		return
+	}
+
+	expiry, err := a.userExpiry(c)
+
+	if err != nil {
+		c.JSON(http.StatusBadRequest, common.Response(http.StatusBadRequest, "invalid expiry", err, nil))
+		return
+	}
+
 	user := authentication.NewUser(c.Request.TLS)
-	path, err := user.CreateUser(a.Keys, a.Config.NodeName, c.Param("username"), c.Param("domain"), c.Param("externalIP"))
+	path, err := user.CreateUser(a.Keys, c.Param("username"), c.Param("domain"), c.Param("externalIP"), expiry)
 
-	if err == nil {
-		var httpClient *http.Client
-		httpClient, err = clients.GenerateHttpClient(a.Keys.CA, a.Keys.Clients[c.Param("username")])
+	if err != nil {
+		entry.Status, entry.Error = http.StatusBadRequest, err.Error()
+		a.record(c, entry)
 
-		if err != nil {
-			c.JSON(http.StatusInternalServerError, common.Response(http.StatusInternalServerError, fmt.Sprintf("failed to create user credentials for: %s", filepath.Clean(c.Param("username"))), nil, nil))
+		c.JSON(http.StatusBadRequest, common.Response(http.StatusBadRequest, fmt.Sprintf("failed to create user credentials for: %s", username), err, nil))
+		return
+	}
+
+	var httpClient *http.Client
+	httpClient, err = clients.GenerateHttpClient(a.Keys.Pool(), a.Keys.Clients[username])
+
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, common.Response(http.StatusInternalServerError, fmt.Sprintf("failed to create user credentials for: %s", username), nil, nil))
+		return
+	}
+
+	a.Manager.Http.Append(username, &clients.Client{
+		API:  fmt.Sprintf("%s:%s", c.Param("domain"), a.Config.HostPort.Port),
+		Http: httpClient,
+	})
+
+	certificate := a.Keys.Clients[username].Certificate
+
+	// Registry is replicated and keeps every serial issued to the user so revoking denies all of them
+	err = a.proposeUsers(revocation.KIND_USERS, username, revocation.Serial(certificate), revocation.Certificate{
+		Username:  username,
+		Serial:    revocation.Serial(certificate),
+		Domain:    c.Param("domain"),
+		Node:      a.Config.NodeName,
+		NotBefore: certificate.NotBefore,
+		NotAfter:  certificate.NotAfter,
+	})
+
+	if err != nil {
+		entry.Status, entry.Error = http.StatusInternalServerError, err.Error()
+		a.record(c, entry)
+
+		c.JSON(http.StatusInternalServerError, common.Response(http.StatusInternalServerError, "failed to replicate user", err, nil))
+		return
+	}
+
+	bundle, err := os.ReadFile(path)
+
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, common.Response(http.StatusInternalServerError, "failed to read user bundle", err, nil))
+		return
+	}
+
+	data, _ := json.Marshal(string(bundle))
+
+	entry.Status = http.StatusOK
+	a.record(c, entry)
+
+	c.JSON(http.StatusOK, common.Response(http.StatusOK, fmt.Sprintf("user created, run: cat %s", strings.Replace(path, a.Config.Environment.Container.Home, "$HOME", 1)), nil, data))
+}
+
+// RenewUser reissues the user certificate with the signing CA keeping the same key, so users can move off the CA
+// retired by rollover before it stops being trusted, certificate can only be renewed on the node that issued it
+func (a *Api) RenewUser(c *gin.Context) {
+	username := filepath.Clean(c.Param("username"))
+	entry := audit.Entry{Action: "renew", Kind: rbac.KIND_USER, Name: username}
+
+	if a.Cluster == nil || !a.Cluster.Started {
+		c.JSON(http.StatusBadRequest, common.Response(http.StatusBadRequest, "", errors.New("cluster is not started"), nil))
+		return
+	}
+
+	if a.isNodeName(username) || keys.Reserved(username) {
+		c.JSON(http.StatusBadRequest, common.Response(http.StatusBadRequest, "", fmt.Errorf("username %s is reserved, node certificates are renewed by the nodes", username), nil))
+		return
+	}
+
+	expiry, err := a.userExpiry(c)
+
+	if err != nil {
+		c.JSON(http.StatusBadRequest, common.Response(http.StatusBadRequest, "invalid expiry", err, nil))
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(c.Request.Context(), 5*time.Second)
+	defer cancel()
+
+	certificates, err := a.users(ctx)
+
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, common.Response(http.StatusInternalServerError, "", err, nil))
+		return
+	}
+
+	var issued *revocation.Certificate
+
+	for _, certificate := range certificates {
+		if certificate.Username != username {
+			continue
+		}
+
+		// Revoking denies every serial, renewing would bring the user back
+		if certificate.Status == revocation.STATUS_REVOKED {
+			c.JSON(http.StatusBadRequest, common.Response(http.StatusBadRequest, "", fmt.Errorf("user %s is revoked", username), nil))
 			return
 		}
 
-		a.Manager.Http.Append(c.Param("username"), &clients.Client{
-			API:  fmt.Sprintf("%s:%s", c.Param("domain"), a.Config.HostPort.Port),
-			Http: httpClient,
+		issued = certificate
+	}
+
+	client, ok := a.Keys.Clients[username]
+
+	if issued == nil || !ok {
+		if issued != nil {
+			err = fmt.Errorf("user %s was issued on node %s, renew it there", username, issued.Node)
+		} else {
+			err = errors.New(static.USER_NOT_FOUND)
+		}
+
+		c.JSON(http.StatusNotFound, common.Response(http.StatusNotFound, "", err, nil))
+		return
+	}
+
+	err = a.renewUser(username, client, expiry)
+
+	if err != nil {
+		entry.Status, entry.Error = http.StatusInternalServerError, err.Error()
+		a.record(c, entry)
+
+		c.JSON(http.StatusInternalServerError, common.Response(http.StatusInternalServerError, fmt.Sprintf("failed to renew user credentials for: %s", username), err, nil))
+		return
+	}
+
+	err = a.proposeUsers(revocation.KIND_USERS, username, revocation.Serial(client.Certificate), revocation.Certificate{
+		Username:  username,
+		Serial:    revocation.Serial(client.Certificate),
+		Domain:    issued.Domain,
+		Node:      a.Config.NodeName,
+		NotBefore: client.Certificate.NotBefore,
+		NotAfter:  client.Certificate.NotAfter,
+	})
+
+	if err != nil {
+		entry.Status, entry.Error = http.StatusInternalServerError, err.Error()
+		a.record(c, entry)
+
+		c.JSON(http.StatusInternalServerError, common.Response(http.StatusInternalServerError, "failed to replicate user", err, nil))
+		return
+	}
+
+	bundle, err := os.ReadFile(fmt.Sprintf("%s/%s.pem", static.SMR_SSH_HOME, username))
+
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, common.Response(http.StatusInternalServerError, "failed to read user bundle", err, nil))
+		return
+	}
+
+	data, _ := json.Marshal(string(bundle))
+
+	entry.Status = http.StatusOK
+	a.record(c, entry)
+
+	c.JSON(http.StatusOK, common.Response(http.StatusOK, fmt.Sprintf("user %s renewed, certificate is valid until %s", username, client.Certificate.NotAfter.Format(time.RFC3339)), nil, data))
+}
+
+func (a *Api) renewUser(username string, client *keys.Client, expiry time.Duration) error {
+	err := client.Renew(a.Keys.GetTrust().CA, expiry)
+
+	if err != nil {
+		return err
+	}
+
+	err = client.Write(static.SMR_SSH_HOME, username)
+
+	if err != nil {
+		return err
+	}
+
+	err = a.Keys.GeneratePemBundle(static.SMR_SSH_HOME, username, client)
+
+	if err != nil {
+		return err
+	}
+
+	existing, ok := a.Manager.Http.Clients[username]
+
+	if !ok {
+		return nil
+	}
+
+	httpClient, err := clients.GenerateHttpClient(a.Keys.Pool(), client)
+
+	if err != nil {
+		return err
+	}
+
+	a.Manager.Http.Append(username, &clients.Client{
+		API:  existing.API,
+		Http: httpClient,
+	})
+
+	return nil
+}
+
+func (a *Api) userExpiry(c *gin.Context) (time.Duration, error) {
+	if c.Query("expiry") == "" {
+		return a.Config.Certificates.Expiry, nil
+	}
+
+	expiry, err := time.ParseDuration(c.Query("expiry"))
+
+	if err != nil {
+		return 0, err
+	}
+
+	if expiry <= 0 {
+		return 0, errors.New("expiry must be positive")
+	}
+
+	return expiry, nil
+}
+
+func (a *Api) ListUsers(c *gin.Context) {
+	ctx, cancel := context.WithTimeout(c.Request.Context(), 5*time.Second)
+	defer cancel()
+
+	certificates, err := a.users(ctx)
+
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, common.Response(http.StatusInternalServerError, "", err, nil))
+		return
+	}
+
+	data, err := json.Marshal(certificates)
+
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, common.Response(http.StatusInternalServerError, "", err, nil))
+		return
+	}
+
+	c.JSON(http.StatusOK, common.Response(http.StatusOK, "", nil, data))
+}
+
+// RevokeUser adds serials of all user certificates to the denylist replicated through raft
+func (a *Api) RevokeUser(c *gin.Context) {
+	username := filepath.Clean(c.Param("username"))
+	entry := audit.Entry{Action: "revoke", Kind: rbac.KIND_USER, Name: username}
+
+	if a.Cluster == nil || !a.Cluster.Started {
+		c.JSON(http.StatusBadRequest, common.Response(http.StatusBadRequest, "", errors.New("cluster is not started"), nil))
+		return
+	}
+
+	if a.isNodeName(username) {
+		c.JSON(http.StatusBadRequest, common.Response(http.StatusBadRequest, "", errors.New("node certificates can't be revoked, remove the node instead"), nil))
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(c.Request.Context(), 5*time.Second)
+	defer cancel()
+
+	certificates, err := a.users(ctx)
+
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, common.Response(http.StatusInternalServerError, "", err, nil))
+		return
+	}
+
+	found := false
+	serials := make([]string, 0)
+
+	for _, certificate := range certificates {
+		if certificate.Username != username {
+			continue
+		}
+
+		found = true
+
+		if certificate.Status != revocation.STATUS_REVOKED && !slices.Contains(serials, certificate.Serial) {
+			serials = append(serials, certificate.Serial)
+		}
+	}
+
+	if !found {
+		c.JSON(http.StatusNotFound, common.Response(http.StatusNotFound, "", errors.New(static.USER_NOT_FOUND), nil))
+		return
+	}
+
+	if len(serials) == 0 {
+		c.JSON(http.StatusOK, common.Response(http.StatusOK, fmt.Sprintf("user %s is already revoked", username), nil, nil))
+		return
+	}
+
+	by := authentication.NewUser(c.Request.TLS).Username
+
+	for _, serial := range serials {
+		err = a.proposeUsers(revocation.KIND_REVOCATION, revocation.GROUP_INTERNAL, serial, revocation.Revoked{
+			Serial:   serial,
+			Username: username,
+			By:       by,
+			Time:     time.Now().UTC(),
 		})
 
-		c.JSON(http.StatusOK, common.Response(http.StatusOK, fmt.Sprintf("user created, run: cat %s", strings.Replace(path, a.Config.Environment.Container.Home, "$HOME", 1)), nil, nil))
-	} else {
-		c.JSON(http.StatusBadRequest, common.Response(http.StatusBadRequest, fmt.Sprintf("failed to create user credentials for: %s", filepath.Clean(c.Param("username"))), nil, nil))
+		if err != nil {
+			entry.Status, entry.Error = http.StatusInternalServerError, err.Error()
+			a.record(c, entry)
+
+			c.JSON(http.StatusInternalServerError, common.Response(http.StatusInternalServerError, "failed to revoke user", err, nil))
+			return
+		}
+	}
+
+	entry.Status = http.StatusOK
+	a.record(c, entry)
+
+	c.JSON(http.StatusOK, common.Response(http.StatusOK, fmt.Sprintf("user %s revoked, certificates %s are denied on every node", username, strings.Join(serials, ", ")), nil, nil))
+}
+
+// WatchRevocations loads the denylist from the store and keeps it in sync with entries committed via raft
+func (a *Api) WatchRevocations() error {
+	opts := f.DefaultToStringOpts()
+	opts.AddPrefixSlash = true
+	opts.AddTrailingSlash = true
+
+	prefix := f.New(static.SMR_PREFIX, static.CATEGORY_PLAIN, revocation.KIND_REVOCATION).ToStringWithOpts(opts)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	response, err := a.Etcd.Get(ctx, prefix, clientv3.WithPrefix())
 
+	if err != nil {
+		return err
 	}
+
+	for _, kv := range response.Kvs {
+		a.revoke(kv.Value)
+	}
+
+	go func() {
+		for watch := range a.Etcd.Watch(context.Background(), prefix, clientv3.WithPrefix(), clientv3.WithRev(response.Header.Revision+1), clientv3.WithPrevKV()) {
+			for _, event := range watch.Events {
+				if event.Type == clientv3.EventTypeDelete {
+					if event.PrevKv != nil {
+						revoked := &revocation.Revoked{}
+
+						if json.Unmarshal(event.PrevKv.Value, revoked) == nil {
+							a.Revocations.Remove(revoked.Serial)
+						}
+					}
+
+					continue
+				}
+
+				a.revoke(event.Kv.Value)
+			}
+		}
+	}()
+
+	return nil
+}
+
+func (a *Api) revoke(value []byte) {
+	revoked := &revocation.Revoked{}
+
+	if json.Unmarshal(value, revoked) == nil && revoked.Serial != "" {
+		a.Revocations.Add(revoked)
+	}
+}
+
+func (a *Api) users(ctx context.Context) ([]*revocation.Certificate, error) {
+	opts := f.DefaultToStringOpts()
+	opts.AddPrefixSlash = true
+	opts.AddTrailingSlash = true
+
+	response, err := a.Etcd.Get(ctx, f.New(static.SMR_PREFIX, static.CATEGORY_PLAIN, revocation.KIND_USERS).ToStringWithOpts(opts), clientv3.WithPrefix())
+
+	if err != nil {
+		return nil, err
+	}
+
+	certificates := make([]*revocation.Certificate, 0, len(response.Kvs))
+
+	for _, kv := range response.Kvs {
+		certificate := &revocation.Certificate{}
+
+		if json.Unmarshal(kv.Value, certificate) != nil {
+			continue
+		}
+
+		certificate.Resolve(a.Revocations)
+		certificates = append(certificates, certificate)
+	}
+
+	return certificates, nil
+}
+
+func (a *Api) proposeUsers(kind string, group string, name string, value interface{}) error {
+	bytes, err := json.Marshal(value)
+
+	if err != nil {
+		return err
+	}
+
+	format := f.New(static.SMR_PREFIX, static.CATEGORY_PLAIN, kind, group, name)
+	obj := objects.New(a.Manager.Http.Clients[a.User.Username], a.User)
+
+	return obj.Wait(format, bytes)
 }
@@ -8,11 +8,14 @@ import (
 	"github.com/gin-gonic/gin"
 	"github.com/simplecontainer/smr/pkg/clients"
 	"github.com/simplecontainer/smr/pkg/configuration"
+	"github.com/simplecontainer/smr/pkg/events/events"
 	"github.com/simplecontainer/smr/pkg/kinds/common"
+	"github.com/simplecontainer/smr/pkg/kinds/node/shared"
 	"github.com/simplecontainer/smr/pkg/logger"
 	"github.com/simplecontainer/smr/pkg/network"
 	"github.com/simplecontainer/smr/pkg/node"
 	"github.com/simplecontainer/smr/pkg/startup"
+	"github.com/simplecontainer/smr/pkg/static"
 	"go.etcd.io/etcd/raft/v3/raftpb"
 	"go.uber.org/zap"
 	"io"
@@ -138,6 +141,59 @@ func (a *Api) GetNodeVersion(c *gin.Context) {
 	c.JSON(response.HttpStatus, response)
 }
 
+// SetNodeLabels replaces labels of the node and propagates them to the rest of the cluster
+func (a *Api) SetNodeLabels(c *gin.Context) {
+	nodeID, err := a.parseNodeID(c)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, common.Response(http.StatusBadRequest, "please provide valid node id", err, nil))
+		return
+	}
+
+	data, err := io.ReadAll(c.Request.Body)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, common.Response(http.StatusBadRequest, "", err, nil))
+		return
+	}
+
+	labels := make(map[string]string)
+	err = json.Unmarshal(data, &labels)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, common.Response(http.StatusBadRequest, "invalid labels", err, nil))
+		return
+	}
+
+	if nodeID != a.Cluster.Node.NodeID {
+		target := a.Cluster.Cluster.FindById(nodeID)
+
+		if target == nil {
+			c.JSON(http.StatusNotFound, common.Response(http.StatusNotFound, "node not found", nil, nil))
+			return
+		}
+
+		response := network.Send(a.Manager.Http.Clients[a.Manager.User.Username].Http, fmt.Sprintf("%s/api/v1/cluster/node/%d/labels", target.API, nodeID), http.MethodPost, data)
+		c.JSON(response.HttpStatus, response)
+		return
+	}
+
+	a.Config.Labels = labels
+	a.Cluster.Node.Labels = labels
+
+	err = startup.Save(a.Config, a.Config.Environment.Container, 0)
+	if err != nil {
+		logger.Log.Error("failed to persist node labels", zap.Error(err))
+	}
+
+	event, err := events.NewNodeEvent(events.EVENT_NODE_LABELED, a.Cluster.Node)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, common.Response(http.StatusInternalServerError, "", err, nil))
+		return
+	}
+
+	events.Dispatch(event, a.KindsRegistry[static.KIND_NODE].GetShared().(*shared.Shared), a.Cluster.Node.NodeID)
+
+	c.JSON(http.StatusOK, common.Response(http.StatusOK, "node labels updated", nil, network.ToJSON(labels)))
+}
+
 func (a *Api) parseNodeID(c *gin.Context) (uint64, error) {
 	if c.Param("id") == "" {
 		return 0, fmt.Errorf("missing node id")
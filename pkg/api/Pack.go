@@ -0,0 +1,128 @@
+package api
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"github.com/gin-gonic/gin"
+	"github.com/simplecontainer/smr/pkg/audit"
+	"github.com/simplecontainer/smr/pkg/authentication"
+	"github.com/simplecontainer/smr/pkg/kinds/common"
+	"github.com/simplecontainer/smr/pkg/packer"
+	"github.com/simplecontainer/smr/pkg/packer/signature"
+	"github.com/simplecontainer/smr/pkg/rbac"
+	"net/http"
+	"os"
+	"strings"
+)
+
+const MAX_PACK_SIZE = 32 << 20
+
+// ApplyPack verifies the uploaded pack against trust policy of its origin before any definition is read, same as
+// gitops does for cloned repositories, and proposes definitions as the node once caller is allowed to apply each of them
+func (a *Api) ApplyPack(c *gin.Context) {
+	user := authentication.NewUser(c.Request.TLS)
+
+	directory, err := os.MkdirTemp("", "smr-pack-")
+
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, common.Response(http.StatusInternalServerError, "", err, nil))
+		return
+	}
+
+	defer os.RemoveAll(directory)
+
+	err = packer.Extract(http.MaxBytesReader(c.Writer, c.Request.Body, MAX_PACK_SIZE), directory)
+
+	if err != nil {
+		c.JSON(http.StatusBadRequest, common.Response(http.StatusBadRequest, "invalid pack archive", err, nil))
+		return
+	}
+
+	origin := packer.Origin(directory)
+	entry := audit.Entry{Action: "apply", Kind: "pack", Name: origin}
+
+	if a.Manager.Trust != nil {
+		err = a.Manager.Trust.Require(origin, directory)
+
+		if err != nil {
+			entry.Status, entry.Error = http.StatusForbidden, err.Error()
+			a.record(c, entry)
+
+			c.JSON(http.StatusForbidden, common.Response(http.StatusForbidden, "pack verification failed", err, nil))
+			return
+		}
+	}
+
+	pack, err := packer.Read(directory, c.QueryArray("set"), a.Manager.Kinds)
+
+	if err != nil {
+		c.JSON(http.StatusBadRequest, common.Response(http.StatusBadRequest, "invalid pack", err, nil))
+		return
+	}
+
+	// Definitions are proposed by the node so the caller's permissions are checked here for every one of them
+	for _, definition := range pack.Definitions {
+		err = a.Authorize(user, rbac.Request{
+			Verb:  rbac.VERB_APPLY,
+			Kind:  definition.Definition.Definition.GetKind(),
+			Group: definition.Definition.Definition.GetMeta().Group,
+		})
+
+		if err != nil {
+			entry.Status, entry.Error = http.StatusForbidden, err.Error()
+			a.record(c, entry)
+
+			c.JSON(http.StatusForbidden, common.Response(http.StatusForbidden, "forbidden", err, nil))
+			return
+		}
+	}
+
+	client, ok := a.Manager.Http.Clients[a.User.Username]
+
+	if !ok {
+		c.JSON(http.StatusInternalServerError, common.Response(http.StatusInternalServerError, "", errors.New("node client is missing"), nil))
+		return
+	}
+
+	proposed := make([]string, 0, len(pack.Definitions))
+
+	for _, definition := range pack.Definitions {
+		meta := definition.Definition.Definition.GetMeta()
+		err = definition.Definition.ProposeApply(client.Http, client.API)
+
+		if err != nil {
+			entry.Status, entry.Error = http.StatusBadRequest, err.Error()
+			a.record(c, entry)
+
+			data, _ := json.Marshal(proposed)
+			c.JSON(http.StatusBadRequest, common.Response(http.StatusBadRequest, fmt.Sprintf("failed to propose %s/%s/%s", definition.Definition.Definition.GetKind(), meta.Group, meta.Name), err, data))
+			return
+		}
+
+		proposed = append(proposed, fmt.Sprintf("%s/%s/%s", definition.Definition.Definition.GetKind(), meta.Group, meta.Name))
+	}
+
+	entry.Status = http.StatusOK
+	a.record(c, entry)
+
+	data, _ := json.Marshal(proposed)
+	c.JSON(http.StatusOK, common.Response(http.StatusOK, "pack proposed for apply", nil, data))
+}
+
+// unsigned rejects definitions users send directly while any trust policy exists, such definitions have no origin
+// to verify so scoped policy could be skipped by applying the same definitions unpacked, they have to come from
+// a signed pack applied through ApplyPack
+func (a *Api) unsigned(user *authentication.User) error {
+	if user.Node || a.Manager.Trust == nil || !a.Manager.Trust.Enforced() {
+		return nil
+	}
+
+	scopes := make([]string, 0)
+
+	for _, policy := range a.Manager.Trust.List() {
+		scopes = append(scopes, policy.Scope)
+	}
+
+	return fmt.Errorf("%w: trust policies %s require definitions to be applied from signed pack", signature.ERROR_UNSIGNED, strings.Join(scopes, ", "))
+}
@@ -4,7 +4,11 @@ import (
 	"encoding/json"
 	"errors"
 	"github.com/gin-gonic/gin"
+	"github.com/simplecontainer/smr/pkg/audit"
+	"github.com/simplecontainer/smr/pkg/authentication"
+	"github.com/simplecontainer/smr/pkg/definitions"
 	"github.com/simplecontainer/smr/pkg/definitions/commonv1"
+	"github.com/simplecontainer/smr/pkg/encrypt"
 	"github.com/simplecontainer/smr/pkg/f"
 	"github.com/simplecontainer/smr/pkg/kinds/common"
 	"github.com/simplecontainer/smr/pkg/static"
@@ -47,6 +51,39 @@ func (a *Api) Propose(c *gin.Context) {
 						return
 					}
 
+					// Violations are reported to the user instead of failing on every node after replication
+					if c.Param("action") == "apply" {
+						jsonData, err = a.admit(c.Request.Context(), authentication.NewUser(c.Request.TLS), kind, jsonData)
+
+						if err != nil {
+							response := rejected(err)
+
+							a.record(c, audit.Entry{
+								Action: c.Param("action"),
+								Kind:   kind,
+								Group:  request.Definition.GetMeta().Group,
+								Name:   request.Definition.GetMeta().Name,
+								Error:  err.Error(),
+								Status: response.HttpStatus,
+							})
+
+							c.JSON(response.HttpStatus, response)
+							return
+						}
+
+						// Patched definition is decoded again so fields removed by the patch don't linger
+						request, err = common.NewRequest(kind)
+
+						if err == nil {
+							err = request.Definition.FromJson(jsonData)
+						}
+
+						if err != nil {
+							c.JSON(http.StatusBadRequest, common.Response(http.StatusBadRequest, "invalid definition after admission", err, nil))
+							return
+						}
+					}
+
 					var valid bool
 					valid, err = request.Definition.Validate()
 
@@ -87,10 +124,34 @@ func (a *Api) Propose(c *gin.Context) {
 						return
 					}
 
+					var diff json.RawMessage
+
+					if c.Param("action") == "apply" {
+						diff = a.diff(c.Request.Context(), kind, request.Definition.GetMeta().Group, request.Definition.GetMeta().Name, bytes)
+					}
+
+					// Payload is sealed before it reaches raft log, snapshots and etcd
+					bytes, err = definitions.Seal(kind, bytes, encrypt.Ring)
+
+					if err != nil {
+						c.JSON(http.StatusInternalServerError, common.Response(http.StatusInternalServerError, "failed to encrypt definition", err, nil))
+						return
+					}
+
 					format = f.New(static.SMR_PREFIX, static.CATEGORY_KIND, kind, request.Definition.GetMeta().Group, request.Definition.GetMeta().Name)
 
 					a.Cluster.KVStore.Propose(format.ToStringWithUUID(), bytes, a.Manager.Config.KVStore.Node.NodeID)
 
+					a.record(c, audit.Entry{
+						Action: c.Param("action"),
+						Kind:   kind,
+						Group:  request.Definition.GetMeta().Group,
+						Name:   request.Definition.GetMeta().Name,
+						Diff:   diff,
+						Detail: "proposed",
+						Status: http.StatusOK,
+					})
+
 					c.JSON(http.StatusOK, common.Response(http.StatusOK, static.RESPONSE_SCHEDULED, nil, nil))
 				}
 			}
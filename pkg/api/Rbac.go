@@ -0,0 +1,199 @@
+package api
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"github.com/simplecontainer/smr/pkg/authentication"
+	"github.com/simplecontainer/smr/pkg/contracts/idefinitions"
+	v1 "github.com/simplecontainer/smr/pkg/definitions/v1"
+	"github.com/simplecontainer/smr/pkg/f"
+	"github.com/simplecontainer/smr/pkg/kinds/common"
+	"github.com/simplecontainer/smr/pkg/logger"
+	"github.com/simplecontainer/smr/pkg/rbac"
+	"github.com/simplecontainer/smr/pkg/static"
+	clientv3 "go.etcd.io/etcd/client/v3"
+	"go.uber.org/zap"
+	"slices"
+	"time"
+)
+
+// Authorize lets cluster nodes through and checks everyone else against roles and bindings stored in the cluster,
+// certificates of nodes issued before they carried the node unit are recognized by the node name until regenerated
+// and cluster without bindings isn't enforced until SeedPolicies binds existing users
+func (a *Api) Authorize(user *authentication.User, request rbac.Request) error {
+	if user.Node || a.isNodeName(user.Username) {
+		return nil
+	}
+
+	if a.Etcd == nil {
+		return fmt.Errorf("%w: cluster is not started", rbac.ERROR_FORBIDDEN)
+	}
+
+	roles, bindings, err := rbac.Policies.Get(a.policies)
+
+	if err != nil {
+		return err
+	}
+
+	if len(bindings) == 0 {
+		return nil
+	}
+
+	return rbac.Authorize(user.Username, request, roles, bindings)
+}
+
+// SeedPolicies binds admin role to existing users and nodes when cluster has no bindings yet, otherwise enabling
+// RBAC on existing cluster would lock out everyone holding certificate issued before
+func (a *Api) SeedPolicies() error {
+	_, bindings, err := a.policies()
+
+	if err != nil {
+		return err
+	}
+
+	if len(bindings) > 0 {
+		return nil
+	}
+
+	users := make([]string, 0)
+
+	for username := range a.Keys.Clients {
+		users = append(users, username)
+	}
+
+	if a.Cluster != nil && a.Cluster.Cluster != nil {
+		for _, n := range a.Cluster.Cluster.Nodes {
+			if !slices.Contains(users, n.NodeName) {
+				users = append(users, n.NodeName)
+			}
+		}
+	}
+
+	slices.Sort(users)
+
+	role := v1.NewRole()
+	role.Kind, role.Prefix = static.KIND_ROLE, static.SMR_PREFIX
+	role.Meta.Group, role.Meta.Name = rbac.ADMIN_GROUP, rbac.ADMIN_NAME
+	role.Spec.Rules = []v1.RoleRule{{Verbs: []string{rbac.WILDCARD}, Kinds: []string{rbac.WILDCARD}, Groups: []string{rbac.WILDCARD}}}
+
+	binding := v1.NewRoleBinding()
+	binding.Kind, binding.Prefix = static.KIND_ROLEBINDING, static.SMR_PREFIX
+	binding.Meta.Group, binding.Meta.Name = rbac.ADMIN_GROUP, rbac.ADMIN_NAME
+	binding.Spec.Role = v1.RoleBindingRoleRef{Group: rbac.ADMIN_GROUP, Name: rbac.ADMIN_NAME}
+	binding.Spec.Users = users
+
+	client := a.Manager.Http.Clients[a.User.Username]
+
+	for _, definition := range []idefinitions.IDefinition{role, binding} {
+		request, err := common.NewRequest(definition.GetKind())
+
+		if err != nil {
+			return err
+		}
+
+		request.Definition.Definition = definition
+
+		if err = request.ProposeApply(client.Http, client.API); err != nil {
+			return err
+		}
+	}
+
+	logger.Log.Info("seeded admin rolebinding", zap.Strings("users", users))
+
+	return nil
+}
+
+// SeedPoliciesOnLeader seeds policies once the node is elected, followers give up since leader takes care of it
+func (a *Api) SeedPoliciesOnLeader() {
+	ticker := time.NewTicker(time.Second)
+	defer ticker.Stop()
+
+	timeout := time.After(time.Minute)
+
+	for {
+		select {
+		case <-ticker.C:
+			if a.Cluster.RaftNode == nil || !a.Cluster.RaftNode.IsLeader.Load() {
+				continue
+			}
+
+			if err := a.SeedPolicies(); err != nil {
+				logger.Log.Error("failed to seed admin rolebinding", zap.Error(err))
+			}
+
+			return
+		case <-timeout:
+			return
+		}
+	}
+}
+
+func (a *Api) policies() ([]*v1.RoleDefinition, []*v1.RoleBindingDefinition, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	roles := make([]*v1.RoleDefinition, 0)
+	bindings := make([]*v1.RoleBindingDefinition, 0)
+
+	err := a.listKind(ctx, static.KIND_ROLE, func(value []byte) error {
+		role := v1.NewRole()
+		roles = append(roles, role)
+		return json.Unmarshal(value, role)
+	})
+
+	if err != nil {
+		return nil, nil, err
+	}
+
+	err = a.listKind(ctx, static.KIND_ROLEBINDING, func(value []byte) error {
+		binding := v1.NewRoleBinding()
+		bindings = append(bindings, binding)
+		return json.Unmarshal(value, binding)
+	})
+
+	if err != nil {
+		return nil, nil, err
+	}
+
+	return roles, bindings, nil
+}
+
+// isNodeName is true if username belongs to the cluster node, used where certificate is not at hand or predates the
+// node unit
+func (a *Api) isNodeName(username string) bool {
+	if username == a.Config.NodeName {
+		return true
+	}
+
+	if a.Cluster != nil && a.Cluster.Cluster != nil {
+		for _, n := range a.Cluster.Cluster.Nodes {
+			if n.NodeName == username {
+				return true
+			}
+		}
+	}
+
+	return false
+}
+
+func (a *Api) listKind(ctx context.Context, kind string, decode func([]byte) error) error {
+	format := f.New(static.SMR_PREFIX, static.CATEGORY_KIND, kind)
+	opts := f.DefaultToStringOpts()
+	opts.AddPrefixSlash = true
+	opts.AddTrailingSlash = true
+
+	response, err := a.Etcd.Get(ctx, format.ToStringWithOpts(opts), clientv3.WithPrefix())
+
+	if err != nil {
+		return err
+	}
+
+	for _, kv := range response.Kvs {
+		if err = decode(kv.Value); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
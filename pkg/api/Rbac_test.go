@@ -0,0 +1,36 @@
+package api
+
+import (
+	"github.com/simplecontainer/smr/pkg/authentication"
+	"github.com/simplecontainer/smr/pkg/configuration"
+	"github.com/simplecontainer/smr/pkg/rbac"
+	"github.com/stretchr/testify/assert"
+	"testing"
+)
+
+func TestAuthorize(t *testing.T) {
+	a := &Api{Config: &configuration.Configuration{NodeName: "smr-node-1"}}
+	request := rbac.Request{Verb: rbac.VERB_APPLY, Kind: rbac.KIND_CLUSTER}
+
+	tests := []struct {
+		name          string
+		user          *authentication.User
+		expectedError error
+	}{
+		{"Node certificate", &authentication.User{Username: "smr-node-2", Node: true}, nil},
+		{"Local node certificate issued before node unit", &authentication.User{Username: "smr-node-1"}, nil},
+		{"User before cluster is started", &authentication.User{Username: "alice"}, rbac.ERROR_FORBIDDEN},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			err := a.Authorize(tc.user, request)
+
+			if tc.expectedError == nil {
+				assert.NoError(t, err)
+			} else {
+				assert.ErrorIs(t, err, tc.expectedError)
+			}
+		})
+	}
+}
@@ -0,0 +1,57 @@
+package api
+
+import (
+	"encoding/json"
+	"github.com/gin-gonic/gin"
+	"github.com/simplecontainer/smr/pkg/kinds/common"
+	"github.com/simplecontainer/smr/pkg/selector"
+	"net/http"
+)
+
+type labeled struct {
+	Meta struct {
+		Labels map[string]string `json:"labels"`
+	} `json:"meta"`
+	Definition *labeled `json:"Definition,omitempty"`
+}
+
+// parseSelector reads the selector query parameter and responds with bad request if it is malformed
+func parseSelector(c *gin.Context) (*selector.Selector, bool) {
+	s, err := selector.Parse(c.Query("selector"))
+
+	if err != nil {
+		c.JSON(http.StatusBadRequest, common.Response(http.StatusBadRequest, "invalid label selector", err, nil))
+		return nil, false
+	}
+
+	return s, true
+}
+
+// filter keeps objects whose labels match the selector - states are matched by labels of their definition
+func filter(s *selector.Selector, objects []json.RawMessage) []json.RawMessage {
+	if s.IsEmpty() {
+		return objects
+	}
+
+	filtered := make([]json.RawMessage, 0)
+
+	for _, object := range objects {
+		tmp := labeled{}
+
+		if err := json.Unmarshal(object, &tmp); err != nil {
+			continue
+		}
+
+		labels := tmp.Meta.Labels
+
+		if tmp.Definition != nil {
+			labels = tmp.Definition.Meta.Labels
+		}
+
+		if s.Matches(labels) {
+			filtered = append(filtered, object)
+		}
+	}
+
+	return filtered
+}
@@ -29,6 +29,11 @@ func (a *Api) ListState(c *gin.Context) {
 	kind := c.Param("kind")
 	group := c.Param("group")
 
+	s, ok := parseSelector(c)
+	if !ok {
+		return
+	}
+
 	format := f.New(prefix, version, category, kind, group)
 	opts := f.DefaultToStringOpts()
 	opts.AddPrefixSlash = true
@@ -45,7 +50,7 @@ func (a *Api) ListState(c *gin.Context) {
 		return
 	}
 
-	c.JSON(http.StatusOK, common.Response(http.StatusOK, "", nil, network.ToJSON(states)))
+	c.JSON(http.StatusOK, common.Response(http.StatusOK, "", nil, network.ToJSON(filter(s, states))))
 }
 
 // GetState godoc
@@ -135,7 +140,7 @@ func (a *Api) append(c *gin.Context, stateValue []byte, prefix, version, kind, g
 		return nil, err
 	}
 
-	state["Definition"] = definition.Kvs[0].Value
+	state["Definition"] = unseal(static.CATEGORY_KIND, kind, definition.Kvs[0].Value)
 
 	combined, err := json.Marshal(state)
 	if err != nil {
@@ -0,0 +1,195 @@
+package api
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"github.com/gin-gonic/gin"
+	"github.com/simplecontainer/smr/pkg/audit"
+	"github.com/simplecontainer/smr/pkg/authentication"
+	"github.com/simplecontainer/smr/pkg/f"
+	"github.com/simplecontainer/smr/pkg/kinds/common"
+	"github.com/simplecontainer/smr/pkg/objects"
+	"github.com/simplecontainer/smr/pkg/packer/signature"
+	"github.com/simplecontainer/smr/pkg/packer/trust"
+	"github.com/simplecontainer/smr/pkg/rbac"
+	"github.com/simplecontainer/smr/pkg/static"
+	clientv3 "go.etcd.io/etcd/client/v3"
+	"io"
+	"net/http"
+	"time"
+)
+
+func (a *Api) ListTrust(c *gin.Context) {
+	data, err := json.Marshal(a.Manager.Trust.List())
+
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, common.Response(http.StatusInternalServerError, "", err, nil))
+		return
+	}
+
+	c.JSON(http.StatusOK, common.Response(http.StatusOK, "", nil, data))
+}
+
+// SetTrust replaces keys trusted to sign packs of the scope on every node
+func (a *Api) SetTrust(c *gin.Context) {
+	entry := audit.Entry{Action: "apply", Kind: rbac.KIND_TRUST}
+
+	if a.Cluster == nil || !a.Cluster.Started {
+		c.JSON(http.StatusBadRequest, common.Response(http.StatusBadRequest, "", errors.New("cluster is not started"), nil))
+		return
+	}
+
+	body, err := io.ReadAll(c.Request.Body)
+
+	if err != nil {
+		c.JSON(http.StatusBadRequest, common.Response(http.StatusBadRequest, "", err, nil))
+		return
+	}
+
+	policy := &trust.Policy{}
+
+	if err = json.Unmarshal(body, policy); err != nil {
+		c.JSON(http.StatusBadRequest, common.Response(http.StatusBadRequest, "invalid trust policy", err, nil))
+		return
+	}
+
+	policy.Scope = trust.Source(policy.Scope)
+	entry.Name = policy.Scope
+
+	if policy.Scope == "" || len(policy.Keys) == 0 {
+		c.JSON(http.StatusBadRequest, common.Response(http.StatusBadRequest, "invalid trust policy", errors.New("scope and at least one key are required"), nil))
+		return
+	}
+
+	for _, key := range policy.Keys {
+		if err = signature.ValidatePublicKey(key); err != nil {
+			c.JSON(http.StatusBadRequest, common.Response(http.StatusBadRequest, "invalid trust policy", err, nil))
+			return
+		}
+	}
+
+	policy.By = authentication.NewUser(c.Request.TLS).Username
+	policy.Time = time.Now().UTC()
+
+	data, err := json.Marshal(policy)
+
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, common.Response(http.StatusInternalServerError, "", err, nil))
+		return
+	}
+
+	err = a.proposeTrust(policy.Scope, data)
+
+	if err != nil {
+		entry.Status, entry.Error = http.StatusInternalServerError, err.Error()
+		a.record(c, entry)
+
+		c.JSON(http.StatusInternalServerError, common.Response(http.StatusInternalServerError, "failed to replicate trust policy", err, nil))
+		return
+	}
+
+	entry.Status = http.StatusOK
+	a.record(c, entry)
+
+	c.JSON(http.StatusOK, common.Response(http.StatusOK, fmt.Sprintf("packs from %s must be signed by one of %d trusted keys", policy.Scope, len(policy.Keys)), nil, nil))
+}
+
+func (a *Api) RemoveTrust(c *gin.Context) {
+	scope := trust.Source(c.Query("scope"))
+	entry := audit.Entry{Action: "remove", Kind: rbac.KIND_TRUST, Name: scope}
+
+	if a.Cluster == nil || !a.Cluster.Started {
+		c.JSON(http.StatusBadRequest, common.Response(http.StatusBadRequest, "", errors.New("cluster is not started"), nil))
+		return
+	}
+
+	found := false
+
+	for _, policy := range a.Manager.Trust.List() {
+		if policy.Scope == scope {
+			found = true
+		}
+	}
+
+	if !found {
+		c.JSON(http.StatusNotFound, common.Response(http.StatusNotFound, "", errors.New(fmt.Sprintf("trust policy for %s not found", scope)), nil))
+		return
+	}
+
+	// Empty value is replicated as delete
+	err := a.proposeTrust(scope, nil)
+
+	if err != nil {
+		entry.Status, entry.Error = http.StatusInternalServerError, err.Error()
+		a.record(c, entry)
+
+		c.JSON(http.StatusInternalServerError, common.Response(http.StatusInternalServerError, "failed to remove trust policy", err, nil))
+		return
+	}
+
+	entry.Status = http.StatusOK
+	a.record(c, entry)
+
+	c.JSON(http.StatusOK, common.Response(http.StatusOK, fmt.Sprintf("trust policy for %s removed", scope), nil, nil))
+}
+
+// WatchTrust loads trust policies from the store and keeps them in sync with entries committed via raft
+func (a *Api) WatchTrust() error {
+	opts := f.DefaultToStringOpts()
+	opts.AddPrefixSlash = true
+	opts.AddTrailingSlash = true
+
+	prefix := f.New(static.SMR_PREFIX, static.CATEGORY_PLAIN, trust.KIND_TRUST).ToStringWithOpts(opts)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	response, err := a.Etcd.Get(ctx, prefix, clientv3.WithPrefix())
+
+	if err != nil {
+		return err
+	}
+
+	for _, kv := range response.Kvs {
+		a.trust(kv.Value)
+	}
+
+	go func() {
+		for watch := range a.Etcd.Watch(context.Background(), prefix, clientv3.WithPrefix(), clientv3.WithRev(response.Header.Revision+1), clientv3.WithPrevKV()) {
+			for _, event := range watch.Events {
+				if event.Type == clientv3.EventTypeDelete {
+					if event.PrevKv != nil {
+						policy := &trust.Policy{}
+
+						if json.Unmarshal(event.PrevKv.Value, policy) == nil {
+							a.Manager.Trust.Remove(policy.Scope)
+						}
+					}
+
+					continue
+				}
+
+				a.trust(event.Kv.Value)
+			}
+		}
+	}()
+
+	return nil
+}
+
+func (a *Api) trust(value []byte) {
+	policy := &trust.Policy{}
+
+	if json.Unmarshal(value, policy) == nil && policy.Scope != "" {
+		a.Manager.Trust.Add(policy)
+	}
+}
+
+func (a *Api) proposeTrust(scope string, data []byte) error {
+	format := f.New(static.SMR_PREFIX, static.CATEGORY_PLAIN, trust.KIND_TRUST, trust.GROUP_INTERNAL, trust.Key(scope))
+	obj := objects.New(a.Manager.Http.Clients[a.User.Username], a.User)
+
+	return obj.Wait(format, data)
+}
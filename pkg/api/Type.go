@@ -1,6 +1,8 @@
 package api
 
 import (
+	"github.com/simplecontainer/smr/pkg/admission"
+	"github.com/simplecontainer/smr/pkg/audit"
 	"github.com/simplecontainer/smr/pkg/authentication"
 	"github.com/simplecontainer/smr/pkg/cluster"
 	"github.com/simplecontainer/smr/pkg/configuration"
@@ -10,6 +12,7 @@ import (
 	"github.com/simplecontainer/smr/pkg/keys"
 	"github.com/simplecontainer/smr/pkg/manager"
 	"github.com/simplecontainer/smr/pkg/relations"
+	"github.com/simplecontainer/smr/pkg/revocation"
 	"github.com/simplecontainer/smr/pkg/version"
 	"github.com/simplecontainer/smr/pkg/wss"
 	clientv3 "go.etcd.io/etcd/client/v3"
@@ -33,6 +36,9 @@ type Api struct {
 	KindsRegistry   map[string]ikinds.Kind
 	Manager         *manager.Manager
 	Version         *version.Version
+	AuditTrail      *audit.Audit
+	Revocations     *revocation.List
+	Admission       *admission.Admission
 }
 
 type Kv struct {
@@ -0,0 +1,137 @@
+package middlewares
+
+import (
+	"bytes"
+	"encoding/json"
+	"github.com/gin-gonic/gin"
+	"github.com/simplecontainer/smr/pkg/authentication"
+	"github.com/simplecontainer/smr/pkg/kinds/common"
+	"github.com/simplecontainer/smr/pkg/rbac"
+	"io"
+	"net/http"
+	"strings"
+)
+
+type Authorizer interface {
+	Authorize(user *authentication.User, request rbac.Request) error
+}
+
+// RBAC maps the route to verb, kind and group and rejects the request if user isn't granted it
+func RBAC(authorizer Authorizer) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		request, protected := requestFromRoute(c)
+
+		if !protected || c.Request.TLS == nil {
+			c.Next()
+			return
+		}
+
+		err := authorizer.Authorize(authentication.NewUser(c.Request.TLS), request)
+
+		if err != nil {
+			c.AbortWithStatusJSON(http.StatusForbidden, common.Response(http.StatusForbidden, "forbidden", err, nil))
+			return
+		}
+
+		c.Next()
+	}
+}
+
+func requestFromRoute(c *gin.Context) (rbac.Request, bool) {
+	path := c.FullPath()
+
+	request := rbac.Request{
+		Kind:  c.Param("kind"),
+		Group: c.Param("group"),
+	}
+
+	switch {
+	case strings.HasPrefix(path, "/api/v1/attempt/"), strings.HasPrefix(path, "/api/v1/propose/"):
+		// Malformed definition is left to the handler, empty kind is only granted by wildcard anyway
+		request.Kind, request.Group = fromBody(c)
+		request.Verb = rbac.VERB_APPLY
+
+		if c.Param("action") == "remove" {
+			request.Verb = rbac.VERB_DELETE
+		}
+	case strings.HasPrefix(path, "/api/v1/kind/propose/"):
+		request.Kind = rbac.Resource(request.Kind, c.Param("category"))
+		request.Verb = rbac.VERB_APPLY
+	case strings.HasPrefix(path, "/api/v1/kind/compare/"):
+		request.Kind = rbac.Resource(request.Kind, c.Param("category"))
+		request.Verb = rbac.VERB_GET
+	case strings.HasPrefix(path, "/api/v1/kind/"), strings.HasPrefix(path, "/api/v1/state/"):
+		request.Kind = rbac.Resource(request.Kind, c.Param("category"))
+		request.Verb = fromMethod(c.Request.Method, c.Param("name") != "")
+	case strings.HasPrefix(path, "/api/v1/key/"):
+		request.Kind = rbac.KIND_KEY
+		request.Verb = fromMethod(c.Request.Method, true)
+	case strings.HasPrefix(path, "/api/v1/cluster"):
+		request.Kind = rbac.KIND_CLUSTER
+		request.Verb = fromMethod(c.Request.Method, true)
+	case strings.HasPrefix(path, "/api/v1/debug/"), strings.HasPrefix(path, "/api/v1/logs/"):
+		request.Verb = rbac.VERB_LOGS
+	case strings.HasPrefix(path, "/api/v1/exec/"):
+		request.Verb = rbac.VERB_EXEC
+	case strings.HasPrefix(path, "/api/v1/audit"):
+		request.Kind = rbac.KIND_AUDIT
+		request.Verb = rbac.VERB_LIST
+	case strings.HasPrefix(path, "/api/v1/user"):
+		request.Kind = rbac.KIND_USER
+		request.Verb = fromMethod(c.Request.Method, c.Param("username") != "")
+	case strings.HasPrefix(path, "/api/v1/trust"):
+		request.Kind = rbac.KIND_TRUST
+		request.Verb = fromMethod(c.Request.Method, false)
+	case path == "/events":
+		request.Kind = rbac.KIND_EVENT
+		request.Verb = rbac.VERB_LIST
+	default:
+		// Health, version, metrics and certificate exchange stay open to every authenticated client, pack apply
+		// authorizes every definition of the pack in the handler
+		return request, false
+	}
+
+	return request, true
+}
+
+func fromMethod(method string, single bool) string {
+	switch method {
+	case http.MethodPost, http.MethodPut:
+		return rbac.VERB_APPLY
+	case http.MethodDelete:
+		return rbac.VERB_DELETE
+	default:
+		if single {
+			return rbac.VERB_GET
+		}
+
+		return rbac.VERB_LIST
+	}
+}
+
+// fromBody reads kind and group from definition and puts the body back for the handler
+func fromBody(c *gin.Context) (string, string) {
+	if c.Request.Body == nil {
+		return "", ""
+	}
+
+	body, err := io.ReadAll(c.Request.Body)
+	c.Request.Body = io.NopCloser(bytes.NewReader(body))
+
+	if err != nil {
+		return "", ""
+	}
+
+	definition := struct {
+		Kind string `json:"kind"`
+		Meta struct {
+			Group string `json:"group"`
+		} `json:"meta"`
+	}{}
+
+	if json.Unmarshal(body, &definition) != nil {
+		return "", ""
+	}
+
+	return definition.Kind, definition.Meta.Group
+}
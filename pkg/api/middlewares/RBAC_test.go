@@ -0,0 +1,41 @@
+package middlewares
+
+import (
+	"github.com/gin-gonic/gin"
+	"github.com/simplecontainer/smr/pkg/rbac"
+	"github.com/stretchr/testify/assert"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestRequestFromRoute(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	var request rbac.Request
+
+	router := gin.New()
+	capture := func(c *gin.Context) {
+		request, _ = requestFromRoute(c)
+	}
+
+	router.GET("/api/v1/kind/:prefix/:version/:category/:kind/:group/:name", capture)
+	router.GET("/api/v1/state/:prefix/:version/:category/:kind/:group", capture)
+
+	tests := []struct {
+		name     string
+		path     string
+		expected rbac.Request
+	}{
+		{"Kind", "/api/v1/kind/simplecontainer.io/v1/kind/containers/example/nginx", rbac.Request{Verb: rbac.VERB_GET, Kind: "containers", Group: "example"}},
+		{"Revision", "/api/v1/kind/simplecontainer.io/v1/revision/secret/example/db", rbac.Request{Verb: rbac.VERB_GET, Kind: "secret/revision", Group: "example"}},
+		{"State", "/api/v1/state/simplecontainer.io/v1/state/containers/example", rbac.Request{Verb: rbac.VERB_LIST, Kind: "containers/state", Group: "example"}},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			router.ServeHTTP(httptest.NewRecorder(), httptest.NewRequest(http.MethodGet, tc.path, nil))
+			assert.Equal(t, tc.expected, request)
+		})
+	}
+}
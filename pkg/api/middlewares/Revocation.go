@@ -0,0 +1,27 @@
+package middlewares
+
+import (
+	"github.com/gin-gonic/gin"
+	"github.com/simplecontainer/smr/pkg/kinds/common"
+	"github.com/simplecontainer/smr/pkg/revocation"
+	"net/http"
+)
+
+// Revocation rejects requests on connections established before the client certificate was revoked
+func Revocation(list *revocation.List) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		if c.Request.TLS == nil || len(c.Request.TLS.PeerCertificates) == 0 {
+			c.Next()
+			return
+		}
+
+		err := list.Check(c.Request.TLS.PeerCertificates[0])
+
+		if err != nil {
+			c.AbortWithStatusJSON(http.StatusUnauthorized, common.Response(http.StatusUnauthorized, "unauthorized", err, nil))
+			return
+		}
+
+		c.Next()
+	}
+}
@@ -0,0 +1,127 @@
+package audit
+
+import (
+	"bufio"
+	"encoding/json"
+	"gopkg.in/natefinch/lumberjack.v2"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+)
+
+func New(directory string) *Audit {
+	return &Audit{
+		Directory: directory,
+		writer: &lumberjack.Logger{
+			Filename:   filepath.Join(directory, AUDIT_FILE),
+			MaxSize:    MAX_SIZE_MB,
+			MaxBackups: MAX_BACKUPS,
+			MaxAge:     MAX_AGE_DAYS,
+		},
+	}
+}
+
+// Record appends entry as single json line, rotated files are kept next to the current one
+func (audit *Audit) Record(entry Entry) error {
+	if entry.Time.IsZero() {
+		entry.Time = time.Now().UTC()
+	}
+
+	if entry.Result == "" {
+		entry.Result = RESULT_SUCCESS
+
+		if entry.Status >= 400 || entry.Error != "" {
+			entry.Result = RESULT_FAILURE
+		}
+	}
+
+	bytes, err := json.Marshal(entry)
+
+	if err != nil {
+		return err
+	}
+
+	audit.lock.Lock()
+	defer audit.lock.Unlock()
+
+	_, err = audit.writer.Write(append(bytes, '\n'))
+	return err
+}
+
+// Query reads current and rotated audit files and returns matching entries ordered by time
+func (audit *Audit) Query(filter Filter) ([]Entry, error) {
+	ext := filepath.Ext(AUDIT_FILE)
+	files, err := filepath.Glob(filepath.Join(audit.Directory, strings.TrimSuffix(AUDIT_FILE, ext)+"*"+ext))
+
+	if err != nil {
+		return nil, err
+	}
+
+	entries := make([]Entry, 0)
+
+	audit.lock.Lock()
+	defer audit.lock.Unlock()
+
+	for _, file := range files {
+		matched, err := read(file, filter)
+
+		if err != nil {
+			return nil, err
+		}
+
+		entries = append(entries, matched...)
+	}
+
+	sort.SliceStable(entries, func(i, j int) bool {
+		return entries[i].Time.Before(entries[j].Time)
+	})
+
+	return entries, nil
+}
+
+func (filter Filter) Matches(entry Entry) bool {
+	if !filter.Since.IsZero() && entry.Time.Before(filter.Since) {
+		return false
+	}
+
+	if filter.User != "" && entry.User != filter.User {
+		return false
+	}
+
+	if filter.Kind != "" && entry.Kind != filter.Kind {
+		return false
+	}
+
+	return true
+}
+
+func read(file string, filter Filter) ([]Entry, error) {
+	f, err := os.Open(file)
+
+	if err != nil {
+		return nil, err
+	}
+
+	defer f.Close()
+
+	entries := make([]Entry, 0)
+	scanner := bufio.NewScanner(f)
+	scanner.Buffer(make([]byte, 0, 64*1024), 16*1024*1024)
+
+	for scanner.Scan() {
+		entry := Entry{}
+
+		// Partially written line after crash shouldn't hide the rest of the trail
+		if json.Unmarshal(scanner.Bytes(), &entry) != nil {
+			continue
+		}
+
+		if filter.Matches(entry) {
+			entries = append(entries, entry)
+		}
+	}
+
+	return entries, scanner.Err()
+}
@@ -0,0 +1,45 @@
+package audit
+
+import (
+	"github.com/stretchr/testify/assert"
+	"testing"
+	"time"
+)
+
+func TestQuery(t *testing.T) {
+	audit := New(t.TempDir())
+	now := time.Now().UTC()
+
+	assert.NoError(t, audit.Record(Entry{Time: now.Add(-2 * time.Hour), User: "alice", Action: "apply", Kind: "containers", Status: 200}))
+	assert.NoError(t, audit.Record(Entry{Time: now.Add(-time.Hour), User: "bob", Action: "remove", Kind: "secret", Status: 403}))
+	assert.NoError(t, audit.Record(Entry{Time: now, User: "alice", Action: "exec", Kind: "containers", Error: "container not found"}))
+
+	tests := []struct {
+		name     string
+		filter   Filter
+		expected []string
+	}{
+		{name: "All", filter: Filter{}, expected: []string{"apply", "remove", "exec"}},
+		{name: "User", filter: Filter{User: "alice"}, expected: []string{"apply", "exec"}},
+		{name: "Kind", filter: Filter{Kind: "secret"}, expected: []string{"remove"}},
+		{name: "Since", filter: Filter{Since: now.Add(-90 * time.Minute)}, expected: []string{"remove", "exec"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			entries, err := audit.Query(tt.filter)
+			assert.NoError(t, err)
+
+			actions := make([]string, 0)
+			for _, entry := range entries {
+				actions = append(actions, entry.Action)
+			}
+
+			assert.Equal(t, tt.expected, actions)
+		})
+	}
+
+	entries, err := audit.Query(Filter{User: "bob"})
+	assert.NoError(t, err)
+	assert.Equal(t, RESULT_FAILURE, entries[0].Result)
+}
@@ -0,0 +1,47 @@
+package audit
+
+import (
+	"encoding/json"
+	"gopkg.in/natefinch/lumberjack.v2"
+	"sync"
+	"time"
+)
+
+const AUDIT_FILE = "audit.log"
+const MAX_SIZE_MB = 50
+const MAX_BACKUPS = 20
+const MAX_AGE_DAYS = 365
+
+const RESULT_SUCCESS = "success"
+const RESULT_FAILURE = "failure"
+
+const REDACTED = "[redacted]"
+
+// Audit is node-local trail, every node records only the calls it served itself
+type Audit struct {
+	Directory string
+	writer    *lumberjack.Logger
+	lock      sync.Mutex
+}
+
+type Entry struct {
+	Time   time.Time       `json:"time"`
+	User   string          `json:"user"`
+	Domain string          `json:"domain"`
+	Node   string          `json:"node"`
+	Action string          `json:"action"`
+	Kind   string          `json:"kind"`
+	Group  string          `json:"group"`
+	Name   string          `json:"name"`
+	Diff   json.RawMessage `json:"diff,omitempty"`
+	Detail string          `json:"detail,omitempty"`
+	Status int             `json:"status"`
+	Result string          `json:"result"`
+	Error  string          `json:"error,omitempty"`
+}
+
+type Filter struct {
+	Since time.Time
+	User  string
+	Kind  string
+}
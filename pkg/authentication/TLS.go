@@ -2,12 +2,13 @@ package authentication
 
 import (
 	"crypto/tls"
-	"errors"
 	"fmt"
 	"github.com/simplecontainer/smr/pkg/configuration"
 	"github.com/simplecontainer/smr/pkg/keys"
 	"github.com/simplecontainer/smr/pkg/static"
 	"path/filepath"
+	"slices"
+	"time"
 )
 
 func NewUser(TLSRequest *tls.ConnectionState) *User {
@@ -17,46 +18,44 @@ func NewUser(TLSRequest *tls.ConnectionState) *User {
 	return user
 }
 
-func (user *User) CreateUser(k *keys.Keys, agent string, username string, domain string, externalIP string) (string, error) {
-	if user.Username == agent && user.Domain == "localhost" {
-		exists := k.ClientExists(static.SMR_SSH_HOME, filepath.Clean(username))
-		usernameClean := filepath.Clean(username)
+// CreateUser issues the client certificate signed by the cluster CA, access to it is guarded by the RBAC
+func (user *User) CreateUser(k *keys.Keys, username string, domain string, externalIP string, expiry time.Duration) (string, error) {
+	exists := k.ClientExists(static.SMR_SSH_HOME, filepath.Clean(username))
+	usernameClean := filepath.Clean(username)
 
-		if exists == nil {
-			client := keys.NewClient()
-
-			err := client.Generate(
-				k.CA,
-				configuration.NewDomains([]string{domain, fmt.Sprintf("%s.%s", static.SMR_ENDPOINT_NAME, static.SMR_LOCAL_DOMAIN)}),
-				configuration.NewIPs([]string{externalIP}),
-				username,
-			)
+	if exists != nil {
+		return "", exists
+	}
 
-			if err != nil {
-				return "", err
-			}
+	client := keys.NewClient()
 
-			err = client.Write(static.SMR_SSH_HOME, usernameClean)
+	err := client.Generate(
+		k.CA,
+		configuration.NewDomains([]string{domain, fmt.Sprintf("%s.%s", static.SMR_ENDPOINT_NAME, static.SMR_LOCAL_DOMAIN)}),
+		configuration.NewIPs([]string{externalIP}),
+		username,
+		expiry,
+	)
 
-			if err != nil {
-				return "", err
-			}
+	if err != nil {
+		return "", err
+	}
 
-			err = k.GeneratePemBundle(static.SMR_SSH_HOME, usernameClean, client)
+	err = client.Write(static.SMR_SSH_HOME, usernameClean)
 
-			if err != nil {
-				return "", err
-			}
+	if err != nil {
+		return "", err
+	}
 
-			k.AppendClient(usernameClean, client)
+	err = k.GeneratePemBundle(static.SMR_SSH_HOME, usernameClean, client)
 
-			return fmt.Sprintf("%s/%s.pem", static.SMR_SSH_HOME, usernameClean), nil
-		} else {
-			return "", exists
-		}
-	} else {
-		return "", errors.New("users can only be created by agent user from localhost")
+	if err != nil {
+		return "", err
 	}
+
+	k.AppendClient(usernameClean, client)
+
+	return fmt.Sprintf("%s/%s.pem", static.SMR_SSH_HOME, usernameClean), nil
 }
 
 func (user *User) ReadTLSFromGinCtx(TLSRequest *tls.ConnectionState) {
@@ -65,6 +64,9 @@ func (user *User) ReadTLSFromGinCtx(TLSRequest *tls.ConnectionState) {
 
 		if TLSRequest.PeerCertificates[0] != nil {
 			user.Username = TLSRequest.PeerCertificates[0].Subject.CommonName
+
+			// Only nodes get certificates with the unit, users are issued by CreateUser without it
+			user.Node = slices.Contains(TLSRequest.PeerCertificates[0].Subject.OrganizationalUnit, keys.NODE_UNIT)
 		}
 	}
 }
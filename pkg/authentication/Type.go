@@ -3,4 +3,5 @@ package authentication
 type User struct {
 	Username string
 	Domain   string
+	Node     bool `json:"-"`
 }
@@ -12,6 +12,7 @@ import (
 	"github.com/simplecontainer/smr/pkg/formaters"
 	"github.com/simplecontainer/smr/pkg/static"
 	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
 )
 
 func Alias() {
@@ -48,7 +49,7 @@ func cmdPs(api iapi.Api, cli *client.Client, args []string) {
 
 	switch format.GetKind() {
 	case static.KIND_GITOPS:
-		objects, err = resources.ListState(cli.Context, format.GetPrefix(), format.GetVersion(), static.CATEGORY_STATE, format.GetKind())
+		objects, err = resources.ListState(cli.Context, format.GetPrefix(), format.GetVersion(), static.CATEGORY_STATE, format.GetKind(), viper.GetString("selector"))
 		if err != nil {
 			helpers.PrintAndExit(err, 1)
 		}
@@ -56,7 +57,7 @@ func cmdPs(api iapi.Api, cli *client.Client, args []string) {
 		formaters.Gitops(objects)
 		break
 	case static.KIND_CONTAINERS:
-		objects, err = resources.ListState(cli.Context, format.GetPrefix(), format.GetVersion(), static.CATEGORY_STATE, format.GetKind())
+		objects, err = resources.ListState(cli.Context, format.GetPrefix(), format.GetVersion(), static.CATEGORY_STATE, format.GetKind(), viper.GetString("selector"))
 		if err != nil {
 			helpers.PrintAndExit(err, 1)
 		}
@@ -71,4 +72,5 @@ func cmdPs(api iapi.Api, cli *client.Client, args []string) {
 
 func cmdPsFlags(cmd *cobra.Command) {
 	cmd.Flags().String("output", "table", "output format: table, json")
+	cmd.Flags().StringP("selector", "l", "", "Label selector to filter on, e.g. app=web,env in (prod,staging)")
 }
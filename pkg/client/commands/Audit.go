@@ -0,0 +1,33 @@
+package commands
+
+import (
+	"github.com/simplecontainer/smr/internal/helpers"
+	"github.com/simplecontainer/smr/pkg/client"
+	"github.com/simplecontainer/smr/pkg/client/resources"
+	"github.com/simplecontainer/smr/pkg/command"
+	"github.com/simplecontainer/smr/pkg/contracts/iapi"
+	"github.com/simplecontainer/smr/pkg/formaters"
+	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
+)
+
+func Audit() {
+	Commands = append(Commands,
+		command.NewBuilder().Parent("smrctl").Name("audit").Args(cobra.NoArgs).Function(cmdAudit).Flags(cmdAuditFlags).BuildWithValidation(),
+	)
+}
+
+func cmdAudit(api iapi.Api, cli *client.Client, args []string) {
+	entries, err := resources.Audit(cli.Context, viper.GetString("since"), viper.GetString("user"), viper.GetString("kind"))
+
+	if err != nil {
+		helpers.PrintAndExit(err, 1)
+	}
+
+	formaters.Audit(entries)
+}
+func cmdAuditFlags(cmd *cobra.Command) {
+	cmd.Flags().String("since", "24h", "Show entries newer than duration (eg. 24h) or RFC3339 time")
+	cmd.Flags().String("user", "", "Show entries of the user only")
+	cmd.Flags().String("kind", "", "Show entries for the kind only")
+}
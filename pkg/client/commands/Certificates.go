@@ -0,0 +1,41 @@
+package commands
+
+import (
+	"fmt"
+	"github.com/simplecontainer/smr/internal/helpers"
+	"github.com/simplecontainer/smr/pkg/client"
+	"github.com/simplecontainer/smr/pkg/client/resources"
+	"github.com/simplecontainer/smr/pkg/command"
+	"github.com/simplecontainer/smr/pkg/contracts/iapi"
+	"github.com/simplecontainer/smr/pkg/formaters"
+	"github.com/spf13/cobra"
+)
+
+func Certificates() {
+	Commands = append(Commands,
+		command.NewBuilder().Parent("smrctl").Name("certificates").Function(command.EmptyFunction).BuildWithValidation(),
+		command.NewBuilder().Parent("certificates").Name("list").Args(cobra.NoArgs).Function(cmdCertificatesList).BuildWithValidation(),
+		command.NewBuilder().Parent("certificates").Name("rollover").Args(cobra.ExactArgs(1)).Function(cmdCertificatesRollover).BuildWithValidation(),
+	)
+}
+
+func cmdCertificatesList(api iapi.Api, cli *client.Client, args []string) {
+	infos, err := resources.ListCertificates(cli.Context)
+
+	if err != nil {
+		helpers.PrintAndExit(err, 1)
+	}
+
+	formaters.Certificates(infos)
+}
+
+// cmdCertificatesRollover runs phase of CA rollover: stage, promote or finish
+func cmdCertificatesRollover(api iapi.Api, cli *client.Client, args []string) {
+	explanation, err := resources.RolloverCA(cli.Context, args[0])
+
+	if err != nil {
+		helpers.PrintAndExit(err, 1)
+	}
+
+	fmt.Println(explanation)
+}
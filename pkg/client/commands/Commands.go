@@ -25,6 +25,11 @@ func PreloadCommands() {
 	Containers()
 	Gitops()
 	Pack()
+	Encryption()
+	Audit()
+	Users()
+	Certificates()
+	Trust()
 }
 
 func Run(cli *client.Client, c *cobra.Command) {
@@ -11,13 +11,14 @@ import (
 	"github.com/simplecontainer/smr/pkg/formaters"
 	"github.com/simplecontainer/smr/pkg/static"
 	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
 )
 
 func Containers() {
 	Commands = append(Commands,
 		command.NewBuilder().Parent("smrctl").Name("containers").Args(cobra.NoArgs).Function(cmdPs).Flags(cmdPsFlags).BuildWithValidation(),
 		command.NewBuilder().Parent("containers").Name("images").Args(cobra.MaximumNArgs(1)).Function(cmdImages).Flags(cmdPsFlags).BuildWithValidation(),
-		command.NewBuilder().Parent("containers").Name("restart").Args(cobra.ExactArgs(1)).Function(cmdRestart).BuildWithValidation(),
+		command.NewBuilder().Parent("containers").Name("restart").Args(cobra.ExactArgs(1)).Function(cmdRestart).Flags(cmdSelectorFlags).BuildWithValidation(),
 	)
 }
 
@@ -28,7 +29,7 @@ func cmdImages(api iapi.Api, cli *client.Client, args []string) {
 	}
 
 	var objects []json.RawMessage
-	objects, err = resources.ListState(cli.Context, format.GetPrefix(), format.GetVersion(), static.CATEGORY_STATE, format.GetKind())
+	objects, err = resources.ListState(cli.Context, format.GetPrefix(), format.GetVersion(), static.CATEGORY_STATE, format.GetKind(), viper.GetString("selector"))
 	if err != nil {
 		helpers.PrintAndExit(err, 1)
 	}
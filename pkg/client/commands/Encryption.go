@@ -0,0 +1,28 @@
+package commands
+
+import (
+	"fmt"
+	"github.com/simplecontainer/smr/pkg/client"
+	"github.com/simplecontainer/smr/pkg/command"
+	"github.com/simplecontainer/smr/pkg/contracts/iapi"
+	"github.com/simplecontainer/smr/pkg/network"
+	"github.com/spf13/cobra"
+	"net/http"
+)
+
+func Encryption() {
+	Commands = append(Commands,
+		command.NewBuilder().Parent("smrctl").Name("encryption").Function(command.EmptyFunction).BuildWithValidation(),
+		command.NewBuilder().Parent("encryption").Name("rotate").Args(cobra.NoArgs).Function(cmdEncryptionRotate).BuildWithValidation(),
+	)
+}
+
+func cmdEncryptionRotate(api iapi.Api, cli *client.Client, args []string) {
+	response := network.Send(cli.Context.GetHTTPClient(), fmt.Sprintf("%s/api/v1/cluster/encryption/rotate", cli.Context.APIURL), http.MethodPost, nil)
+
+	if response.Success {
+		fmt.Println(response.Explanation)
+	} else {
+		fmt.Println(response.ErrorExplanation)
+	}
+}
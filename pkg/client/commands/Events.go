@@ -2,14 +2,18 @@ package commands
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"github.com/simplecontainer/smr/internal/helpers"
 	"github.com/simplecontainer/smr/pkg/client"
+	"github.com/simplecontainer/smr/pkg/client/resources"
 	"github.com/simplecontainer/smr/pkg/command"
 	"github.com/simplecontainer/smr/pkg/contexts"
 	"github.com/simplecontainer/smr/pkg/contracts/iapi"
+	"github.com/simplecontainer/smr/pkg/contracts/iformat"
 	"github.com/simplecontainer/smr/pkg/events/events"
 	"github.com/simplecontainer/smr/pkg/f"
+	"github.com/simplecontainer/smr/pkg/formaters"
 	"github.com/simplecontainer/smr/pkg/kinds/gitops/implementation"
 	"github.com/simplecontainer/smr/pkg/network"
 	"github.com/simplecontainer/smr/pkg/static"
@@ -24,7 +28,8 @@ func Events() {
 		command.NewBuilder().Parent("smrctl").Name("commit").Function(cmdCommit).Flags(cmdCommitFlags).Args(cobra.ExactArgs(3)).BuildWithValidation(),
 		command.NewBuilder().Parent("smrctl").Name("sync").Args(cobra.ExactArgs(1)).Function(cmdSync).BuildWithValidation(),
 		command.NewBuilder().Parent("smrctl").Name("refresh").Args(cobra.ExactArgs(1)).Function(cmdRefresh).BuildWithValidation(),
-		command.NewBuilder().Parent("smrctl").Name("restart").Args(cobra.ExactArgs(1)).Function(cmdRestart).BuildWithValidation(),
+		command.NewBuilder().Parent("smrctl").Name("restart").Args(cobra.ExactArgs(1)).Function(cmdRestart).Flags(cmdSelectorFlags).BuildWithValidation(),
+		command.NewBuilder().Parent("smrctl").Name("resume").Args(cobra.ExactArgs(1)).Function(cmdResume).BuildWithValidation(),
 	)
 }
 
@@ -111,6 +116,11 @@ func cmdRestart(api iapi.Api, cli *client.Client, args []string) {
 		helpers.PrintAndExit(err, 1)
 	}
 
+	if viper.GetString("selector") != "" {
+		restartSelected(cli, format, viper.GetString("selector"))
+		return
+	}
+
 	event := events.New(events.EVENT_RESTART, static.KIND_CONTAINERS, static.SMR_PREFIX, static.KIND_CONTAINERS, format.GetGroup(), format.GetName(), nil)
 
 	var bytes []byte
@@ -119,6 +129,49 @@ func cmdRestart(api iapi.Api, cli *client.Client, args []string) {
 	Event(cli.Context, format.GetPrefix(), format.GetVersion(), static.CATEGORY_EVENT, format.GetKind(), format.GetGroup(), format.GetName(), bytes)
 }
 
+func cmdResume(api iapi.Api, cli *client.Client, args []string) {
+	format, err := f.Build(args[0], cli.Group)
+
+	if err != nil {
+		helpers.PrintAndExit(err, 1)
+	}
+
+	event := events.New(events.EVENT_RESUME, static.KIND_CONTAINERS, static.SMR_PREFIX, static.KIND_CONTAINERS, format.GetGroup(), format.GetName(), nil)
+
+	var bytes []byte
+	bytes, err = event.ToJSON()
+
+	Event(cli.Context, format.GetPrefix(), format.GetVersion(), static.CATEGORY_EVENT, format.GetKind(), format.GetGroup(), format.GetName(), bytes)
+}
+
+// restartSelected restarts every container replica whose definition matches the label selector
+func restartSelected(cli *client.Client, format iformat.Format, selector string) {
+	objects, err := resources.ListState(cli.Context, format.GetPrefix(), format.GetVersion(), static.CATEGORY_STATE, static.KIND_CONTAINERS, selector)
+	if err != nil {
+		helpers.PrintAndExit(err, 1)
+	}
+
+	display, err := formaters.ContainerBuilder(objects)
+	if err != nil {
+		helpers.PrintAndExit(err, 1)
+	}
+
+	if len(display) == 0 {
+		helpers.PrintAndExit(errors.New(fmt.Sprintf("no containers matched selector: %s", selector)), 1)
+	}
+
+	for _, container := range display {
+		event := events.New(events.EVENT_RESTART, static.KIND_CONTAINERS, static.SMR_PREFIX, static.KIND_CONTAINERS, container.Group, container.GeneratedName, nil)
+
+		bytes, err := event.ToJSON()
+		if err != nil {
+			helpers.PrintAndExit(err, 1)
+		}
+
+		Event(cli.Context, format.GetPrefix(), format.GetVersion(), static.CATEGORY_EVENT, static.KIND_CONTAINERS, container.Group, container.GeneratedName, bytes)
+	}
+}
+
 func Event(context *contexts.ClientContext, prefix string, version string, category string, kind string, group string, name string, data []byte) {
 	response := network.Send(context.GetHTTPClient(), fmt.Sprintf("%s/api/v1/kind/propose/%s/%s/%s/%s/%s/%s", context.APIURL, prefix, version, category, kind, group, name), http.MethodPost, data)
 
@@ -11,6 +11,7 @@ import (
 	"github.com/simplecontainer/smr/pkg/formaters"
 	"github.com/simplecontainer/smr/pkg/static"
 	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
 )
 
 func Gitops() {
@@ -31,7 +32,7 @@ func cmdRepositories(api iapi.Api, cli *client.Client, args []string) {
 	}
 
 	var objects []json.RawMessage
-	objects, err = resources.ListState(cli.Context, format.GetPrefix(), format.GetVersion(), static.CATEGORY_STATE, format.GetKind())
+	objects, err = resources.ListState(cli.Context, format.GetPrefix(), format.GetVersion(), static.CATEGORY_STATE, format.GetKind(), viper.GetString("selector"))
 	if err != nil {
 		helpers.PrintAndExit(err, 1)
 	}
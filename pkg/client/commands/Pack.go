@@ -11,6 +11,7 @@ import (
 	"github.com/simplecontainer/smr/pkg/contracts/iapi"
 	"github.com/simplecontainer/smr/pkg/packer"
 	"github.com/simplecontainer/smr/pkg/packer/ocicredentials"
+	"github.com/simplecontainer/smr/pkg/packer/signature"
 	"github.com/spf13/cobra"
 	"github.com/spf13/viper"
 	"os"
@@ -25,6 +26,7 @@ func Pack() {
 		command.NewBuilder().Parent("pack").Name("logout").Args(cobra.ExactArgs(0)).Function(cmdPackLogout).Flags(cmdPackLogoutFlags).BuildWithValidation(),
 		command.NewBuilder().Parent("pack").Name("push").Args(cobra.ExactArgs(1)).Function(cmdPackPush).Flags(cmdPackPushFlags).BuildWithValidation(),
 		command.NewBuilder().Parent("pack").Name("pull").Args(cobra.ExactArgs(1)).Function(cmdPackPull).Flags(cmdPackPullFlags).BuildWithValidation(),
+		command.NewBuilder().Parent("pack").Name("sign").Args(cobra.ExactArgs(1)).Function(cmdPackSign).Flags(cmdPackSignFlags).BuildWithValidation(),
 	)
 }
 
@@ -225,8 +227,36 @@ func cmdPackPull(api iapi.Api, cli *client.Client, args []string) {
 		helpers.PrintAndExit(err, 1)
 	}
 
+	// Apply verifies the pack against trust policy of the registry it came from
+	err = packer.WriteOrigin(filepath.Join(".", repository), fmt.Sprintf("%s/%s", credentials.Registry, repository))
+	if err != nil {
+		helpers.PrintAndExit(err, 1)
+	}
+
 	fmt.Println("pack pulled successfully: ", pack.Name, pack.Version)
 }
 func cmdPackPullFlags(cmd *cobra.Command) {
 	cmd.Flags().String("registry", ocicredentials.DefaultRegistry, "Registry for packs")
 }
+
+func cmdPackSign(api iapi.Api, cli *client.Client, args []string) {
+	cli.Signer.PrivateKeyPath = viper.GetString("key")
+	cli.Signer.SignerName = viper.GetString("author")
+	cli.Signer.SignerEmail = viper.GetString("email")
+
+	if cli.Signer.PrivateKeyPath == "" {
+		helpers.PrintAndExit(errors.New("private key for signing is required, use --key"), 1)
+	}
+
+	sig, err := signature.Sign(args[0], cli.Signer)
+	if err != nil {
+		helpers.PrintAndExit(err, 1)
+	}
+
+	fmt.Printf("pack signed with key %s, commit %s with the pack\n", signature.Fingerprint(sig.PublicKey), signature.SignatureFile)
+}
+func cmdPackSignFlags(cmd *cobra.Command) {
+	cmd.Flags().String("key", "", "Path to the private key for signing")
+	cmd.Flags().String("author", "", "Signing author")
+	cmd.Flags().String("email", "", "Signing author email")
+}
@@ -2,6 +2,7 @@ package commands
 
 import (
 	"encoding/json"
+	"errors"
 	"fmt"
 	"github.com/simplecontainer/smr/internal/helpers"
 	"github.com/simplecontainer/smr/pkg/client"
@@ -9,12 +10,15 @@ import (
 	"github.com/simplecontainer/smr/pkg/command"
 	"github.com/simplecontainer/smr/pkg/contracts/iapi"
 	"github.com/simplecontainer/smr/pkg/contracts/iformat"
+	v1 "github.com/simplecontainer/smr/pkg/definitions/v1"
 	"github.com/simplecontainer/smr/pkg/f"
 	"github.com/simplecontainer/smr/pkg/formaters"
+	"github.com/simplecontainer/smr/pkg/kinds/common"
 	"github.com/simplecontainer/smr/pkg/packer"
 	"github.com/simplecontainer/smr/pkg/relations"
 	"github.com/simplecontainer/smr/pkg/static"
 	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
 	"gopkg.in/yaml.v3"
 	"net/url"
 	"os"
@@ -24,18 +28,34 @@ import (
 func Resources() {
 	Commands = append(Commands,
 		command.NewBuilder().Parent("smrctl").Name("apply").Args(cobra.ExactArgs(1)).Function(cmdApply).Flags(cmdTemplateFlags).BuildWithValidation(),
-		command.NewBuilder().Parent("smrctl").Name("remove").Args(cobra.ExactArgs(1)).Function(cmdRemove).Flags(cmdTemplateFlags).BuildWithValidation(),
+		command.NewBuilder().Parent("smrctl").Name("remove").Args(cobra.ExactArgs(1)).Function(cmdRemove).Flags(cmdRemoveFlags).BuildWithValidation(),
 		command.NewBuilder().Parent("smrctl").Name("template").Args(cobra.ExactArgs(1)).Function(cmdTemplate).Flags(cmdTemplateFlags).BuildWithValidation(),
-		command.NewBuilder().Parent("smrctl").Name("list").Args(cobra.ExactArgs(1)).Function(cmdList).BuildWithValidation(),
-		command.NewBuilder().Parent("smrctl").Name("get").Args(cobra.ExactArgs(1)).Function(cmdGet).BuildWithValidation(),
+		command.NewBuilder().Parent("smrctl").Name("list").Args(cobra.ExactArgs(1)).Function(cmdList).Flags(cmdSelectorFlags).BuildWithValidation(),
+		command.NewBuilder().Parent("smrctl").Name("get").Args(cobra.ExactArgs(1)).Function(cmdGet).Flags(cmdSelectorFlags).BuildWithValidation(),
 		command.NewBuilder().Parent("smrctl").Name("inspect").Args(cobra.ExactArgs(1)).Function(cmdInspect).BuildWithValidation(),
 		command.NewBuilder().Parent("smrctl").Name("edit").Args(cobra.ExactArgs(1)).Function(cmdEdit).BuildWithValidation(),
+		command.NewBuilder().Parent("smrctl").Name("history").Args(cobra.ExactArgs(1)).Function(cmdHistory).BuildWithValidation(),
+		command.NewBuilder().Parent("smrctl").Name("rollback").Args(cobra.ExactArgs(1)).Function(cmdRollback).Flags(cmdRollbackFlags).BuildWithValidation(),
 	)
 }
 
 var set []string
 
 func cmdApply(api iapi.Api, cli *client.Client, args []string) {
+	// Packs are rendered by the cluster so their signature is verified against trust policy before anything is applied
+	if stat, err := os.Stat(args[0]); err == nil && stat.IsDir() {
+		proposed, err := resources.ApplyPack(cli.Context, args[0], set)
+		if err != nil {
+			helpers.PrintAndExit(err, 1)
+		}
+
+		for _, object := range proposed {
+			fmt.Printf("object proposed for apply: %s\n", object)
+		}
+
+		return
+	}
+
 	pack, _, err := determineDefinitions(args[0], set, cli)
 	if err != nil {
 		helpers.PrintAndExit(err, 1)
@@ -56,6 +76,11 @@ func cmdApply(api iapi.Api, cli *client.Client, args []string) {
 }
 
 func cmdRemove(api iapi.Api, cli *client.Client, args []string) {
+	if viper.GetString("selector") != "" {
+		removeSelected(cli, args[0], viper.GetString("selector"))
+		return
+	}
+
 	pack, format, err := determineDefinitions(args[0], set, cli)
 	if err != nil {
 		helpers.PrintAndExit(err, 1)
@@ -121,6 +146,61 @@ func cmdTemplateFlags(cmd *cobra.Command) {
 	cmd.Flags().StringArrayVar(&set, "set", []string{}, "")
 }
 
+func cmdRemoveFlags(cmd *cobra.Command) {
+	cmdTemplateFlags(cmd)
+	cmdSelectorFlags(cmd)
+}
+
+func cmdSelectorFlags(cmd *cobra.Command) {
+	cmd.Flags().StringP("selector", "l", "", "Label selector to filter on, e.g. app=web,env in (prod,staging)")
+}
+
+func removeSelected(cli *client.Client, kind string, selector string) {
+	format, objects := selected(cli, kind, selector)
+
+	for _, definition := range objects {
+		err := resources.Delete(cli.Context, format.GetPrefix(), format.GetVersion(),
+			static.CATEGORY_KIND, format.GetKind(), definition.Meta.Group, definition.Meta.Name)
+
+		if err != nil {
+			fmt.Println(err)
+		} else {
+			fmt.Printf("object proposed for deleting: %s/%s/%s\n", format.GetKind(), definition.Meta.Group, definition.Meta.Name)
+		}
+	}
+}
+
+// selected lists definitions of the kind matching the label selector
+func selected(cli *client.Client, kind string, selector string) (iformat.Format, []v1.CommonDefinition) {
+	format, err := f.Build(kind, cli.Group)
+	if err != nil {
+		helpers.PrintAndExit(err, 1)
+	}
+
+	objects, err := resources.ListKind(cli.Context, format.GetPrefix(), format.GetVersion(), static.CATEGORY_KIND, format.GetKind(), selector)
+	if err != nil {
+		helpers.PrintAndExit(err, 1)
+	}
+
+	definitions := make([]v1.CommonDefinition, 0)
+
+	for _, obj := range objects {
+		definition := v1.CommonDefinition{}
+
+		if err = json.Unmarshal(obj, &definition); err != nil || definition.Meta == nil {
+			continue
+		}
+
+		definitions = append(definitions, definition)
+	}
+
+	if len(definitions) == 0 {
+		helpers.PrintAndExit(errors.New(fmt.Sprintf("no %s matched selector: %s", format.GetKind(), selector)), 1)
+	}
+
+	return format, definitions
+}
+
 func cmdList(api iapi.Api, cli *client.Client, args []string) {
 	format, err := f.Build(args[0], cli.Group)
 	if err != nil {
@@ -132,7 +212,7 @@ func cmdList(api iapi.Api, cli *client.Client, args []string) {
 	switch format.GetKind() {
 	default:
 		objects, err = resources.ListKind(cli.Context, format.GetPrefix(), format.GetVersion(),
-			static.CATEGORY_KIND, format.GetKind())
+			static.CATEGORY_KIND, format.GetKind(), viper.GetString("selector"))
 		if err != nil {
 			helpers.PrintAndExit(err, 1)
 		}
@@ -141,6 +221,26 @@ func cmdList(api iapi.Api, cli *client.Client, args []string) {
 }
 
 func cmdGet(api iapi.Api, cli *client.Client, args []string) {
+	if viper.GetString("selector") != "" {
+		format, err := f.Build(args[0], cli.Group)
+		if err != nil {
+			helpers.PrintAndExit(err, 1)
+		}
+
+		objects, err := resources.ListKind(cli.Context, format.GetPrefix(), format.GetVersion(), static.CATEGORY_KIND, format.GetKind(), viper.GetString("selector"))
+		if err != nil {
+			helpers.PrintAndExit(err, 1)
+		}
+
+		bytes, err := json.Marshal(objects)
+		if err != nil {
+			helpers.PrintAndExit(err, 1)
+		}
+
+		fmt.Println(string(bytes))
+		return
+	}
+
 	action(cli, args, "get")
 }
 
@@ -152,6 +252,55 @@ func cmdEdit(api iapi.Api, cli *client.Client, args []string) {
 	action(cli, args, "edit")
 }
 
+func cmdHistory(api iapi.Api, cli *client.Client, args []string) {
+	format, err := f.Build(args[0], cli.Group)
+	if err != nil {
+		helpers.PrintAndExit(err, 1)
+	}
+
+	history, err := resources.History(cli.Context, format.GetPrefix(), format.GetVersion(), format.GetKind(), format.GetGroup(), format.GetName())
+	if err != nil {
+		helpers.PrintAndExit(err, 1)
+	}
+
+	formaters.History(history)
+}
+
+func cmdRollback(api iapi.Api, cli *client.Client, args []string) {
+	format, err := f.Build(args[0], cli.Group)
+	if err != nil {
+		helpers.PrintAndExit(err, 1)
+	}
+
+	history, err := resources.History(cli.Context, format.GetPrefix(), format.GetVersion(), format.GetKind(), format.GetGroup(), format.GetName())
+	if err != nil {
+		helpers.PrintAndExit(err, 1)
+	}
+
+	revision, err := common.FindRevision(history, viper.GetUint64("to"))
+	if err != nil {
+		helpers.PrintAndExit(err, 1)
+	}
+
+	request, err := common.NewRequestFromJson(format.GetKind(), revision.Definition)
+	if err != nil {
+		helpers.PrintAndExit(err, 1)
+	}
+
+	request.Definition.SetState(nil)
+
+	err = request.ProposeApply(cli.Context.GetHTTPClient(), cli.Context.APIURL)
+	if err != nil {
+		helpers.PrintAndExit(err, 1)
+	}
+
+	fmt.Printf("object proposed for rollback to revision %d: %s\n", revision.Revision, format.GetKind())
+}
+
+func cmdRollbackFlags(cmd *cobra.Command) {
+	cmd.Flags().Uint64("to", 0, "Revision to roll back to")
+}
+
 func determineDefinitions(entity string, set []string, cli *client.Client) (*packer.Pack, iformat.Format, error) {
 	var pack = packer.New()
 	var format iformat.Format
@@ -2,6 +2,8 @@ package commands
 
 import (
 	"context"
+	"encoding/json"
+	"errors"
 	"fmt"
 	"github.com/gorilla/websocket"
 	"github.com/simplecontainer/smr/internal/helpers"
@@ -9,6 +11,7 @@ import (
 	"github.com/simplecontainer/smr/pkg/client/exec"
 	"github.com/simplecontainer/smr/pkg/command"
 	"github.com/simplecontainer/smr/pkg/contracts/iapi"
+	"github.com/simplecontainer/smr/pkg/contracts/iresponse"
 	CExec "github.com/simplecontainer/smr/pkg/exec"
 	"github.com/simplecontainer/smr/pkg/f"
 	"github.com/simplecontainer/smr/pkg/logger"
@@ -49,6 +52,10 @@ func cmdDebug(api iapi.Api, cli *client.Client, args []string) {
 		helpers.PrintAndExit(err, 1)
 	}
 
+	if resp.StatusCode == http.StatusForbidden {
+		helpers.PrintAndExit(forbidden(resp), 1)
+	}
+
 	sigChan := make(chan os.Signal, 1)
 	signal.Notify(sigChan, syscall.SIGINT, syscall.SIGTERM, syscall.SIGKILL)
 	go func() {
@@ -84,6 +91,10 @@ func cmdLogs(api iapi.Api, cli *client.Client, args []string) {
 		helpers.PrintAndExit(err, 1)
 	}
 
+	if resp.StatusCode == http.StatusForbidden {
+		helpers.PrintAndExit(forbidden(resp), 1)
+	}
+
 	sigChan := make(chan os.Signal, 1)
 	signal.Notify(sigChan, syscall.SIGINT, syscall.SIGTERM, syscall.SIGKILL)
 	go func() {
@@ -249,3 +260,16 @@ func handle(ctx context.Context, cancel context.CancelFunc, conn *websocket.Conn
 		return
 	}
 }
+
+// forbidden extracts the RBAC denial from the response so it isn't printed as raw json
+func forbidden(resp *http.Response) error {
+	defer resp.Body.Close()
+
+	var response iresponse.Response
+
+	if err := json.NewDecoder(resp.Body).Decode(&response); err != nil {
+		return errors.New(http.StatusText(resp.StatusCode))
+	}
+
+	return errors.New(response.ErrorExplanation)
+}
@@ -0,0 +1,82 @@
+package commands
+
+import (
+	"errors"
+	"fmt"
+	"github.com/simplecontainer/smr/internal/helpers"
+	"github.com/simplecontainer/smr/pkg/client"
+	"github.com/simplecontainer/smr/pkg/client/resources"
+	"github.com/simplecontainer/smr/pkg/command"
+	"github.com/simplecontainer/smr/pkg/contracts/iapi"
+	"github.com/simplecontainer/smr/pkg/formaters"
+	"github.com/simplecontainer/smr/pkg/packer/signature"
+	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
+	"os"
+)
+
+func Trust() {
+	Commands = append(Commands,
+		command.NewBuilder().Parent("smrctl").Name("trust").Function(command.EmptyFunction).BuildWithValidation(),
+		command.NewBuilder().Parent("trust").Name("list").Args(cobra.NoArgs).Function(cmdTrustList).BuildWithValidation(),
+		command.NewBuilder().Parent("trust").Name("set").Args(cobra.ExactArgs(1)).Function(cmdTrustSet).Flags(cmdTrustSetFlags).BuildWithValidation(),
+		command.NewBuilder().Parent("trust").Name("remove").Args(cobra.ExactArgs(1)).Function(cmdTrustRemove).BuildWithValidation(),
+	)
+}
+
+func cmdTrustList(api iapi.Api, cli *client.Client, args []string) {
+	policies, err := resources.ListTrust(cli.Context)
+
+	if err != nil {
+		helpers.PrintAndExit(err, 1)
+	}
+
+	formaters.Trust(policies)
+}
+
+func cmdTrustSet(api iapi.Api, cli *client.Client, args []string) {
+	paths := viper.GetStringSlice("key")
+
+	if len(paths) == 0 {
+		helpers.PrintAndExit(errors.New("at least one public key is required, use --key"), 1)
+	}
+
+	keys := make([]string, 0, len(paths))
+
+	for _, path := range paths {
+		data, err := os.ReadFile(path)
+
+		if err != nil {
+			helpers.PrintAndExit(err, 1)
+		}
+
+		key, err := signature.PublicKey(data)
+
+		if err != nil {
+			helpers.PrintAndExit(fmt.Errorf("%s: %w", path, err), 1)
+		}
+
+		keys = append(keys, key)
+	}
+
+	explanation, err := resources.SetTrust(cli.Context, args[0], keys)
+
+	if err != nil {
+		helpers.PrintAndExit(err, 1)
+	}
+
+	fmt.Println(explanation)
+}
+func cmdTrustSetFlags(cmd *cobra.Command) {
+	cmd.Flags().StringSlice("key", []string{}, "Path to the PEM encoded public key trusted to sign packs, can be repeated")
+}
+
+func cmdTrustRemove(api iapi.Api, cli *client.Client, args []string) {
+	explanation, err := resources.RemoveTrust(cli.Context, args[0])
+
+	if err != nil {
+		helpers.PrintAndExit(err, 1)
+	}
+
+	fmt.Println(explanation)
+}
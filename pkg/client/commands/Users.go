@@ -0,0 +1,98 @@
+package commands
+
+import (
+	"fmt"
+	"github.com/simplecontainer/smr/internal/helpers"
+	"github.com/simplecontainer/smr/pkg/client"
+	"github.com/simplecontainer/smr/pkg/client/resources"
+	"github.com/simplecontainer/smr/pkg/command"
+	"github.com/simplecontainer/smr/pkg/contracts/iapi"
+	"github.com/simplecontainer/smr/pkg/formaters"
+	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
+	"os"
+)
+
+func Users() {
+	Commands = append(Commands,
+		command.NewBuilder().Parent("smrctl").Name("users").Function(command.EmptyFunction).BuildWithValidation(),
+		command.NewBuilder().Parent("users").Name("list").Args(cobra.NoArgs).Function(cmdUsersList).BuildWithValidation(),
+		command.NewBuilder().Parent("users").Name("create").Args(cobra.ExactArgs(1)).Function(cmdUsersCreate).Flags(cmdUsersCreateFlags).BuildWithValidation(),
+		command.NewBuilder().Parent("users").Name("renew").Args(cobra.ExactArgs(1)).Function(cmdUsersRenew).Flags(cmdUsersRenewFlags).BuildWithValidation(),
+		command.NewBuilder().Parent("users").Name("revoke").Args(cobra.ExactArgs(1)).Function(cmdUsersRevoke).BuildWithValidation(),
+	)
+}
+
+func cmdUsersList(api iapi.Api, cli *client.Client, args []string) {
+	certificates, err := resources.ListUsers(cli.Context)
+
+	if err != nil {
+		helpers.PrintAndExit(err, 1)
+	}
+
+	formaters.Users(certificates)
+}
+
+func cmdUsersCreate(api iapi.Api, cli *client.Client, args []string) {
+	bundle, err := resources.CreateUser(cli.Context, args[0], viper.GetString("domain"), viper.GetString("ip"), viper.GetString("expiry"))
+
+	if err != nil {
+		helpers.PrintAndExit(err, 1)
+	}
+
+	output := viper.GetString("output")
+
+	if output == "" {
+		output = fmt.Sprintf("%s.pem", args[0])
+	}
+
+	err = os.WriteFile(output, []byte(bundle), 0600)
+
+	if err != nil {
+		helpers.PrintAndExit(err, 1)
+	}
+
+	fmt.Println(fmt.Sprintf("user %s created, credentials bundle saved at %s", args[0], output))
+}
+func cmdUsersCreateFlags(cmd *cobra.Command) {
+	cmd.Flags().String("domain", "localhost", "Domain that user certificate is valid for")
+	cmd.Flags().String("ip", "127.0.0.1", "IP address that user certificate is valid for")
+	cmd.Flags().String("expiry", "", "Validity of the user certificate (eg. 720h), defaults to node configuration")
+	cmd.Flags().String("output", "", "Path to write the pem bundle to, defaults to username.pem")
+}
+
+func cmdUsersRenew(api iapi.Api, cli *client.Client, args []string) {
+	bundle, err := resources.RenewUser(cli.Context, args[0], viper.GetString("expiry"))
+
+	if err != nil {
+		helpers.PrintAndExit(err, 1)
+	}
+
+	output := viper.GetString("output")
+
+	if output == "" {
+		output = fmt.Sprintf("%s.pem", args[0])
+	}
+
+	err = os.WriteFile(output, []byte(bundle), 0600)
+
+	if err != nil {
+		helpers.PrintAndExit(err, 1)
+	}
+
+	fmt.Println(fmt.Sprintf("user %s renewed, credentials bundle saved at %s", args[0], output))
+}
+func cmdUsersRenewFlags(cmd *cobra.Command) {
+	cmd.Flags().String("expiry", "", "Validity of the renewed certificate (eg. 720h), defaults to node configuration")
+	cmd.Flags().String("output", "", "Path to write the pem bundle to, defaults to username.pem")
+}
+
+func cmdUsersRevoke(api iapi.Api, cli *client.Client, args []string) {
+	explanation, err := resources.RevokeUser(cli.Context, args[0])
+
+	if err != nil {
+		helpers.PrintAndExit(err, 1)
+	}
+
+	fmt.Println(explanation)
+}
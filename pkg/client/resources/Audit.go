@@ -0,0 +1,37 @@
+package resources
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"github.com/simplecontainer/smr/pkg/audit"
+	"github.com/simplecontainer/smr/pkg/contexts"
+	"github.com/simplecontainer/smr/pkg/network"
+	"net/http"
+	"net/url"
+)
+
+func Audit(context *contexts.ClientContext, since string, user string, kind string) ([]audit.Entry, error) {
+	query := url.Values{}
+
+	for key, value := range map[string]string{"since": since, "user": user, "kind": kind} {
+		if value != "" {
+			query.Set(key, value)
+		}
+	}
+
+	response := network.Send(context.GetHTTPClient(), fmt.Sprintf("%s/api/v1/audit?%s", context.APIURL, query.Encode()), http.MethodGet, nil)
+
+	if response.HttpStatus != http.StatusOK {
+		return nil, errors.New(response.ErrorExplanation)
+	}
+
+	entries := make([]audit.Entry, 0)
+	err := json.Unmarshal(response.Data, &entries)
+
+	if err != nil {
+		return nil, err
+	}
+
+	return entries, nil
+}
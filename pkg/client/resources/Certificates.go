@@ -0,0 +1,39 @@
+package resources
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"github.com/simplecontainer/smr/pkg/contexts"
+	"github.com/simplecontainer/smr/pkg/keys"
+	"github.com/simplecontainer/smr/pkg/network"
+	"net/http"
+	"net/url"
+)
+
+func ListCertificates(context *contexts.ClientContext) ([]keys.Info, error) {
+	response := network.Send(context.GetHTTPClient(), fmt.Sprintf("%s/api/v1/cluster/certificates", context.APIURL), http.MethodGet, nil)
+
+	if response.HttpStatus != http.StatusOK {
+		return nil, errors.New(response.ErrorExplanation)
+	}
+
+	infos := make([]keys.Info, 0)
+	err := json.Unmarshal(response.Data, &infos)
+
+	if err != nil {
+		return nil, err
+	}
+
+	return infos, nil
+}
+
+func RolloverCA(context *contexts.ClientContext, phase string) (string, error) {
+	response := network.Send(context.GetHTTPClient(), fmt.Sprintf("%s/api/v1/cluster/certificates/ca/%s", context.APIURL, url.PathEscape(phase)), http.MethodPost, nil)
+
+	if response.HttpStatus != http.StatusOK {
+		return "", errors.New(fmt.Sprintf("%s %s", response.Explanation, response.ErrorExplanation))
+	}
+
+	return response.Explanation, nil
+}
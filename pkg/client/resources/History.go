@@ -0,0 +1,25 @@
+package resources
+
+import (
+	"encoding/json"
+	"github.com/simplecontainer/smr/pkg/contexts"
+	"github.com/simplecontainer/smr/pkg/kinds/common"
+	"github.com/simplecontainer/smr/pkg/static"
+)
+
+func History(context *contexts.ClientContext, prefix string, version string, kind string, group string, name string) ([]common.Revision, error) {
+	object, err := Get(context, prefix, version, static.CATEGORY_REVISION, kind, group, name)
+
+	if err != nil {
+		return nil, err
+	}
+
+	history := make([]common.Revision, 0)
+	err = json.Unmarshal(object, &history)
+
+	if err != nil {
+		return nil, err
+	}
+
+	return history, nil
+}
@@ -7,6 +7,7 @@ import (
 	"github.com/simplecontainer/smr/pkg/contexts"
 	"github.com/simplecontainer/smr/pkg/network"
 	"net/http"
+	"net/url"
 )
 
 func listResources(context *contexts.ClientContext, endpoint string) ([]json.RawMessage, error) {
@@ -25,13 +26,13 @@ func listResources(context *contexts.ClientContext, endpoint string) ([]json.Raw
 	return objects, nil
 }
 
-func ListKind(context *contexts.ClientContext, prefix string, version string, category string, kind string) ([]json.RawMessage, error) {
-	endpoint := fmt.Sprintf("/api/v1/kind/%s/%s/%s/%s", prefix, version, category, kind)
+func ListKind(context *contexts.ClientContext, prefix string, version string, category string, kind string, selector string) ([]json.RawMessage, error) {
+	endpoint := fmt.Sprintf("/api/v1/kind/%s/%s/%s/%s%s", prefix, version, category, kind, withSelector(selector))
 	return listResources(context, endpoint)
 }
 
-func ListState(context *contexts.ClientContext, prefix string, version string, category string, kind string) ([]json.RawMessage, error) {
-	endpoint := fmt.Sprintf("/api/v1/state/%s/%s/%s/%s", prefix, version, category, kind)
+func ListState(context *contexts.ClientContext, prefix string, version string, category string, kind string, selector string) ([]json.RawMessage, error) {
+	endpoint := fmt.Sprintf("/api/v1/state/%s/%s/%s/%s%s", prefix, version, category, kind, withSelector(selector))
 	return listResources(context, endpoint)
 }
 
@@ -39,3 +40,11 @@ func ListKindGroup(context *contexts.ClientContext, prefix string, version strin
 	endpoint := fmt.Sprintf("/api/v1/kind/%s/%s/%s/%s/%s", prefix, version, category, kind, group)
 	return listResources(context, endpoint)
 }
+
+func withSelector(selector string) string {
+	if selector == "" {
+		return ""
+	}
+
+	return fmt.Sprintf("?selector=%s", url.QueryEscape(selector))
+}
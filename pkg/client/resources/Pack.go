@@ -0,0 +1,46 @@
+package resources
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"github.com/simplecontainer/smr/pkg/contexts"
+	"github.com/simplecontainer/smr/pkg/network"
+	"github.com/simplecontainer/smr/pkg/packer"
+	"net/http"
+	"net/url"
+)
+
+// ApplyPack uploads the pack so the cluster verifies its signature against trust policy before rendering and proposing
+// definitions, returns proposed objects
+func ApplyPack(context *contexts.ClientContext, directory string, set []string) ([]string, error) {
+	var archive bytes.Buffer
+
+	err := packer.Archive(directory, &archive)
+
+	if err != nil {
+		return nil, err
+	}
+
+	query := url.Values{}
+
+	for _, s := range set {
+		query.Add("set", s)
+	}
+
+	response := network.Send(context.GetHTTPClient(), fmt.Sprintf("%s/api/v1/pack?%s", context.APIURL, query.Encode()), http.MethodPost, archive.Bytes())
+
+	if response.HttpStatus != http.StatusOK {
+		return nil, errors.New(fmt.Sprintf("%s %s", response.Explanation, response.ErrorExplanation))
+	}
+
+	proposed := make([]string, 0)
+	err = json.Unmarshal(response.Data, &proposed)
+
+	if err != nil {
+		return nil, err
+	}
+
+	return proposed, nil
+}
@@ -0,0 +1,58 @@
+package resources
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"github.com/simplecontainer/smr/pkg/contexts"
+	"github.com/simplecontainer/smr/pkg/network"
+	"github.com/simplecontainer/smr/pkg/packer/trust"
+	"net/http"
+	"net/url"
+)
+
+func ListTrust(context *contexts.ClientContext) ([]*trust.Policy, error) {
+	response := network.Send(context.GetHTTPClient(), fmt.Sprintf("%s/api/v1/trust", context.APIURL), http.MethodGet, nil)
+
+	if response.HttpStatus != http.StatusOK {
+		return nil, errors.New(response.ErrorExplanation)
+	}
+
+	policies := make([]*trust.Policy, 0)
+	err := json.Unmarshal(response.Data, &policies)
+
+	if err != nil {
+		return nil, err
+	}
+
+	return policies, nil
+}
+
+func SetTrust(context *contexts.ClientContext, scope string, keys []string) (string, error) {
+	data, err := json.Marshal(trust.Policy{Scope: scope, Keys: keys})
+
+	if err != nil {
+		return "", err
+	}
+
+	response := network.Send(context.GetHTTPClient(), fmt.Sprintf("%s/api/v1/trust", context.APIURL), http.MethodPut, data)
+
+	if response.HttpStatus != http.StatusOK {
+		return "", errors.New(fmt.Sprintf("%s %s", response.Explanation, response.ErrorExplanation))
+	}
+
+	return response.Explanation, nil
+}
+
+func RemoveTrust(context *contexts.ClientContext, scope string) (string, error) {
+	query := url.Values{}
+	query.Set("scope", scope)
+
+	response := network.Send(context.GetHTTPClient(), fmt.Sprintf("%s/api/v1/trust?%s", context.APIURL, query.Encode()), http.MethodDelete, nil)
+
+	if response.HttpStatus != http.StatusOK {
+		return "", errors.New(response.ErrorExplanation)
+	}
+
+	return response.Explanation, nil
+}
@@ -0,0 +1,89 @@
+package resources
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"github.com/simplecontainer/smr/pkg/contexts"
+	"github.com/simplecontainer/smr/pkg/network"
+	"github.com/simplecontainer/smr/pkg/revocation"
+	"net/http"
+	"net/url"
+)
+
+func ListUsers(context *contexts.ClientContext) ([]revocation.Certificate, error) {
+	response := network.Send(context.GetHTTPClient(), fmt.Sprintf("%s/api/v1/user", context.APIURL), http.MethodGet, nil)
+
+	if response.HttpStatus != http.StatusOK {
+		return nil, errors.New(response.ErrorExplanation)
+	}
+
+	certificates := make([]revocation.Certificate, 0)
+	err := json.Unmarshal(response.Data, &certificates)
+
+	if err != nil {
+		return nil, err
+	}
+
+	return certificates, nil
+}
+
+// CreateUser returns the pem bundle with key, certificate and CA of the created user
+func CreateUser(context *contexts.ClientContext, username string, domain string, externalIP string, expiry string) (string, error) {
+	query := url.Values{}
+
+	if expiry != "" {
+		query.Set("expiry", expiry)
+	}
+
+	URL := fmt.Sprintf("%s/api/v1/user/%s/%s/%s?%s", context.APIURL, url.PathEscape(username), url.PathEscape(domain), url.PathEscape(externalIP), query.Encode())
+	response := network.Send(context.GetHTTPClient(), URL, http.MethodPost, nil)
+
+	if response.HttpStatus != http.StatusOK {
+		return "", errors.New(fmt.Sprintf("%s %s", response.Explanation, response.ErrorExplanation))
+	}
+
+	var bundle string
+	err := json.Unmarshal(response.Data, &bundle)
+
+	if err != nil {
+		return "", err
+	}
+
+	return bundle, nil
+}
+
+// RenewUser returns the pem bundle with the certificate reissued by the signing CA
+func RenewUser(context *contexts.ClientContext, username string, expiry string) (string, error) {
+	query := url.Values{}
+
+	if expiry != "" {
+		query.Set("expiry", expiry)
+	}
+
+	URL := fmt.Sprintf("%s/api/v1/user/%s?%s", context.APIURL, url.PathEscape(username), query.Encode())
+	response := network.Send(context.GetHTTPClient(), URL, http.MethodPut, nil)
+
+	if response.HttpStatus != http.StatusOK {
+		return "", errors.New(fmt.Sprintf("%s %s", response.Explanation, response.ErrorExplanation))
+	}
+
+	var bundle string
+	err := json.Unmarshal(response.Data, &bundle)
+
+	if err != nil {
+		return "", err
+	}
+
+	return bundle, nil
+}
+
+func RevokeUser(context *contexts.ClientContext, username string) (string, error) {
+	response := network.Send(context.GetHTTPClient(), fmt.Sprintf("%s/api/v1/user/%s", context.APIURL, url.PathEscape(username)), http.MethodDelete, nil)
+
+	if response.HttpStatus != http.StatusOK {
+		return "", errors.New(response.ErrorExplanation)
+	}
+
+	return response.Explanation, nil
+}
@@ -59,7 +59,7 @@ func GenerateHttpClients(keys *keys.Keys, hostPort configuration.HostPort, clust
 
 	// Configure custom users
 	for username, c := range keys.Clients {
-		httpClient, err := GenerateHttpClient(keys.CA, c)
+		httpClient, err := GenerateHttpClient(keys.Pool(), c)
 
 		if err != nil {
 			return nil, err
@@ -85,7 +85,7 @@ func GenerateHttpClients(keys *keys.Keys, hostPort configuration.HostPort, clust
 				_, ok := keys.Clients[cluster.Node.NodeName]
 
 				if ok {
-					httpClient, err := GenerateHttpClient(keys.CA, keys.Clients[cluster.Node.NodeName])
+					httpClient, err := GenerateHttpClient(keys.Pool(), keys.Clients[cluster.Node.NodeName])
 
 					if err != nil {
 						return nil, err
@@ -110,7 +110,7 @@ func GenerateHttpClients(keys *keys.Keys, hostPort configuration.HostPort, clust
 	return hc, nil
 }
 
-func GenerateHttpClient(ca *keys.CA, client *keys.Client) (*http.Client, error) {
+func GenerateHttpClient(CAPool *x509.CertPool, client *keys.Client) (*http.Client, error) {
 	var PEMCertificate []byte = make([]byte, 0)
 	var PEMPrivateKey []byte = make([]byte, 0)
 
@@ -124,9 +124,6 @@ func GenerateHttpClient(ca *keys.CA, client *keys.Client) (*http.Client, error)
 		return nil, err
 	}
 
-	CAPool := x509.NewCertPool()
-	CAPool.AddCert(ca.Certificate)
-
 	dialer := &net.Dialer{
 		Timeout: 5 * time.Second,
 	}
@@ -32,14 +32,14 @@ func (cluster *Cluster) Regenerate(config *configuration.Configuration, keys *ke
 		}
 	}
 
-	err = keys.GenerateClient(config.Certificates.Domains, config.Certificates.IPs, config.NodeName)
+	err = keys.GenerateClient(config.Certificates.Domains, config.Certificates.IPs, config.NodeName, config.Certificates.Expiry)
 
 	if err != nil {
 		logger.Log.Error(err.Error())
 		return
 	}
 
-	err = keys.GenerateServer(config.Certificates.Domains, config.Certificates.IPs)
+	err = keys.GenerateServer(config.Certificates.Domains, config.Certificates.IPs, config.Certificates.Expiry)
 
 	if err != nil {
 		logger.Log.Error(err.Error())
@@ -57,4 +57,11 @@ func (cluster *Cluster) Regenerate(config *configuration.Configuration, keys *ke
 		logger.Log.Error(err.Error())
 		return
 	}
+
+	// Bundle is what contexts are created from so it must carry the node identity of the regenerated certificate
+	err = keys.GeneratePemBundle(static.SMR_SSH_HOME, config.NodeName, keys.Clients[config.NodeName])
+	if err != nil {
+		logger.Log.Error(err.Error())
+		return
+	}
 }
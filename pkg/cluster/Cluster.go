@@ -38,6 +38,8 @@ func Restore(config *configuration.Configuration) (*Cluster, error) {
 	cluster := node.NewNodes()
 
 	for _, c := range config.KVStore.Cluster {
+		// Saved reachability can be stale, leader announces unreachable members once the node is started
+		c.State.Health.Unreachable = false
 		cluster.Add(c)
 	}
 
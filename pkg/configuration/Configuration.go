@@ -20,10 +20,13 @@ func NewConfig() *Configuration {
 			Container: NewEnvironment(WithContainerConfig()),
 			Host:      NewEnvironment(WithHostConfig()),
 		},
-		Certificates: &Certificates{},
+		Certificates: &Certificates{Expiry: DEFAULT_CERTIFICATE_EXPIRY},
 		Etcd:         DefaultEtcdConfig(),
 		RaftConfig:   DefaultRaftConfig(),
 		Flannel:      DefaultFlannelConfig(),
+		Secrets:      &Secrets{Refresh: DEFAULT_SECRETS_REFRESH, Mounts: DEFAULT_SECRETS_MOUNTS, Vault: &Vault{}},
+		Admission:    &Admission{Images: &ImagesAdmission{}},
+		Gitops:       &Gitops{},
 	}
 }
 
@@ -0,0 +1,25 @@
+package configuration
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+)
+
+// NewLabels parses key=value pairs into node labels
+func NewLabels(pairs []string) (map[string]string, error) {
+	labels := make(map[string]string)
+
+	for _, pair := range pairs {
+		key, value, found := strings.Cut(pair, "=")
+		key = strings.TrimSpace(key)
+
+		if !found || key == "" {
+			return nil, errors.New(fmt.Sprintf("invalid label %s - expected key=value", pair))
+		}
+
+		labels[key] = strings.TrimSpace(value)
+	}
+
+	return labels, nil
+}
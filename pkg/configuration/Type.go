@@ -12,6 +12,7 @@ type Configuration struct {
 	NodeImage    string                `mapstructure:"nodeImage"`
 	NodeTag      string                `mapstructure:"nodeTag"`
 	NodeName     string                `mapstructure:"nodeName"`
+	Labels       map[string]string     `mapstructure:"labels"`
 	HostPort     HostPort              `mapstructure:"hostport"`
 	KVStore      *KVStore              `mapstructure:"kvstore"`
 	Certificates *Certificates         `mapstructure:"certificates"`
@@ -19,6 +20,9 @@ type Configuration struct {
 	Etcd         *EtcdConfiguration    `mapstructure:"etcd"`
 	RaftConfig   *RaftConfiguration    `mapstructure:"raftConfig"`
 	Flannel      *FlannelConfiguration `mapstructure:"flannel"`
+	Secrets      *Secrets              `mapstructure:"secrets"`
+	Admission    *Admission            `mapstructure:"admission"`
+	Gitops       *Gitops               `mapstructure:"gitops"`
 }
 
 type HostPort struct {
@@ -55,11 +59,65 @@ type Ports struct {
 	Overlay string `mapstructure:"overlay"`
 	Etcd    string `mapstructure:"etcd"`
 	Traefik string `mapstructure:"traefik"`
+	Webhook string `mapstructure:"webhook"`
 }
 
+const DEFAULT_CERTIFICATE_EXPIRY = 10 * 365 * 24 * time.Hour
+
 type Certificates struct {
-	Domains *Domains `mapstructure:"domains"`
-	IPs     *IPs     `mapstructure:"ips"`
+	Domains *Domains      `mapstructure:"domains"`
+	IPs     *IPs          `mapstructure:"ips"`
+	Expiry  time.Duration `mapstructure:"expiry"`
+}
+
+const DEFAULT_SECRETS_REFRESH = 5 * time.Minute
+const DEFAULT_SECRETS_MOUNTS = "/run/smr/secrets"
+
+type Secrets struct {
+	Refresh    time.Duration `mapstructure:"refresh"`
+	Directory  string        `mapstructure:"directory"`
+	AgeKeyFile string        `mapstructure:"ageKeyFile"`
+	Mounts     string        `mapstructure:"mounts"`
+	Vault      *Vault        `mapstructure:"vault"`
+}
+
+type Vault struct {
+	Address   string `mapstructure:"address"`
+	Namespace string `mapstructure:"namespace"`
+	TokenFile string `mapstructure:"tokenFile"`
+	CACert    string `mapstructure:"caCert"`
+}
+
+type Admission struct {
+	Images         *ImagesAdmission    `mapstructure:"images"`
+	RequiredLabels []string            `mapstructure:"requiredLabels"`
+	DenyPrivileged bool                `mapstructure:"denyPrivileged"`
+	Webhooks       []*AdmissionWebhook `mapstructure:"webhooks"`
+}
+
+const DEFAULT_ADMISSION_WEBHOOK_TIMEOUT = 10 * time.Second
+
+// AdmissionWebhook is external endpoint receiving definitions, it can reject them or respond with JSON patch - empty
+// kinds means every kind except sealed ones (secret, httpauth, certkey), those are sent redacted and only if listed
+type AdmissionWebhook struct {
+	Name     string        `mapstructure:"name"`
+	URL      string        `mapstructure:"url"`
+	Kinds    []string      `mapstructure:"kinds"`
+	Timeout  time.Duration `mapstructure:"timeout"`
+	FailOpen bool          `mapstructure:"failOpen"`
+	CA       string        `mapstructure:"ca"`
+}
+
+// ImagesAdmission restricts images containers can run, keys are PEM public keys cosign signatures are verified with
+type ImagesAdmission struct {
+	RequireDigest bool     `mapstructure:"requireDigest"`
+	Registries    []string `mapstructure:"registries"`
+	Keys          []string `mapstructure:"keys"`
+}
+
+// Gitops webhook secret can be a secret reference eg. vault://secret/smr/gitops#webhook
+type Gitops struct {
+	WebhookSecret string `mapstructure:"webhookSecret"`
 }
 
 type IPs struct {
@@ -80,6 +138,7 @@ func NewTimeouts() *Timeouts {
 		EtcdConnectionTimeout:     5 * time.Second,
 		NodeStartupTimeout:        60 * time.Second,
 		LeadershipTransferTimeout: 60 * time.Second,
+		NodeFailureTimeout:        30 * time.Second,
 	}
 }
 
@@ -90,6 +149,7 @@ type Timeouts struct {
 	EtcdConnectionTimeout     time.Duration `mapstructure:"etcd_connection_timeout"`
 	NodeStartupTimeout        time.Duration `mapstructure:"node_startup_timeout"`
 	LeadershipTransferTimeout time.Duration `mapstructure:"leadership_transfer_timeout"`
+	NodeFailureTimeout        time.Duration `mapstructure:"node_failure_timeout"`
 }
 
 type EtcdConfiguration struct {
@@ -463,10 +463,14 @@ func (c *ClientContext) ImportCertificates(ctx context.Context, sshDir string) e
 		}
 	}
 
-	if err = importedKeys.CA.Write(sshDir); err != nil {
+	if err = importedKeys.WriteTrust(sshDir); err != nil {
 		return fmt.Errorf("failed to write CA: %w", err)
 	}
 
+	if err = c.importEncryption(client, key, sshDir); err != nil {
+		return err
+	}
+
 	for user, client := range importedKeys.Clients {
 		if err = client.Write(sshDir, user); err != nil {
 			return fmt.Errorf("failed to write client certificate for %s: %w", user, err)
@@ -480,6 +484,42 @@ func (c *ClientContext) ImportCertificates(ctx context.Context, sshDir string) e
 	return nil
 }
 
+// importEncryption fetches key ring of the cluster, node can't join without it since it couldn't open sealed objects
+func (c *ClientContext) importEncryption(client *http.Client, key string, sshDir string) error {
+	response := network.Send(client, fmt.Sprintf("%s/fetch/encryption", c.APIURL), http.MethodGet, nil)
+
+	if !response.Success {
+		return fmt.Errorf("failed to fetch encryption key ring: %s", response.ErrorExplanation)
+	}
+
+	keysEncrypted := keys.Encrypted{}
+	bytes, err := response.Data.MarshalJSON()
+
+	if err != nil {
+		return fmt.Errorf("failed to marshal response data: %w", err)
+	}
+
+	if err = json.Unmarshal(bytes, &keysEncrypted); err != nil {
+		return fmt.Errorf("failed to unmarshal encrypted key ring: %w", err)
+	}
+
+	decrypted, err := encrypt.Decrypt(keysEncrypted.Keys, key)
+	if err != nil {
+		return fmt.Errorf("decryption failed: %w", err)
+	}
+
+	ring := encrypt.NewKeyRing()
+	if err = json.Unmarshal([]byte(decrypted), ring); err != nil {
+		return fmt.Errorf("failed to unmarshal encryption key ring: %w", err)
+	}
+
+	if err = ring.Write(sshDir); err != nil {
+		return fmt.Errorf("failed to write encryption key ring: %w", err)
+	}
+
+	return nil
+}
+
 func (c *ClientContext) deriveKeyFromPrivateKey() (string, error) {
 	if c.Credentials.PrivateKey == nil || c.Credentials.PrivateKey.Len() == 0 {
 		return "", errors.New("private key is empty")
@@ -4,6 +4,8 @@ import (
 	"crypto/tls"
 	"github.com/gin-gonic/gin"
 	mdns "github.com/miekg/dns"
+	"github.com/simplecontainer/smr/pkg/admission"
+	"github.com/simplecontainer/smr/pkg/audit"
 	"github.com/simplecontainer/smr/pkg/authentication"
 	"github.com/simplecontainer/smr/pkg/cluster"
 	"github.com/simplecontainer/smr/pkg/configuration"
@@ -13,7 +15,9 @@ import (
 	"github.com/simplecontainer/smr/pkg/keys"
 	"github.com/simplecontainer/smr/pkg/manager"
 	"github.com/simplecontainer/smr/pkg/node"
+	"github.com/simplecontainer/smr/pkg/rbac"
 	"github.com/simplecontainer/smr/pkg/relations"
+	"github.com/simplecontainer/smr/pkg/revocation"
 	"github.com/simplecontainer/smr/pkg/version"
 	"github.com/simplecontainer/smr/pkg/wss"
 	clientv3 "go.etcd.io/etcd/client/v3"
@@ -70,6 +74,17 @@ type Api interface {
 	GetVersion() *version.Version
 	SetVersion(*version.Version)
 
+	GetAuditTrail() *audit.Audit
+	SetAuditTrail(*audit.Audit)
+
+	GetRevocations() *revocation.List
+	SetRevocations(*revocation.List)
+	WatchRevocations() error
+	WatchTrust() error
+
+	GetAdmission() *admission.Admission
+	SetAdmission(*admission.Admission)
+
 	HandleDns(w mdns.ResponseWriter, m *mdns.Msg)
 
 	Kind(c *gin.Context)
@@ -95,17 +110,35 @@ type Api interface {
 	GetNode(c *gin.Context)
 	GetNodeVersion(c *gin.Context)
 	AddNode(c *gin.Context)
+	SetNodeLabels(c *gin.Context)
 	RemoveNode(c *gin.Context)
+	SyncEncryption(c *gin.Context)
+	RotateEncryption(c *gin.Context)
+	ListCertificates(c *gin.Context)
+	SyncCertificates(c *gin.Context)
+	RolloverCA(c *gin.Context)
+	RenewCertificates()
 
 	Propose(c *gin.Context)
+	ApplyPack(c *gin.Context)
 	Debug(c *gin.Context)
 	Logs(c *gin.Context)
 	Exec(c *gin.Context)
 
 	CreateUser(c *gin.Context)
+	ListUsers(c *gin.Context)
+	RenewUser(c *gin.Context)
+	RevokeUser(c *gin.Context)
+	ListTrust(c *gin.Context)
+	GitopsWebhook(c *gin.Context)
+	SetTrust(c *gin.Context)
+	RemoveTrust(c *gin.Context)
+	Audit(c *gin.Context)
+	Authorize(user *authentication.User, request rbac.Request) error
 
 	Health(c *gin.Context)
 	ExportClients(c *gin.Context)
+	ExportEncryption(c *gin.Context)
 	DisplayVersion(c *gin.Context)
 	Events(c *gin.Context)
 
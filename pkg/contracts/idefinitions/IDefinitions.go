@@ -20,3 +20,8 @@ type IDefinition interface {
 	ToJSONString() (string, error)
 	Validate() (bool, error)
 }
+
+// ISealed is implemented by definitions carrying payload that is encrypted at rest
+type ISealed interface {
+	Transform(func(string) (string, error)) error
+}
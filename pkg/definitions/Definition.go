@@ -47,6 +47,12 @@ func NewImplementation(kind string) idefinitions.IDefinition {
 		def = v1.NewSecret()
 	case static.KIND_VOLUME:
 		def = v1.NewVolume()
+	case static.KIND_ROLE:
+		def = v1.NewRole()
+	case static.KIND_ROLEBINDING:
+		def = v1.NewRoleBinding()
+	case static.KIND_NOTIFICATION:
+		def = v1.NewNotification()
 	default:
 		def = nil
 	}
@@ -163,6 +169,33 @@ func (definition *Definition) UnmarshalJSON(data []byte) error {
 			return err
 		}
 
+		definition.Definition = tmp
+	case static.KIND_ROLE:
+		tmp := &v1.RoleDefinition{}
+
+		err := json.Unmarshal(raw.Definition, tmp)
+		if err != nil {
+			return err
+		}
+
+		definition.Definition = tmp
+	case static.KIND_ROLEBINDING:
+		tmp := &v1.RoleBindingDefinition{}
+
+		err := json.Unmarshal(raw.Definition, tmp)
+		if err != nil {
+			return err
+		}
+
+		definition.Definition = tmp
+	case static.KIND_NOTIFICATION:
+		tmp := &v1.NotificationDefinition{}
+
+		err := json.Unmarshal(raw.Definition, tmp)
+		if err != nil {
+			return err
+		}
+
 		definition.Definition = tmp
 	default:
 		definition.Definition = nil
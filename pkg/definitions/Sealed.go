@@ -0,0 +1,87 @@
+package definitions
+
+import (
+	"github.com/simplecontainer/smr/pkg/contracts/idefinitions"
+	"github.com/simplecontainer/smr/pkg/encrypt"
+)
+
+// Seal encrypts payload of sealed kinds with the ring, definitions of other kinds are returned unchanged
+func Seal(kind string, data []byte, ring *encrypt.KeyRing) ([]byte, error) {
+	if ring == nil {
+		return data, nil
+	}
+
+	return transform(kind, data, ring.Seal)
+}
+
+// Open decrypts payload of sealed kinds, values stored before encryption was enabled pass through
+func Open(kind string, data []byte, ring *encrypt.KeyRing) ([]byte, error) {
+	if ring == nil {
+		return data, nil
+	}
+
+	return transform(kind, data, ring.Open)
+}
+
+// Reseal re-encrypts payload with the current primary key of the ring
+func Reseal(kind string, data []byte, ring *encrypt.KeyRing) ([]byte, error) {
+	if ring == nil {
+		return data, nil
+	}
+
+	return transform(kind, data, func(value string) (string, error) {
+		if ring.Current(value) {
+			return value, nil
+		}
+
+		opened, err := ring.Open(value)
+
+		if err != nil {
+			return "", err
+		}
+
+		return ring.Seal(opened)
+	})
+}
+
+// Redact replaces payload of sealed kinds with placeholder so definition can be shown outside the cluster
+func Redact(kind string, data []byte, placeholder string) ([]byte, error) {
+	return transform(kind, data, func(value string) (string, error) {
+		return placeholder, nil
+	})
+}
+
+// IsSealed reports whether definitions of the kind carry payload encrypted at rest
+func IsSealed(kind string) bool {
+	_, ok := NewImplementation(kind).(idefinitions.ISealed)
+	return ok
+}
+
+func transform(kind string, data []byte, fn func(string) (string, error)) ([]byte, error) {
+	definition := NewImplementation(kind)
+	sealed, ok := definition.(idefinitions.ISealed)
+
+	if !ok {
+		return data, nil
+	}
+
+	err := definition.FromJson(data)
+
+	if err != nil {
+		return nil, err
+	}
+
+	err = sealed.Transform(func(value string) (string, error) {
+		if value == "" {
+			return value, nil
+		}
+
+		return fn(value)
+	})
+
+	if err != nil {
+		return nil, err
+	}
+
+	return definition.ToJSON()
+}
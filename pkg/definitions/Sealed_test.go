@@ -0,0 +1,37 @@
+package definitions
+
+import (
+	"github.com/simplecontainer/smr/pkg/encrypt"
+	"github.com/simplecontainer/smr/pkg/static"
+	"github.com/stretchr/testify/assert"
+	"testing"
+)
+
+func TestSeal(t *testing.T) {
+	ring := encrypt.NewKeyRing()
+	_, err := ring.Generate()
+	assert.NoError(t, err)
+
+	secret := []byte(`{"kind":"secret","prefix":"simplecontainer.io/v1","meta":{"group":"test","name":"db"},"spec":{"data":{"password":"s3cr3t"}}}`)
+
+	sealed, err := Seal(static.KIND_SECRET, secret, ring)
+	assert.NoError(t, err)
+	assert.NotContains(t, string(sealed), "s3cr3t")
+
+	_, err = ring.Generate()
+	assert.NoError(t, err)
+
+	resealed, err := Reseal(static.KIND_SECRET, sealed, ring)
+	assert.NoError(t, err)
+	assert.NotEqual(t, string(sealed), string(resealed))
+
+	opened, err := Open(static.KIND_SECRET, resealed, ring)
+	assert.NoError(t, err)
+	assert.Contains(t, string(opened), `"password":"s3cr3t"`)
+
+	configuration := []byte(`{"kind":"configuration"}`)
+
+	untouched, err := Seal(static.KIND_CONFIGURATION, configuration, ring)
+	assert.NoError(t, err)
+	assert.Equal(t, configuration, untouched)
+}
@@ -76,6 +76,27 @@ func (certkey *CertKeyDefinition) ResolveReferences(obj iobjects.ObjectInterface
 	return nil, nil
 }
 
+// Transform applies fn to private material, used to seal and open the payload kept at rest
+func (certkey *CertKeyDefinition) Transform(fn func(string) (string, error)) error {
+	for _, field := range []*string{
+		&certkey.Spec.PrivateKey,
+		&certkey.Spec.PrivateKeyPassword,
+		&certkey.Spec.KeyStore,
+		&certkey.Spec.KeyStorePassword,
+		&certkey.Spec.CertStorePassword,
+	} {
+		transformed, err := fn(*field)
+
+		if err != nil {
+			return err
+		}
+
+		*field = transformed
+	}
+
+	return nil
+}
+
 func (certkey *CertKeyDefinition) FromJson(bytes []byte) error {
 	return json.Unmarshal(bytes, certkey)
 }
@@ -29,11 +29,13 @@ type ContainersInternal struct {
 	Args           []string                   `json:"args,omitempty" yaml:"args,omitempty"`
 	Dependencies   []ContainersDependsOn      `json:"dependencies,omitempty" yaml:"dependencies,omitempty"`
 	Readiness      []ContainersReadiness      `json:"readiness,omitempty" yaml:"readiness,omitempty"`
+	Liveness       []ContainersLiveness       `json:"liveness,omitempty" yaml:"liveness,omitempty"`
 	Networks       []ContainersNetwork        `json:"networks,omitempty" yaml:"networks,omitempty"`
 	Ports          []ContainersPort           `json:"ports,omitempty" yaml:"ports,omitempty"`
 	Volumes        []ContainersVolume         `json:"volumes,omitempty" yaml:"volumes,omitempty"`
 	Configuration  map[string]string          `json:"configuration,omitempty" yaml:"configuration,omitempty"`
 	Resources      []ContainersResource       `json:"resources,omitempty" yaml:"resources,omitempty"`
+	Secrets        []ContainersSecret         `json:"secrets,omitempty" yaml:"secrets,omitempty"`
 	Configurations []ContainersConfigurations `json:"configurations,omitempty" yaml:"configurations,omitempty"`
 	Replicas       uint64                     `json:"replicas,omitempty" yaml:"replicas,omitempty"`
 	Capabilities   []string                   `json:"capabilities,omitempty" yaml:"capabilities,omitempty"`
@@ -42,8 +44,15 @@ type ContainersInternal struct {
 	Privileged     bool                       `json:"privileged,omitempty" yaml:"privileged,omitempty"`
 	NetworkMode    string                     `json:"network_mode,omitempty" yaml:"network_mode,omitempty"`
 	Spread         *ContainersSpread          `json:"spread,omitempty" yaml:"spread,omitempty"`
+	NodeSelector   map[string]string          `json:"nodeSelector,omitempty" yaml:"nodeSelector,omitempty"`
+	Affinity       *ContainersAffinity        `json:"affinity,omitempty" yaml:"affinity,omitempty"`
+	AntiAffinity   *ContainersAntiAffinity    `json:"antiAffinity,omitempty" yaml:"antiAffinity,omitempty"`
 	Nodes          []string                   `json:"nodes,omitempty" yaml:"nodes,omitempty"`
 	Dns            []string                   `json:"dns,omitempty" yaml:"dns,omitempty"`
+	Limits         *ContainersLimits          `json:"limits,omitempty" yaml:"limits,omitempty"`
+	Requests       *ContainersRequests        `json:"requests,omitempty" yaml:"requests,omitempty"`
+	UpdateStrategy *ContainersUpdateStrategy  `json:"updateStrategy,omitempty" yaml:"updateStrategy,omitempty"`
+	Autoscale      *ContainersAutoscale       `json:"autoscale,omitempty" yaml:"autoscale,omitempty"`
 }
 
 func NewContainers() *ContainersDefinition {
@@ -80,14 +89,81 @@ type ContainersReadiness struct {
 	Body    map[string]string `json:"body"`
 	Method  string            `json:"method"`
 	Command []string          `json:"command"`
+	Network string            `json:"network"`
+	Port    string            `json:"port"`
+	Service string            `json:"service"`
 	Timeout string            `validate:"required" json:"timeout"`
 }
 
+type ContainersLiveness struct {
+	Name             string            `validate:"required" json:"name"`
+	Type             string            `json:"type"`
+	URL              string            `json:"url"`
+	Body             map[string]string `json:"body"`
+	Method           string            `json:"method"`
+	Command          []string          `json:"command"`
+	Network          string            `json:"network"`
+	Port             string            `json:"port"`
+	Timeout          string            `json:"timeout"`
+	Period           string            `json:"period"`
+	InitialDelay     string            `json:"initialDelay"`
+	FailureThreshold uint64            `json:"failureThreshold"`
+}
+
 type ContainersSpread struct {
 	Spread string   `json:"spread,omitempty"`
 	Agents []uint64 `json:"agents,omitempty"`
 }
 
+// ContainersAffinity restricts replicas to nodes matching the label selector, e.g. disk=ssd,zone in (a,b)
+type ContainersAffinity struct {
+	Nodes string `json:"nodes,omitempty" yaml:"nodes,omitempty"`
+}
+
+// ContainersAntiAffinity keeps replicas away from nodes matching the label selector and from each other
+type ContainersAntiAffinity struct {
+	Nodes    string `json:"nodes,omitempty" yaml:"nodes,omitempty"`
+	Replicas bool   `json:"replicas,omitempty" yaml:"replicas,omitempty"`
+}
+
+// ContainersAutoscale keeps average utilisation of replicas near the targets, targets are percentages
+type ContainersAutoscale struct {
+	MinReplicas  uint64 `json:"minReplicas,omitempty" yaml:"minReplicas,omitempty"`
+	MaxReplicas  uint64 `json:"maxReplicas,omitempty" yaml:"maxReplicas,omitempty"`
+	TargetCPU    uint64 `json:"targetCPU,omitempty" yaml:"targetCPU,omitempty"`
+	TargetMemory uint64 `json:"targetMemory,omitempty" yaml:"targetMemory,omitempty"`
+	Cooldown     string `json:"cooldown,omitempty" yaml:"cooldown,omitempty"`
+}
+
+type ContainersLimits struct {
+	CPU        string             `json:"cpu,omitempty" yaml:"cpu,omitempty"`
+	CPUShares  int64              `json:"cpuShares,omitempty" yaml:"cpuShares,omitempty"`
+	Memory     string             `json:"memory,omitempty" yaml:"memory,omitempty"`
+	MemorySwap string             `json:"memorySwap,omitempty" yaml:"memorySwap,omitempty"`
+	Pids       int64              `json:"pids,omitempty" yaml:"pids,omitempty"`
+	ShmSize    string             `json:"shmSize,omitempty" yaml:"shmSize,omitempty"`
+	Ulimits    []ContainersUlimit `json:"ulimits,omitempty" yaml:"ulimits,omitempty"`
+}
+
+type ContainersRequests struct {
+	CPU    string `json:"cpu,omitempty" yaml:"cpu,omitempty"`
+	Memory string `json:"memory,omitempty" yaml:"memory,omitempty"`
+}
+
+type ContainersUpdateStrategy struct {
+	Type           string `json:"type,omitempty" yaml:"type,omitempty"`
+	MaxUnavailable uint64 `json:"maxUnavailable,omitempty" yaml:"maxUnavailable,omitempty"`
+	MaxSurge       uint64 `json:"maxSurge,omitempty" yaml:"maxSurge,omitempty"`
+	Timeout        string `json:"timeout,omitempty" yaml:"timeout,omitempty"`
+	OnFailure      string `json:"onFailure,omitempty" yaml:"onFailure,omitempty"`
+}
+
+type ContainersUlimit struct {
+	Name string `validate:"required" json:"name" yaml:"name"`
+	Soft int64  `json:"soft" yaml:"soft"`
+	Hard int64  `json:"hard" yaml:"hard"`
+}
+
 type ContainersNetwork struct {
 	Group string `json:"group"`
 	Name  string `json:"name"`
@@ -115,6 +191,20 @@ type ContainersResource struct {
 	Permissions *FileInfo
 }
 
+// ContainersSecret mounts keys of the secret as files on tmpfs, without items every key is mounted under its name
+type ContainersSecret struct {
+	Name        string                 `json:"name" yaml:"name"`
+	Group       string                 `json:"group" yaml:"group"`
+	MountPoint  string                 `json:"mountPoint" yaml:"mountPoint"`
+	Items       []ContainersSecretItem `json:"items,omitempty" yaml:"items,omitempty"`
+	Permissions *FileInfo              `json:"fileInfo,omitempty" yaml:"fileInfo,omitempty"`
+}
+
+type ContainersSecretItem struct {
+	Key  string `json:"key" yaml:"key"`
+	Path string `json:"path,omitempty" yaml:"path,omitempty"`
+}
+
 type FileInfo struct {
 	Owner       *int    `json:"owner"`
 	Group       *int    `json:"group"`
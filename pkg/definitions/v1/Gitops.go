@@ -26,8 +26,21 @@ type GitopsSpec struct {
 	DirectoryPath   string             `json:"directoryPath"`
 	PoolingInterval string             `json:"poolingInterval"`
 	AutomaticSync   bool               `json:"automaticSync"`
+	Prune           bool               `json:"prune"`
+	WaveTimeout     string             `json:"waveTimeout"`
 	CertKeyRef      *GitopsCertKeyRef  `json:"certKeyRef"`
 	HttpAuthRef     *GitopsHttpauthRef `json:"httpAuthRef"`
+	Overlays        []*GitopsOverlay   `json:"overlays"`
+}
+
+// GitopsOverlay is layered on top of the base directory, empty repository and revision point to the base ones,
+// credentials of the base are only used for the base repository
+type GitopsOverlay struct {
+	RepoURL       string             `json:"repoURL"`
+	Revision      string             `json:"revision"`
+	DirectoryPath string             `json:"directoryPath"`
+	CertKeyRef    *GitopsCertKeyRef  `json:"certKeyRef"`
+	HttpAuthRef   *GitopsHttpauthRef `json:"httpAuthRef"`
 }
 
 type GitopsCertKeyRef struct {
@@ -86,10 +99,18 @@ func (gitops *GitopsDefinition) GetKind() string {
 }
 
 func (gitops *GitopsDefinition) ResolveReferences(obj iobjects.ObjectInterface) ([]idefinitions.IDefinition, error) {
+	return resolveGitopsAuth(obj, gitops.Spec.HttpAuthRef, gitops.Spec.CertKeyRef)
+}
+
+func (overlay *GitopsOverlay) ResolveReferences(obj iobjects.ObjectInterface) ([]idefinitions.IDefinition, error) {
+	return resolveGitopsAuth(obj, overlay.HttpAuthRef, overlay.CertKeyRef)
+}
+
+func resolveGitopsAuth(obj iobjects.ObjectInterface, httpAuthRef *GitopsHttpauthRef, certKeyRef *GitopsCertKeyRef) ([]idefinitions.IDefinition, error) {
 	references := make([]idefinitions.IDefinition, 0)
 
-	if gitops.Spec.HttpAuthRef != nil {
-		format := f.New(gitops.Spec.HttpAuthRef.Prefix, "kind", static.KIND_HTTPAUTH, gitops.Spec.HttpAuthRef.Group, gitops.Spec.HttpAuthRef.Name)
+	if httpAuthRef != nil {
+		format := f.New(httpAuthRef.Prefix, "kind", static.KIND_HTTPAUTH, httpAuthRef.Group, httpAuthRef.Name)
 		obj.Find(format)
 
 		if !obj.Exists() {
@@ -107,8 +128,8 @@ func (gitops *GitopsDefinition) ResolveReferences(obj iobjects.ObjectInterface)
 		references = append(references, httpauth)
 	}
 
-	if gitops.Spec.CertKeyRef != nil {
-		format := f.New(gitops.Spec.CertKeyRef.Prefix, "kind", static.KIND_CERTKEY, gitops.Spec.CertKeyRef.Group, gitops.Spec.CertKeyRef.Name)
+	if certKeyRef != nil {
+		format := f.New(certKeyRef.Prefix, "kind", static.KIND_CERTKEY, certKeyRef.Group, certKeyRef.Name)
 
 		obj.Find(format)
 
@@ -71,6 +71,18 @@ func (httpauth *HttpAuthDefinition) ResolveReferences(obj iobjects.ObjectInterfa
 	return nil, nil
 }
 
+// Transform applies fn to the password, used to seal and open the payload kept at rest
+func (httpauth *HttpAuthDefinition) Transform(fn func(string) (string, error)) error {
+	password, err := fn(httpauth.Spec.Password)
+
+	if err != nil {
+		return err
+	}
+
+	httpauth.Spec.Password = password
+	return nil
+}
+
 func (httpauth *HttpAuthDefinition) FromJson(bytes []byte) error {
 	return json.Unmarshal(bytes, httpauth)
 }
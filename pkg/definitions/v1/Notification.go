@@ -0,0 +1,116 @@
+package v1
+
+import (
+	"encoding/json"
+	"errors"
+	"github.com/go-playground/validator/v10"
+	"github.com/simplecontainer/smr/pkg/contracts/idefinitions"
+	"github.com/simplecontainer/smr/pkg/contracts/iobjects"
+	"github.com/simplecontainer/smr/pkg/definitions/commonv1"
+	"github.com/simplecontainer/smr/pkg/static"
+	"gopkg.in/yaml.v3"
+)
+
+type NotificationDefinition struct {
+	Kind   string           `json:"kind" validate:"required"`
+	Prefix string           `json:"prefix" validate:"required"`
+	Meta   *commonv1.Meta   `json:"meta" validate:"required"`
+	Spec   NotificationSpec `json:"spec" validate:"required"`
+	State  *commonv1.State  `json:"state"`
+}
+
+// NotificationSpec posts message rendered from the template when watched object transitions to one of the events,
+// url can be a secret reference since incoming webhooks carry the token in the url
+type NotificationSpec struct {
+	Type      string            `json:"type" yaml:"type" validate:"required,oneof=slack matrix webhook"`
+	URL       string            `json:"url" yaml:"url" validate:"required"`
+	Headers   map[string]string `json:"headers" yaml:"headers"`
+	Kinds     []string          `json:"kinds" yaml:"kinds"`
+	Groups    []string          `json:"groups" yaml:"groups"`
+	Events    []string          `json:"events" yaml:"events"`
+	Template  string            `json:"template" yaml:"template"`
+	Retries   int               `json:"retries" yaml:"retries" validate:"gte=0"`
+	RateLimit string            `json:"rateLimit" yaml:"rateLimit"`
+}
+
+func NewNotification() *NotificationDefinition {
+	return &NotificationDefinition{
+		Kind:   "",
+		Prefix: "",
+		Meta: &commonv1.Meta{
+			Group:   "",
+			Name:    "",
+			Labels:  nil,
+			Runtime: &commonv1.Runtime{},
+		},
+		Spec:  NotificationSpec{},
+		State: nil,
+	}
+}
+
+func (notification *NotificationDefinition) GetPrefix() string {
+	return notification.Prefix
+}
+
+func (notification *NotificationDefinition) SetRuntime(runtime *commonv1.Runtime) {
+	notification.Meta.Runtime = runtime
+}
+
+func (notification *NotificationDefinition) GetRuntime() *commonv1.Runtime {
+	return notification.Meta.Runtime
+}
+
+func (notification *NotificationDefinition) GetMeta() *commonv1.Meta {
+	return notification.Meta
+}
+
+func (notification *NotificationDefinition) GetState() *commonv1.State {
+	return notification.State
+}
+
+func (notification *NotificationDefinition) SetState(state *commonv1.State) {
+	notification.State = state
+}
+
+func (notification *NotificationDefinition) GetKind() string {
+	return static.KIND_NOTIFICATION
+}
+
+func (notification *NotificationDefinition) ResolveReferences(obj iobjects.ObjectInterface) ([]idefinitions.IDefinition, error) {
+	return nil, nil
+}
+
+func (notification *NotificationDefinition) FromJson(bytes []byte) error {
+	return json.Unmarshal(bytes, notification)
+}
+
+func (notification *NotificationDefinition) ToJSON() ([]byte, error) {
+	bytes, err := json.Marshal(notification)
+	return bytes, err
+}
+
+func (notification *NotificationDefinition) ToYAML() ([]byte, error) {
+	bytes, err := yaml.Marshal(notification)
+	return bytes, err
+}
+
+func (notification *NotificationDefinition) ToJSONString() (string, error) {
+	bytes, err := json.Marshal(notification)
+	return string(bytes), err
+}
+
+func (notification *NotificationDefinition) Validate() (bool, error) {
+	validate := validator.New(validator.WithRequiredStructEnabled())
+
+	err := validate.Struct(notification)
+	if err != nil {
+		var invalidValidationError *validator.InvalidValidationError
+		if errors.As(err, &invalidValidationError) {
+			return false, err
+		}
+		// from here you can create your own error messages in whatever language you wish
+		return false, err
+	}
+
+	return true, nil
+}
@@ -0,0 +1,114 @@
+package v1
+
+import (
+	"encoding/json"
+	"errors"
+	"github.com/go-playground/validator/v10"
+	"github.com/simplecontainer/smr/pkg/contracts/idefinitions"
+	"github.com/simplecontainer/smr/pkg/contracts/iobjects"
+	"github.com/simplecontainer/smr/pkg/definitions/commonv1"
+	"github.com/simplecontainer/smr/pkg/static"
+	"gopkg.in/yaml.v3"
+)
+
+type RoleDefinition struct {
+	Kind   string          `json:"kind" validate:"required"`
+	Prefix string          `json:"prefix" validate:"required"`
+	Meta   *commonv1.Meta  `json:"meta" validate:"required"`
+	Spec   RoleSpec        `json:"spec" validate:"required"`
+	State  *commonv1.State `json:"state"`
+}
+
+type RoleSpec struct {
+	Rules []RoleRule `json:"rules" yaml:"rules" validate:"required,dive"`
+}
+
+// RoleRule grants verbs on kinds in groups, * matches anything, state and other categories of the kind are granted
+// as kind/category eg. containers/state
+type RoleRule struct {
+	Verbs  []string `json:"verbs" yaml:"verbs" validate:"required,min=1"`
+	Kinds  []string `json:"kinds" yaml:"kinds" validate:"required,min=1"`
+	Groups []string `json:"groups" yaml:"groups" validate:"required,min=1"`
+}
+
+func NewRole() *RoleDefinition {
+	return &RoleDefinition{
+		Kind:   "",
+		Prefix: "",
+		Meta: &commonv1.Meta{
+			Group:   "",
+			Name:    "",
+			Labels:  nil,
+			Runtime: &commonv1.Runtime{},
+		},
+		Spec:  RoleSpec{},
+		State: nil,
+	}
+}
+
+func (role *RoleDefinition) GetPrefix() string {
+	return role.Prefix
+}
+
+func (role *RoleDefinition) SetRuntime(runtime *commonv1.Runtime) {
+	role.Meta.Runtime = runtime
+}
+
+func (role *RoleDefinition) GetRuntime() *commonv1.Runtime {
+	return role.Meta.Runtime
+}
+
+func (role *RoleDefinition) GetMeta() *commonv1.Meta {
+	return role.Meta
+}
+
+func (role *RoleDefinition) GetState() *commonv1.State {
+	return role.State
+}
+
+func (role *RoleDefinition) SetState(state *commonv1.State) {
+	role.State = state
+}
+
+func (role *RoleDefinition) GetKind() string {
+	return static.KIND_ROLE
+}
+
+func (role *RoleDefinition) ResolveReferences(obj iobjects.ObjectInterface) ([]idefinitions.IDefinition, error) {
+	return nil, nil
+}
+
+func (role *RoleDefinition) FromJson(bytes []byte) error {
+	return json.Unmarshal(bytes, role)
+}
+
+func (role *RoleDefinition) ToJSON() ([]byte, error) {
+	bytes, err := json.Marshal(role)
+	return bytes, err
+}
+
+func (role *RoleDefinition) ToYAML() ([]byte, error) {
+	bytes, err := yaml.Marshal(role)
+	return bytes, err
+}
+
+func (role *RoleDefinition) ToJSONString() (string, error) {
+	bytes, err := json.Marshal(role)
+	return string(bytes), err
+}
+
+func (role *RoleDefinition) Validate() (bool, error) {
+	validate := validator.New(validator.WithRequiredStructEnabled())
+
+	err := validate.Struct(role)
+	if err != nil {
+		var invalidValidationError *validator.InvalidValidationError
+		if errors.As(err, &invalidValidationError) {
+			return false, err
+		}
+		// from here you can create your own error messages in whatever language you wish
+		return false, err
+	}
+
+	return true, nil
+}
@@ -0,0 +1,113 @@
+package v1
+
+import (
+	"encoding/json"
+	"errors"
+	"github.com/go-playground/validator/v10"
+	"github.com/simplecontainer/smr/pkg/contracts/idefinitions"
+	"github.com/simplecontainer/smr/pkg/contracts/iobjects"
+	"github.com/simplecontainer/smr/pkg/definitions/commonv1"
+	"github.com/simplecontainer/smr/pkg/static"
+	"gopkg.in/yaml.v3"
+)
+
+type RoleBindingDefinition struct {
+	Kind   string          `json:"kind" validate:"required"`
+	Prefix string          `json:"prefix" validate:"required"`
+	Meta   *commonv1.Meta  `json:"meta" validate:"required"`
+	Spec   RoleBindingSpec `json:"spec" validate:"required"`
+	State  *commonv1.State `json:"state"`
+}
+
+// RoleBindingSpec grants role to users identified by common name of their client certificate
+type RoleBindingSpec struct {
+	Role  RoleBindingRoleRef `json:"role" yaml:"role" validate:"required"`
+	Users []string           `json:"users" yaml:"users" validate:"required,min=1"`
+}
+
+type RoleBindingRoleRef struct {
+	Group string `json:"group" yaml:"group" validate:"required"`
+	Name  string `json:"name" yaml:"name" validate:"required"`
+}
+
+func NewRoleBinding() *RoleBindingDefinition {
+	return &RoleBindingDefinition{
+		Kind:   "",
+		Prefix: "",
+		Meta: &commonv1.Meta{
+			Group:   "",
+			Name:    "",
+			Labels:  nil,
+			Runtime: &commonv1.Runtime{},
+		},
+		Spec:  RoleBindingSpec{},
+		State: nil,
+	}
+}
+
+func (rolebinding *RoleBindingDefinition) GetPrefix() string {
+	return rolebinding.Prefix
+}
+
+func (rolebinding *RoleBindingDefinition) SetRuntime(runtime *commonv1.Runtime) {
+	rolebinding.Meta.Runtime = runtime
+}
+
+func (rolebinding *RoleBindingDefinition) GetRuntime() *commonv1.Runtime {
+	return rolebinding.Meta.Runtime
+}
+
+func (rolebinding *RoleBindingDefinition) GetMeta() *commonv1.Meta {
+	return rolebinding.Meta
+}
+
+func (rolebinding *RoleBindingDefinition) GetState() *commonv1.State {
+	return rolebinding.State
+}
+
+func (rolebinding *RoleBindingDefinition) SetState(state *commonv1.State) {
+	rolebinding.State = state
+}
+
+func (rolebinding *RoleBindingDefinition) GetKind() string {
+	return static.KIND_ROLEBINDING
+}
+
+func (rolebinding *RoleBindingDefinition) ResolveReferences(obj iobjects.ObjectInterface) ([]idefinitions.IDefinition, error) {
+	return nil, nil
+}
+
+func (rolebinding *RoleBindingDefinition) FromJson(bytes []byte) error {
+	return json.Unmarshal(bytes, rolebinding)
+}
+
+func (rolebinding *RoleBindingDefinition) ToJSON() ([]byte, error) {
+	bytes, err := json.Marshal(rolebinding)
+	return bytes, err
+}
+
+func (rolebinding *RoleBindingDefinition) ToYAML() ([]byte, error) {
+	bytes, err := yaml.Marshal(rolebinding)
+	return bytes, err
+}
+
+func (rolebinding *RoleBindingDefinition) ToJSONString() (string, error) {
+	bytes, err := json.Marshal(rolebinding)
+	return string(bytes), err
+}
+
+func (rolebinding *RoleBindingDefinition) Validate() (bool, error) {
+	validate := validator.New(validator.WithRequiredStructEnabled())
+
+	err := validate.Struct(rolebinding)
+	if err != nil {
+		var invalidValidationError *validator.InvalidValidationError
+		if errors.As(err, &invalidValidationError) {
+			return false, err
+		}
+		// from here you can create your own error messages in whatever language you wish
+		return false, err
+	}
+
+	return true, nil
+}
@@ -69,6 +69,21 @@ func (secret *SecretDefinition) ResolveReferences(obj iobjects.ObjectInterface)
 	return nil, nil
 }
 
+// Transform applies fn to every data value, used to seal and open the payload kept at rest
+func (secret *SecretDefinition) Transform(fn func(string) (string, error)) error {
+	for key, value := range secret.Spec.Data {
+		transformed, err := fn(value)
+
+		if err != nil {
+			return err
+		}
+
+		secret.Spec.Data[key] = transformed
+	}
+
+	return nil
+}
+
 func (secret *SecretDefinition) FromJson(bytes []byte) error {
 	return json.Unmarshal(bytes, secret)
 }
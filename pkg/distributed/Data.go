@@ -8,6 +8,8 @@ import (
 	"github.com/simplecontainer/smr/pkg/authentication"
 	"github.com/simplecontainer/smr/pkg/clients"
 	"github.com/simplecontainer/smr/pkg/contracts/iformat"
+	"github.com/simplecontainer/smr/pkg/definitions"
+	"github.com/simplecontainer/smr/pkg/encrypt"
 	"github.com/simplecontainer/smr/pkg/f"
 	"github.com/simplecontainer/smr/pkg/kinds/common"
 	"github.com/simplecontainer/smr/pkg/logger"
@@ -71,8 +73,16 @@ func (replication *Replication) ListenData(agent string) {
 func (replication *Replication) HandleObject(format iformat.Format, data KV.KV) {
 	defer acks.ACKS.Ack(format.GetUUID())
 
+	// Kinds work with plaintext, payload is sealed again when stored locally
+	value, err := definitions.Open(format.GetKind(), data.Val, encrypt.Ring)
+
+	if err != nil {
+		logger.Log.Error("failed to decrypt object", zap.String("format", format.ToString()), zap.Error(err))
+		return
+	}
+
 	request, _ := common.NewRequest(format.GetKind())
-	err := request.Definition.FromJson(data.Val)
+	err = request.Definition.FromJson(value)
 
 	if err != nil {
 		logger.Log.Error(err.Error())
@@ -0,0 +1,197 @@
+package encrypt
+
+import (
+	"crypto/rand"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+var ERROR_UNKNOWN_KEY = errors.New("value is encrypted with unknown cluster key")
+var ERROR_INVALID_ENVELOPE = errors.New("invalid encryption envelope")
+
+func NewKeyRing() *KeyRing {
+	return &KeyRing{
+		Keys: make(map[string]string),
+	}
+}
+
+// IsSealed reports whether value is an envelope produced by Seal
+func IsSealed(value string) bool {
+	return strings.HasPrefix(value, ENVELOPE_PREFIX)
+}
+
+// Generate adds new key encryption key and makes it primary, older keys stay to open existing envelopes
+func (ring *KeyRing) Generate() (string, error) {
+	id, err := ring.Add()
+
+	if err != nil {
+		return "", err
+	}
+
+	return id, ring.Promote(id)
+}
+
+// Add puts new key encryption key into the ring without using it for sealing yet
+func (ring *KeyRing) Add() (string, error) {
+	id, err := random(8)
+
+	if err != nil {
+		return "", err
+	}
+
+	key, err := random(32)
+
+	if err != nil {
+		return "", err
+	}
+
+	ring.lock.Lock()
+	defer ring.lock.Unlock()
+
+	ring.Keys[id] = key
+
+	return id, nil
+}
+
+// Promote makes key from the ring the one used for sealing
+func (ring *KeyRing) Promote(id string) error {
+	ring.lock.Lock()
+	defer ring.lock.Unlock()
+
+	if _, ok := ring.Keys[id]; !ok {
+		return fmt.Errorf("%w: %s", ERROR_UNKNOWN_KEY, id)
+	}
+
+	ring.Primary = id
+	return nil
+}
+
+// Seal encrypts value with fresh data key and wraps the data key with the primary key encryption key
+func (ring *KeyRing) Seal(value string) (string, error) {
+	if IsSealed(value) {
+		return value, nil
+	}
+
+	ring.lock.RLock()
+	id := ring.Primary
+	kek, ok := ring.Keys[id]
+	ring.lock.RUnlock()
+
+	if !ok {
+		return "", fmt.Errorf("%w: %s", ERROR_UNKNOWN_KEY, id)
+	}
+
+	dek, err := random(32)
+
+	if err != nil {
+		return "", err
+	}
+
+	wrapped, err := Encrypt(dek, kek)
+
+	if err != nil {
+		return "", err
+	}
+
+	ciphertext, err := Encrypt(value, dek)
+
+	if err != nil {
+		return "", err
+	}
+
+	return fmt.Sprintf("%s%s:%s:%s", ENVELOPE_PREFIX, id, wrapped, ciphertext), nil
+}
+
+// Open decrypts envelope with whichever key sealed it, plaintext values are returned as is
+func (ring *KeyRing) Open(value string) (string, error) {
+	if !IsSealed(value) {
+		return value, nil
+	}
+
+	parts := strings.Split(strings.TrimPrefix(value, ENVELOPE_PREFIX), ":")
+
+	if len(parts) != 3 {
+		return "", ERROR_INVALID_ENVELOPE
+	}
+
+	ring.lock.RLock()
+	kek, ok := ring.Keys[parts[0]]
+	ring.lock.RUnlock()
+
+	if !ok {
+		return "", fmt.Errorf("%w: %s", ERROR_UNKNOWN_KEY, parts[0])
+	}
+
+	dek, err := Decrypt(parts[1], kek)
+
+	if err != nil {
+		return "", fmt.Errorf("%w: %s", ERROR_INVALID_ENVELOPE, err.Error())
+	}
+
+	return Decrypt(parts[2], dek)
+}
+
+// Current reports whether value is sealed with the primary key, otherwise it needs re-encryption
+func (ring *KeyRing) Current(value string) bool {
+	ring.lock.RLock()
+	defer ring.lock.RUnlock()
+
+	return strings.HasPrefix(value, fmt.Sprintf("%s%s:", ENVELOPE_PREFIX, ring.Primary))
+}
+
+// Merge takes keys and primary from other ring, keys missing from other are kept
+func (ring *KeyRing) Merge(other *KeyRing) {
+	other.lock.RLock()
+	defer other.lock.RUnlock()
+
+	ring.lock.Lock()
+	defer ring.lock.Unlock()
+
+	for id, key := range other.Keys {
+		ring.Keys[id] = key
+	}
+
+	if other.Primary != "" {
+		ring.Primary = other.Primary
+	}
+}
+
+func (ring *KeyRing) Write(directory string) error {
+	ring.lock.RLock()
+	bytes, err := json.Marshal(ring)
+	ring.lock.RUnlock()
+
+	if err != nil {
+		return err
+	}
+
+	return os.WriteFile(filepath.Join(directory, KEYRING_FILE), bytes, 0600)
+}
+
+func (ring *KeyRing) Read(directory string) error {
+	bytes, err := os.ReadFile(filepath.Join(directory, KEYRING_FILE))
+
+	if err != nil {
+		return err
+	}
+
+	ring.lock.Lock()
+	defer ring.lock.Unlock()
+
+	return json.Unmarshal(bytes, ring)
+}
+
+func random(size int) (string, error) {
+	bytes := make([]byte, size)
+
+	if _, err := rand.Read(bytes); err != nil {
+		return "", err
+	}
+
+	return hex.EncodeToString(bytes), nil
+}
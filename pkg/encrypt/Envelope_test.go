@@ -0,0 +1,60 @@
+package encrypt
+
+import (
+	"github.com/stretchr/testify/assert"
+	"testing"
+)
+
+func TestSealOpen(t *testing.T) {
+	ring := NewKeyRing()
+	_, err := ring.Generate()
+	assert.NoError(t, err)
+
+	sealed, err := ring.Seal("s3cr3t")
+	assert.NoError(t, err)
+	assert.True(t, IsSealed(sealed))
+	assert.NotContains(t, sealed, "s3cr3t")
+
+	resealed, err := ring.Seal(sealed)
+	assert.NoError(t, err)
+	assert.Equal(t, sealed, resealed)
+
+	opened, err := ring.Open(sealed)
+	assert.NoError(t, err)
+	assert.Equal(t, "s3cr3t", opened)
+
+	plain, err := ring.Open("not encrypted")
+	assert.NoError(t, err)
+	assert.Equal(t, "not encrypted", plain)
+}
+
+func TestRotation(t *testing.T) {
+	ring := NewKeyRing()
+	_, err := ring.Generate()
+	assert.NoError(t, err)
+
+	old, err := ring.Seal("s3cr3t")
+	assert.NoError(t, err)
+	assert.True(t, ring.Current(old))
+
+	_, err = ring.Generate()
+	assert.NoError(t, err)
+	assert.False(t, ring.Current(old))
+
+	opened, err := ring.Open(old)
+	assert.NoError(t, err)
+	assert.Equal(t, "s3cr3t", opened)
+
+	other := NewKeyRing()
+	_, err = other.Generate()
+	assert.NoError(t, err)
+
+	_, err = other.Open(old)
+	assert.ErrorIs(t, err, ERROR_UNKNOWN_KEY)
+
+	other.Merge(ring)
+
+	opened, err = other.Open(old)
+	assert.NoError(t, err)
+	assert.Equal(t, "s3cr3t", opened)
+}
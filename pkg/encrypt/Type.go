@@ -0,0 +1,15 @@
+package encrypt
+
+import "sync"
+
+const ENVELOPE_PREFIX = "smr:enc:v1:"
+const KEYRING_FILE = "encryption.json"
+
+// Ring is the cluster key ring loaded on start, when nil values are kept in plaintext
+var Ring *KeyRing
+
+type KeyRing struct {
+	Primary string            `json:"primary"`
+	Keys    map[string]string `json:"keys"`
+	lock    sync.RWMutex
+}
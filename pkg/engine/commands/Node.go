@@ -3,6 +3,7 @@ package commands
 import (
 	"github.com/simplecontainer/smr/pkg/client"
 	"github.com/simplecontainer/smr/pkg/command"
+	"github.com/simplecontainer/smr/pkg/configuration"
 	"github.com/simplecontainer/smr/pkg/contracts/iapi"
 	"github.com/simplecontainer/smr/pkg/engine/node"
 	"github.com/simplecontainer/smr/pkg/static"
@@ -28,6 +29,7 @@ func cmdNodeCreateFlags(cmd *cobra.Command) {
 	cmd.Flags().String("platform", static.PLATFORM_DOCKER, "Container platform to manage containers lifecycle")
 
 	cmd.Flags().String("node", "simplecontainer-node", "Node container name")
+	cmd.Flags().StringSlice("label", []string{}, "Node label used for scheduling. Format: key=value (repeatable)")
 
 	cmd.Flags().String("image", "quay.io/simplecontainer/smr", "Node image name")
 	cmd.Flags().String("tag", "latest", "Node image tag")
@@ -40,10 +42,29 @@ func cmdNodeCreateFlags(cmd *cobra.Command) {
 	cmd.Flags().String("listen", "0.0.0.0:1443", "Simplecontainer mTLS listening interface and port combo")
 	cmd.Flags().String("domain", "", "Domain that TLS certificates is valid for")
 	cmd.Flags().String("ip", "", "IP address that TLS certificates is valid for")
+	cmd.Flags().Duration("expiry", configuration.DEFAULT_CERTIFICATE_EXPIRY, "Validity of the node and user TLS certificates")
+
+	cmd.Flags().String("secrets.directory", "", "Directory on the node holding files for file:// and sops:// secret references")
+	cmd.Flags().String("secrets.age-key-file", "", "Age identities used to decrypt sops:// secret references")
+	cmd.Flags().String("secrets.mounts", configuration.DEFAULT_SECRETS_MOUNTS, "Host tmpfs directory backing secrets mounted into containers")
+	cmd.Flags().Duration("secrets.refresh", configuration.DEFAULT_SECRETS_REFRESH, "How long resolved secret references are cached")
+	cmd.Flags().String("vault.address", "", "Vault address for vault:// secret references")
+	cmd.Flags().String("vault.namespace", "", "Vault namespace")
+	cmd.Flags().String("vault.token-file", "", "File holding the Vault token, VAULT_TOKEN is used if not set")
+	cmd.Flags().String("vault.ca-cert", "", "CA certificate used to verify Vault")
+	cmd.Flags().Bool("admission.images.require-digest", false, "Reject containers whose image is not pinned by digest")
+	cmd.Flags().StringSlice("admission.images.registries", []string{}, "Registries or registry namespaces containers are allowed to pull images from")
+	cmd.Flags().StringSlice("admission.images.keys", []string{}, "PEM public keys, when set images must carry cosign signature made by one of them")
+	cmd.Flags().StringSlice("admission.required-labels", []string{}, "Labels every definition must have")
+	cmd.Flags().Bool("admission.deny-privileged", false, "Reject privileged containers")
+	cmd.Flags().StringSlice("admission.webhooks", []string{}, "Admission webhook URLs called in order, they can reject definitions or respond with JSON patch")
+	cmd.Flags().Bool("admission.webhooks-fail-open", false, "Admit definitions when admission webhook is unreachable")
+	cmd.Flags().String("gitops.webhook-secret", "", "Secret git providers sign push webhooks with, webhooks are rejected if not set - polling still works")
 
 	cmd.Flags().String("port.control", ":1443", "Port mapping of node control plane -> Default 0.0.0.0:1443")
 	cmd.Flags().String("port.overlay", ":9212", "Port mapping of node overlay raft port  -> Default 0.0.0.0:9212")
 	cmd.Flags().String("port.etcd", "2379", "Port mapping of node overlay raft port  -> Default 127.0.0.1:2379 (Cant be exposed to outside!)")
+	cmd.Flags().String("port.webhook", "", "Port mapping of gitops webhook TLS listener without client certificates -> Disabled if empty eg. 0.0.0.0:9443")
 
 }
 
@@ -2,11 +2,12 @@ package commands
 
 import (
 	"crypto/tls"
-	"crypto/x509"
 	"fmt"
 	"github.com/gin-gonic/gin"
 	mdns "github.com/miekg/dns"
+	"github.com/simplecontainer/smr/pkg/admission"
 	"github.com/simplecontainer/smr/pkg/api/middlewares"
+	"github.com/simplecontainer/smr/pkg/audit"
 	"github.com/simplecontainer/smr/pkg/authentication"
 	"github.com/simplecontainer/smr/pkg/client"
 	"github.com/simplecontainer/smr/pkg/clients"
@@ -14,15 +15,18 @@ import (
 	"github.com/simplecontainer/smr/pkg/configuration"
 	"github.com/simplecontainer/smr/pkg/contracts/iapi"
 	"github.com/simplecontainer/smr/pkg/dns"
+	"github.com/simplecontainer/smr/pkg/encrypt"
 	"github.com/simplecontainer/smr/pkg/keys"
 	"github.com/simplecontainer/smr/pkg/kinds"
 	"github.com/simplecontainer/smr/pkg/logger"
 	"github.com/simplecontainer/smr/pkg/metrics"
+	"github.com/simplecontainer/smr/pkg/secrets"
 	"github.com/simplecontainer/smr/pkg/startup"
 	"github.com/simplecontainer/smr/pkg/static"
 	"github.com/spf13/cobra"
 	"net/http"
 	"os"
+	"path/filepath"
 	"strconv"
 	"time"
 )
@@ -79,13 +83,13 @@ func cmdStart(api iapi.Api, cli *client.Client, args []string) {
 	found = api.GetKeys().ServerExists(static.SMR_SSH_HOME, api.GetConfig().NodeName)
 
 	if found != nil {
-		err = api.GetKeys().GenerateServer(api.GetConfig().Certificates.Domains, api.GetConfig().Certificates.IPs)
+		err = api.GetKeys().GenerateServer(api.GetConfig().Certificates.Domains, api.GetConfig().Certificates.IPs, api.GetConfig().Certificates.Expiry)
 
 		if err != nil {
 			panic(err)
 		}
 
-		err = api.GetKeys().GenerateClient(api.GetConfig().Certificates.Domains, api.GetConfig().Certificates.IPs, api.GetConfig().NodeName)
+		err = api.GetKeys().GenerateClient(api.GetConfig().Certificates.Domains, api.GetConfig().Certificates.IPs, api.GetConfig().NodeName, api.GetConfig().Certificates.Expiry)
 
 		if err != nil {
 			panic(err)
@@ -126,6 +130,35 @@ func cmdStart(api iapi.Api, cli *client.Client, args []string) {
 		os.Exit(1)
 	}
 
+	err = api.GetKeys().ReadTrust(static.SMR_SSH_HOME)
+
+	if err != nil {
+		panic(err)
+	}
+
+	err = api.GetKeys().LoadEncryption(static.SMR_SSH_HOME, api.GetConfig().KVStore.Join || api.GetConfig().KVStore.Peer != "")
+
+	if err != nil {
+		panic(err)
+	}
+
+	encrypt.Ring = api.GetKeys().Encryption
+
+	secrets.Resolver, err = secrets.New(api.GetConfig().Secrets)
+
+	if err != nil {
+		panic(err)
+	}
+
+	admissionPolicy, err := admission.New(api.GetConfig().Admission)
+
+	if err != nil {
+		panic(err)
+	}
+
+	api.SetAdmission(admissionPolicy)
+	api.SetAuditTrail(audit.New(filepath.Join(api.GetConfig().Environment.Container.NodeDirectory, static.LOGDIR)))
+
 	// Cluster information is unknown, this only enables localhost to talk to itself via https
 	api.GetManager().Http, err = clients.GenerateHttpClients(api.GetKeys(), api.GetConfig().HostPort, nil)
 
@@ -164,6 +197,8 @@ func cmdStart(api iapi.Api, cli *client.Client, args []string) {
 	routerHttp := gin.New()
 
 	router.Use(middlewares.CORS())
+	router.Use(middlewares.Revocation(api.GetRevocations()))
+	router.Use(middlewares.RBAC(api))
 
 	v1 := router.Group("/api/v1")
 	{
@@ -219,13 +254,20 @@ func cmdStart(api iapi.Api, cli *client.Client, args []string) {
 			cluster.GET("/node/:id", api.GetNode)
 			cluster.GET("/node/version/:id", api.GetNodeVersion)
 			cluster.POST("/node", api.AddNode)
+			cluster.POST("/node/:id/labels", api.SetNodeLabels)
 			cluster.DELETE("/node/:node", api.RemoveNode)
+			cluster.POST("/encryption", api.SyncEncryption)
+			cluster.POST("/encryption/rotate", api.RotateEncryption)
+			cluster.GET("/certificates", api.ListCertificates)
+			cluster.POST("/certificates", api.SyncCertificates)
+			cluster.POST("/certificates/ca/:phase", api.RolloverCA)
 		}
 
 		definitions := v1.Group("/")
 		{
 			definitions.POST("propose/:action", api.Propose)
 			definitions.DELETE("propose/:action", api.Propose)
+			definitions.POST("pack", api.ApplyPack)
 			definitions.GET("debug/:prefix/:version/:category/:kind/:group/:name/:which/:follow", api.Debug)
 			definitions.GET("logs/:prefix/:version/:category/:kind/:group/:name/:which/:follow", api.Logs)
 			definitions.GET("exec/:prefix/:version/:category/:kind/:group/:name/:interactive", api.Exec)
@@ -233,12 +275,25 @@ func cmdStart(api iapi.Api, cli *client.Client, args []string) {
 
 		users := v1.Group("/user")
 		{
+			users.GET("", api.ListUsers)
 			users.POST("/:username/:domain/:externalIP", api.CreateUser)
+			users.PUT("/:username", api.RenewUser)
+			users.DELETE("/:username", api.RevokeUser)
+		}
+
+		packTrust := v1.Group("/trust")
+		{
+			packTrust.GET("", api.ListTrust)
+			packTrust.PUT("", api.SetTrust)
+			packTrust.DELETE("", api.RemoveTrust)
 		}
+
+		v1.GET("/audit", api.Audit)
 	}
 
 	router.GET("/connect", api.Health)
 	router.GET("/fetch/certs", api.ExportClients)
+	router.GET("/fetch/encryption", api.ExportEncryption)
 
 	router.GET("/metrics", api.MetricsHandle())
 	router.GET("/healthz", api.Health)
@@ -254,18 +309,29 @@ func cmdStart(api iapi.Api, cli *client.Client, args []string) {
 	routerHttp.GET("/healthz", api.Health)
 	routerHttp.GET("/version", api.DisplayVersion)
 
-	CAPool := x509.NewCertPool()
-	CAPool.AddCert(api.GetKeys().CA.Certificate)
+	// Git providers can't present client certificates, payload signature authenticates them instead, token-only
+	// deliveries (GitLab) are refused here since this listener is plain http - they go to the webhook TLS listener
+	routerHttp.POST("/gitops/webhook", api.GitopsWebhook)
 
-	tlsConfig := &tls.Config{
-		ClientAuth: tls.RequireAndVerifyClientCert,
-		ClientCAs:  CAPool,
-	}
+	routerWebhook := gin.New()
+	routerWebhook.POST("/gitops/webhook", api.GitopsWebhook)
 
-	tlsConfig.GetCertificate = api.GetKeys().Reloader.GetCertificateFunc()
+	tlsConfig := api.GetKeys().TLSConfig(api.GetRevocations().VerifyPeerCertificate)
 
 	api.SetupEtcd()
 
+	err = api.WatchRevocations()
+
+	if err != nil {
+		panic(err)
+	}
+
+	err = api.WatchTrust()
+
+	if err != nil {
+		panic(err)
+	}
+
 	server := http.Server{
 		Addr:         fmt.Sprintf("%s:%s", api.GetConfig().HostPort.Host, api.GetConfig().HostPort.Port),
 		Handler:      router,
@@ -302,6 +368,27 @@ func cmdStart(api iapi.Api, cli *client.Client, args []string) {
 		}
 	}()
 
+	if api.GetConfig().Ports != nil && api.GetConfig().Ports.Webhook != "" {
+		go func() {
+			// Node certificate is served but client certificates aren't requested so git providers can connect
+			webhookServer := http.Server{
+				Addr:    ":9443",
+				Handler: routerWebhook,
+				TLSConfig: &tls.Config{
+					MinVersion:     tls.VersionTLS12,
+					ClientAuth:     tls.NoClientCert,
+					GetCertificate: api.GetKeys().Reloader.GetCertificateFunc(),
+				},
+				ReadTimeout: 10 * time.Second,
+			}
+
+			err = webhookServer.ListenAndServeTLS("", "")
+			if err != nil {
+				panic(err)
+			}
+		}()
+	}
+
 	err = server.ListenAndServeTLS("", "")
 	if err != nil {
 		panic(err)
@@ -29,11 +29,17 @@ func Create(api iapi.Api) {
 	}
 
 	api.GetConfig().NodeName = viper.GetString("node")
+	api.GetConfig().Labels, err = configuration.NewLabels(viper.GetStringSlice("label"))
+
+	if err != nil {
+		helpers.PrintAndExit(err, 1)
+	}
 
 	api.GetConfig().NodeImage = viper.GetString("image")
 	api.GetConfig().NodeTag = viper.GetString("tag")
 	api.GetConfig().Certificates.Domains = configuration.NewDomains([]string{viper.GetString("domain")})
 	api.GetConfig().Certificates.IPs = configuration.NewIPs([]string{viper.GetString("ip")})
+	api.GetConfig().Certificates.Expiry = viper.GetDuration("expiry")
 
 	// Internal domains needed
 	api.GetConfig().Certificates.Domains.Add("localhost")
@@ -50,10 +56,47 @@ func Create(api iapi.Api) {
 		Peer:    viper.GetString("peer"),
 	}
 
+	api.GetConfig().Secrets = &configuration.Secrets{
+		Refresh:    viper.GetDuration("secrets.refresh"),
+		Directory:  viper.GetString("secrets.directory"),
+		AgeKeyFile: viper.GetString("secrets.age-key-file"),
+		Mounts:     viper.GetString("secrets.mounts"),
+		Vault: &configuration.Vault{
+			Address:   viper.GetString("vault.address"),
+			Namespace: viper.GetString("vault.namespace"),
+			TokenFile: viper.GetString("vault.token-file"),
+			CACert:    viper.GetString("vault.ca-cert"),
+		},
+	}
+
+	api.GetConfig().Admission = &configuration.Admission{
+		Images: &configuration.ImagesAdmission{
+			RequireDigest: viper.GetBool("admission.images.require-digest"),
+			Registries:    viper.GetStringSlice("admission.images.registries"),
+			Keys:          viper.GetStringSlice("admission.images.keys"),
+		},
+		RequiredLabels: viper.GetStringSlice("admission.required-labels"),
+		DenyPrivileged: viper.GetBool("admission.deny-privileged"),
+		Webhooks:       make([]*configuration.AdmissionWebhook, 0),
+	}
+
+	for _, endpoint := range viper.GetStringSlice("admission.webhooks") {
+		api.GetConfig().Admission.Webhooks = append(api.GetConfig().Admission.Webhooks, &configuration.AdmissionWebhook{
+			URL:      endpoint,
+			Timeout:  configuration.DEFAULT_ADMISSION_WEBHOOK_TIMEOUT,
+			FailOpen: viper.GetBool("admission.webhooks-fail-open"),
+		})
+	}
+
+	api.GetConfig().Gitops = &configuration.Gitops{
+		WebhookSecret: viper.GetString("gitops.webhook-secret"),
+	}
+
 	api.GetConfig().Ports = &configuration.Ports{
 		Control: viper.GetString("port.control"),
 		Overlay: viper.GetString("port.overlay"),
 		Etcd:    viper.GetString("port.etcd"),
+		Webhook: viper.GetString("port.webhook"),
 	}
 
 	err = startup.Save(api.GetConfig(), environment, 0750)
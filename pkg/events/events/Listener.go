@@ -9,6 +9,7 @@ import (
 	"github.com/simplecontainer/smr/pkg/distributed"
 	"github.com/simplecontainer/smr/pkg/f"
 	"github.com/simplecontainer/smr/pkg/logger"
+	"github.com/simplecontainer/smr/pkg/notifications"
 	"github.com/simplecontainer/smr/pkg/wss"
 	"go.uber.org/zap"
 	"time"
@@ -38,11 +39,28 @@ func Listen(kindRegistry map[string]ikinds.Kind, e chan KV.KV, informer *distrib
 			}
 			wss.Lock.RUnlock()
 
+			notify(event, data.Node)
 			Handle(kindRegistry, informer, event, data.Node)
 		}
 	}
 }
 
+// notify forwards state transitions to the notifier, actions are skipped since they don't change the last known state
+func notify(event Event, node uint64) {
+	if notifications.Notifier == nil || event.IsEmpty() {
+		return
+	}
+
+	switch event.GetType() {
+	case EVENT_DELETED:
+		notifications.Notifier.Forget(event)
+	case EVENT_COMMIT, EVENT_REFRESH, EVENT_SYNC, EVENT_RESTART, EVENT_INSPECT, EVENT_CHANGED, EVENT_CHANGE, EVENT_STOP, EVENT_RECREATE, EVENT_RESUME:
+		return
+	default:
+		notifications.Notifier.Handle(event, node)
+	}
+}
+
 func Handle(kindRegistry map[string]ikinds.Kind, informer *distributed.Informer, event Event, node uint64) {
 	kind, ok := kindRegistry[event.Target]
 
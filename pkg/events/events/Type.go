@@ -21,6 +21,11 @@ const EVENT_SYNC = "sync"
 
 // Container events
 const EVENT_RESTART = "restart"
+const EVENT_LIVENESS_FAILED = "liveness_failed"
+const EVENT_ROLLOUT_COMPLETED = "rollout_completed"
+const EVENT_ROLLOUT_FAILED = "rollout_failed"
+const EVENT_RESUME = "resume"
+const EVENT_AUTOSCALED = "autoscaled"
 
 // Shared events
 const EVENT_INSPECT = "inspect"
@@ -37,3 +42,7 @@ const EVENT_DRAIN_SUCCESS = "drain_success"
 
 const EVENT_CLUSTER_STARTED = "cluster_started"
 const EVENT_CLUSTER_READY = "cluster_ready"
+const EVENT_NODE_LABELED = "node_labeled"
+const EVENT_NODE_RESOURCES = "node_resources"
+const EVENT_NODE_UNREACHABLE = "node_unreachable"
+const EVENT_NODE_REACHABLE = "node_reachable"
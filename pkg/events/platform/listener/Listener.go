@@ -16,6 +16,7 @@ import (
 	"github.com/simplecontainer/smr/pkg/metrics"
 	"github.com/simplecontainer/smr/pkg/static"
 	"go.uber.org/zap"
+	"strconv"
 	"time"
 )
 
@@ -151,6 +152,12 @@ func HandleDie(shared *shared.Shared, cw *watcher.Container, event ievents.Event
 		if containerW != nil && !containerW.IsDone() && containerW.GetAllowPlatformEvents() {
 			cw.Logger.Info(fmt.Sprintf("container is stopped - reconcile to dead %s", cw.Container.GetGeneratedName()))
 
+			// Exit code tells one-shot containers that completed apart from the crashed ones
+			if code, err := strconv.Atoi(string(event.GetData())); err == nil {
+				cw.Container.GetStatus().LastExitCode = code
+				cw.Container.GetStatus().LastExitTimestamp = time.Now()
+			}
+
 			cw.Container.GetStatus().RejectQueueAttempts(time.Now())
 			cw.Container.GetStatus().GetPending().Clear()
 			cw.Container.GetStatus().QueueState(status.DEAD, time.Now())
@@ -0,0 +1,58 @@
+package formaters
+
+import (
+	"encoding/json"
+	"fmt"
+	"github.com/olekukonko/tablewriter"
+	"github.com/simplecontainer/smr/pkg/audit"
+	"os"
+	"strings"
+	"time"
+)
+
+func Audit(entries []audit.Entry) {
+	table := tablewriter.NewWriter(os.Stdout)
+	table.Header([]string{"TIME", "USER", "NODE", "ACTION", "OBJECT", "CHANGES", "RESULT"})
+
+	SetStyle(table)
+
+	for _, entry := range entries {
+		result := fmt.Sprintf("%s (%d)", entry.Result, entry.Status)
+
+		if entry.Error != "" {
+			result = fmt.Sprintf("%s: %s", result, entry.Error)
+		}
+
+		table.Append([]string{
+			entry.Time.Local().Format(time.DateTime),
+			entry.User,
+			entry.Node,
+			entry.Action,
+			fmt.Sprintf("%s/%s/%s", entry.Kind, entry.Group, entry.Name),
+			changes(entry),
+			result,
+		})
+	}
+
+	table.Render()
+}
+
+// changes lists paths touched by the diff, falls back to detail for calls without definition
+func changes(entry audit.Entry) string {
+	operations := make([]struct {
+		Op   string `json:"op"`
+		Path string `json:"path"`
+	}, 0)
+
+	if len(entry.Diff) == 0 || json.Unmarshal(entry.Diff, &operations) != nil {
+		return entry.Detail
+	}
+
+	paths := make([]string, 0, len(operations))
+
+	for _, operation := range operations {
+		paths = append(paths, fmt.Sprintf("%s %s", operation.Op, operation.Path))
+	}
+
+	return strings.Join(paths, "\n")
+}
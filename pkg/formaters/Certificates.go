@@ -0,0 +1,45 @@
+package formaters
+
+import (
+	"fmt"
+	"github.com/olekukonko/tablewriter"
+	"github.com/simplecontainer/smr/pkg/keys"
+	"os"
+	"time"
+)
+
+func Certificates(infos []keys.Info) {
+	table := tablewriter.NewWriter(os.Stdout)
+	table.Header([]string{"NODE", "TYPE", "NAME", "SERIAL", "EXPIRES", "REMAINING", "STATUS"})
+
+	SetStyle(table)
+
+	for _, info := range infos {
+		table.Append([]string{
+			info.Node,
+			info.Type,
+			info.Name,
+			info.Serial,
+			info.NotAfter.Local().Format(time.DateTime),
+			remaining(info.NotAfter),
+			info.Status,
+		})
+	}
+
+	table.Render()
+}
+
+func remaining(notAfter time.Time) string {
+	d := time.Until(notAfter)
+
+	switch {
+	case d <= 0:
+		return "expired"
+	case d < time.Hour:
+		return fmt.Sprintf("%dm", int(d.Minutes()))
+	case d < 24*time.Hour:
+		return fmt.Sprintf("%dh", int(d.Hours()))
+	default:
+		return fmt.Sprintf("%dd", int(d.Hours())/24)
+	}
+}
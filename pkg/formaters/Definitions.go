@@ -33,6 +33,16 @@ func Definitions(objects []json.RawMessage) {
 					RoundAndFormatDuration(d.Definition.Definition.GetState().Gitops.LastSync),
 				})
 			}
+
+			// Preview of the next sync, orphans are removed by it only when prune is enabled
+			for _, o := range g.Gitops.Orphans {
+				table.Append([]string{
+					fmt.Sprintf("%s/%s/%s", static.KIND_GITOPS, g.GetGroup(), g.GetName()),
+					fmt.Sprintf("%s/%s/%s", o.Kind, o.Group, o.Name),
+					helpers.CliMask(g.Gitops.Prune, "Prune", "Orphaned"),
+					"-",
+				})
+			}
 		}
 
 		table.Render()
@@ -16,7 +16,7 @@ func Gitops(objects []json.RawMessage) {
 	}
 
 	table := tablewriter.NewWriter(os.Stdout)
-	table.Header([]string{"RESOURCE", "REVISION", "SYNCED", "AUTO SYNC", "STATUS"})
+	table.Header([]string{"RESOURCE", "REVISION", "SYNCED", "AUTO SYNC", "WAVE", "STATUS", "REASON"})
 
 	SetStyle(table)
 
@@ -45,7 +45,9 @@ func Gitops(objects []json.RawMessage) {
 			g.GetGit().Revision,
 			helpers.CliMask(g.GetStatus().LastSyncedCommit.IsZero(), "Never synced", g.GetStatus().LastSyncedCommit.String()[:7]),
 			fmt.Sprintf("%v", g.GetAutoSync()),
+			g.GetWaves().Progress(),
 			g.GetStatus().State.State,
+			g.GetStatus().Reason,
 		})
 	}
 
@@ -0,0 +1,31 @@
+package formaters
+
+import (
+	"fmt"
+	"github.com/olekukonko/tablewriter"
+	"github.com/simplecontainer/smr/pkg/kinds/common"
+	"os"
+)
+
+func History(history []common.Revision) {
+	table := tablewriter.NewWriter(os.Stdout)
+	table.Header([]string{"REVISION", "CREATED", "CURRENT"})
+
+	SetStyle(table)
+
+	for i, revision := range history {
+		current := ""
+
+		if i == len(history)-1 {
+			current = "*"
+		}
+
+		table.Append([]string{
+			fmt.Sprintf("%d", revision.Revision),
+			RoundAndFormatDuration(revision.Created),
+			current,
+		})
+	}
+
+	table.Render()
+}
@@ -0,0 +1,34 @@
+package formaters
+
+import (
+	"github.com/olekukonko/tablewriter"
+	"github.com/simplecontainer/smr/pkg/packer/signature"
+	"github.com/simplecontainer/smr/pkg/packer/trust"
+	"os"
+	"strings"
+	"time"
+)
+
+func Trust(policies []*trust.Policy) {
+	table := tablewriter.NewWriter(os.Stdout)
+	table.Header([]string{"SCOPE", "KEYS", "BY", "UPDATED"})
+
+	SetStyle(table)
+
+	for _, policy := range policies {
+		fingerprints := make([]string, 0, len(policy.Keys))
+
+		for _, key := range policy.Keys {
+			fingerprints = append(fingerprints, signature.Fingerprint(key))
+		}
+
+		table.Append([]string{
+			policy.Scope,
+			strings.Join(fingerprints, "\n"),
+			policy.By,
+			policy.Time.Local().Format(time.DateTime),
+		})
+	}
+
+	table.Render()
+}
@@ -0,0 +1,28 @@
+package formaters
+
+import (
+	"github.com/olekukonko/tablewriter"
+	"github.com/simplecontainer/smr/pkg/revocation"
+	"os"
+	"time"
+)
+
+func Users(certificates []revocation.Certificate) {
+	table := tablewriter.NewWriter(os.Stdout)
+	table.Header([]string{"USERNAME", "SERIAL", "DOMAIN", "NODE", "EXPIRES", "STATUS"})
+
+	SetStyle(table)
+
+	for _, certificate := range certificates {
+		table.Append([]string{
+			certificate.Username,
+			certificate.Serial,
+			certificate.Domain,
+			certificate.Node,
+			certificate.NotAfter.Local().Format(time.DateTime),
+			certificate.Status,
+		})
+	}
+
+	table.Render()
+}
@@ -68,6 +68,11 @@ func (ca *CA) Generate() error {
 	return nil
 }
 func (ca *CA) Write(directory string) error {
+	return ca.WriteAs(directory, CA_NAME)
+}
+
+// WriteAs stores the CA under the name, cross-signed certificate is kept next to it while rollover is in progress
+func (ca *CA) WriteAs(directory string, name string) error {
 	err := os.MkdirAll(directory, os.ModePerm)
 
 	if err != nil {
@@ -86,8 +91,8 @@ func (ca *CA) Write(directory string) error {
 		return err
 	}
 
-	ca.CertificatePath = fmt.Sprintf("%s/ca.crt", directory)
-	ca.PrivateKeyPath = fmt.Sprintf("%s/ca.key", directory)
+	ca.CertificatePath = fmt.Sprintf("%s/%s.crt", directory, name)
+	ca.PrivateKeyPath = fmt.Sprintf("%s/%s.key", directory, name)
 
 	err = os.WriteFile(ca.CertificatePath, PemCertificate, 0644)
 
@@ -101,11 +106,32 @@ func (ca *CA) Write(directory string) error {
 		return err
 	}
 
-	return nil
+	crossPath := fmt.Sprintf("%s/%s-cross.crt", directory, name)
+
+	if len(ca.CrossBytes) == 0 {
+		err = os.Remove(crossPath)
+
+		if err != nil && !os.IsNotExist(err) {
+			return err
+		}
+
+		return nil
+	}
+
+	PemCross, err := PEMEncode(CERTIFICATE, ca.CrossBytes)
+
+	if err != nil {
+		return err
+	}
+
+	return os.WriteFile(crossPath, PemCross, 0644)
 }
 func (ca *CA) Read(directory string) error {
-	ca.CertificatePath = fmt.Sprintf("%s/ca.crt", directory)
-	ca.PrivateKeyPath = fmt.Sprintf("%s/ca.key", directory)
+	return ca.ReadAs(directory, CA_NAME)
+}
+func (ca *CA) ReadAs(directory string, name string) error {
+	ca.CertificatePath = fmt.Sprintf("%s/%s.crt", directory, name)
+	ca.PrivateKeyPath = fmt.Sprintf("%s/%s.key", directory, name)
 
 	PemCertificate, err := os.ReadFile(ca.CertificatePath)
 	if err != nil {
@@ -139,9 +165,50 @@ func (ca *CA) Read(directory string) error {
 		return err
 	}
 
+	PemCross, err := os.ReadFile(fmt.Sprintf("%s/%s-cross.crt", directory, name))
+
+	if err == nil {
+		ca.CrossBytes = PEMDecode(PemCross)
+	} else if !os.IsNotExist(err) {
+		return err
+	}
+
+	return nil
+}
+
+// Load parses certificate and private key from bytes when CA is received over the wire
+func (ca *CA) Load() error {
+	var err error
+
+	ca.Certificate, err = x509.ParseCertificate(ca.CertificateBytes)
+
+	if err != nil {
+		return err
+	}
+
+	var PrivateKeyTmp any
+	PrivateKeyTmp, err = x509.ParsePKCS8PrivateKey(ca.PrivateKeyBytes)
+
+	if err != nil {
+		return err
+	}
+
+	ca.PrivateKey = PrivateKeyTmp.(*ecdsa.PrivateKey)
+
 	return nil
 }
 
+// CrossSign issues the CA certificate signed by the previous CA so peers trusting only the previous CA accept the chain
+func (ca *CA) CrossSign(previous *CA) error {
+	template := *ca.Certificate
+	template.SerialNumber = generateSerialNumber()
+
+	var err error
+	ca.CrossBytes, err = x509.CreateCertificate(rand.Reader, &template, previous.Certificate, &ca.PrivateKey.PublicKey, previous.PrivateKey)
+
+	return err
+}
+
 func IsCA(block *pem.Block) (bool, error) {
 	if block == nil || block.Type != "CERTIFICATE" {
 		return false, fmt.Errorf("not a certificate PEM block")
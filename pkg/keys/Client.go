@@ -19,7 +19,14 @@ func NewClient() *Client {
 	}
 }
 
-func (client *Client) Generate(ca *CA, domains *configuration.Domains, ips *configuration.IPs, CN string) error {
+func (client *Client) Generate(ca *CA, domains *configuration.Domains, ips *configuration.IPs, CN string, expiry time.Duration) error {
+	return client.generate(ca, domains, ips, pkix.Name{
+		Organization: []string{"simplecontainer"},
+		CommonName:   CN,
+	}, expiry)
+}
+
+func (client *Client) generate(ca *CA, domains *configuration.Domains, ips *configuration.IPs, subject pkix.Name, expiry time.Duration) error {
 	var err error
 
 	client.Sni = generateSerialNumber()
@@ -45,14 +52,11 @@ func (client *Client) Generate(ca *CA, domains *configuration.Domains, ips *conf
 
 	client.Certificate = &x509.Certificate{
 		SerialNumber: client.Sni,
-		Subject: pkix.Name{
-			Organization: []string{"simplecontainer"},
-			CommonName:   CN,
-		},
+		Subject:      subject,
 		DNSNames:     domains.ToStringSlice(),
 		IPAddresses:  ips.ToIPNetSlice(),
 		NotBefore:    time.Now(),
-		NotAfter:     time.Now().AddDate(10, 0, 0),
+		NotAfter:     notAfter(expiry),
 		SubjectKeyId: SubjectKeyIdentifier[:],
 		ExtKeyUsage:  []x509.ExtKeyUsage{x509.ExtKeyUsageClientAuth, x509.ExtKeyUsageServerAuth},
 		KeyUsage:     x509.KeyUsageDigitalSignature | x509.KeyUsageKeyEncipherment,
@@ -173,3 +177,18 @@ func (client *Client) Load(certificate []byte, privateKey []byte) error {
 
 	return nil
 }
+
+// Renew re-signs the certificate for the same key pair, peers and contexts holding the key keep working
+func (client *Client) Renew(ca *CA, expiry time.Duration) error {
+	var err error
+
+	client.Certificate, client.CertificateBytes, err = renew(client.Certificate, &client.PrivateKey.PublicKey, ca, expiry)
+
+	if err != nil {
+		return err
+	}
+
+	client.Sni = client.Certificate.SerialNumber
+
+	return nil
+}
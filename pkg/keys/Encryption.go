@@ -0,0 +1,33 @@
+package keys
+
+import (
+	"errors"
+	"github.com/simplecontainer/smr/pkg/encrypt"
+	"io/fs"
+)
+
+var ERROR_MISSING_ENCRYPTION = errors.New("encryption key ring is missing, import it from the cluster before joining")
+
+// LoadEncryption reads the cluster key ring from the directory or generates one when node starts first time,
+// joining node must have the ring imported since a fresh one couldn't open anything the cluster sealed
+func (keys *Keys) LoadEncryption(directory string, joining bool) error {
+	keys.Encryption = encrypt.NewKeyRing()
+
+	err := keys.Encryption.Read(directory)
+
+	if err == nil || !errors.Is(err, fs.ErrNotExist) {
+		return err
+	}
+
+	if joining {
+		return ERROR_MISSING_ENCRYPTION
+	}
+
+	_, err = keys.Encryption.Generate()
+
+	if err != nil {
+		return err
+	}
+
+	return keys.Encryption.Write(directory)
+}
@@ -2,16 +2,19 @@ package keys
 
 import (
 	"crypto/rand"
+	"crypto/x509/pkix"
 	"encoding/pem"
 	"errors"
 	"fmt"
 	"github.com/simplecontainer/smr/pkg/configuration"
+	"github.com/simplecontainer/smr/pkg/encrypt"
 	"io/fs"
 	"log"
 	"math/big"
 	"os"
 	"path/filepath"
 	"strings"
+	"time"
 )
 
 func NewKeys() *Keys {
@@ -23,6 +26,20 @@ func NewKeys() *Keys {
 	}
 }
 
+// Reserved is true if client files of the username would clash with files node keeps next to them
+func Reserved(username string) bool {
+	if username == "" || username == "." || username == ".." || strings.ContainsAny(username, `/\`) {
+		return true
+	}
+
+	switch username {
+	case CA_NAME, CA_STAGED, CA_RETIRED, strings.TrimSuffix(TRUST_BUNDLE, filepath.Ext(TRUST_BUNDLE)), strings.TrimSuffix(encrypt.KEYRING_FILE, filepath.Ext(encrypt.KEYRING_FILE)):
+		return true
+	}
+
+	return strings.HasSuffix(username, "-server") || strings.HasSuffix(username, "-cross")
+}
+
 func NewClients() map[string]*Client {
 	return make(map[string]*Client)
 }
@@ -35,19 +52,24 @@ func (keys *Keys) GenerateCA() error {
 	return keys.CA.Generate()
 }
 
-func (keys *Keys) GenerateServer(domains *configuration.Domains, ips *configuration.IPs) error {
+func (keys *Keys) GenerateServer(domains *configuration.Domains, ips *configuration.IPs, expiry time.Duration) error {
 	hostname, err := os.Hostname()
 
 	if err != nil {
 		hostname = "simplecontainer"
 	}
 
-	return keys.Server.Generate(keys.CA, domains, ips, hostname)
+	return keys.Server.Generate(keys.CA, domains, ips, hostname, expiry)
 }
 
-func (keys *Keys) GenerateClient(domains *configuration.Domains, ips *configuration.IPs, username string) error {
+// GenerateClient issues client certificate of the node, it carries NODE_UNIT so the node is told apart from users
+func (keys *Keys) GenerateClient(domains *configuration.Domains, ips *configuration.IPs, username string, expiry time.Duration) error {
 	keys.Clients[username] = NewClient()
-	return keys.Clients[username].Generate(keys.CA, domains, ips, username)
+	return keys.Clients[username].generate(keys.CA, domains, ips, pkix.Name{
+		Organization:       []string{"simplecontainer"},
+		OrganizationalUnit: []string{NODE_UNIT},
+		CommonName:         username,
+	}, expiry)
 }
 
 func (keys *Keys) CAExists(directory string, username string) error {
@@ -201,3 +223,12 @@ func generateSerialNumber() *big.Int {
 	}
 	return serialNumber
 }
+
+// notAfter falls back to the default expiry for configurations created before expiry was configurable
+func notAfter(expiry time.Duration) time.Time {
+	if expiry <= 0 {
+		expiry = configuration.DEFAULT_CERTIFICATE_EXPIRY
+	}
+
+	return time.Now().Add(expiry)
+}
@@ -0,0 +1,29 @@
+package keys
+
+import (
+	"github.com/stretchr/testify/assert"
+	"testing"
+)
+
+func TestReserved(t *testing.T) {
+	tests := []struct {
+		name     string
+		username string
+		wanted   bool
+	}{
+		{"Regular user", "alice", false},
+		{"Certificate authority", CA_NAME, true},
+		{"Staged certificate authority", CA_STAGED, true},
+		{"Trust bundle", "trust", true},
+		{"Encryption key ring", "encryption", true},
+		{"Server certificate", "node-1-server", true},
+		{"Path traversal", "../alice", true},
+		{"Empty", "", true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.wanted, Reserved(tt.username))
+		})
+	}
+}
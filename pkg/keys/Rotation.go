@@ -0,0 +1,294 @@
+package keys
+
+import (
+	"crypto/ecdsa"
+	"crypto/rand"
+	"crypto/tls"
+	"crypto/x509"
+	"errors"
+	"fmt"
+	"os"
+	"time"
+)
+
+var ERROR_ROLLOVER_IN_PROGRESS = errors.New("previous certificate authority rollover is not finished")
+var ERROR_NOTHING_STAGED = errors.New("no certificate authority is staged")
+var ERROR_NOT_PROMOTED = errors.New("staged certificate authority is not promoted")
+
+func renew(certificate *x509.Certificate, publicKey *ecdsa.PublicKey, ca *CA, expiry time.Duration) (*x509.Certificate, []byte, error) {
+	template := *certificate
+	template.SerialNumber = generateSerialNumber()
+	template.NotBefore = time.Now()
+	template.NotAfter = notAfter(expiry)
+
+	bytes, err := x509.CreateCertificate(rand.Reader, &template, ca.Certificate, publicKey, ca.PrivateKey)
+
+	if err != nil {
+		return nil, nil, err
+	}
+
+	renewed, err := x509.ParseCertificate(bytes)
+
+	if err != nil {
+		return nil, nil, err
+	}
+
+	return renewed, bytes, nil
+}
+
+// Renewable reports if less than third of the certificate validity is left
+func Renewable(certificate *x509.Certificate, now time.Time) bool {
+	lifetime := certificate.NotAfter.Sub(certificate.NotBefore)
+	return certificate.NotAfter.Sub(now) < lifetime/3
+}
+
+// Pool holds every CA trusted at the moment: signing one and the other side of rollover if it is in progress
+func (keys *Keys) Pool() *x509.CertPool {
+	keys.lock.RLock()
+	defer keys.lock.RUnlock()
+
+	pool := x509.NewCertPool()
+
+	for _, ca := range []*CA{keys.CA, keys.Staged, keys.Retired} {
+		if ca != nil && ca.Certificate != nil {
+			pool.AddCert(ca.Certificate)
+		}
+	}
+
+	return pool
+}
+
+// TLSConfig for listeners requiring client certificate, trusted CAs are resolved on every handshake to follow rollover
+func (keys *Keys) TLSConfig(verify func([][]byte, [][]*x509.Certificate) error) *tls.Config {
+	config := &tls.Config{
+		ClientAuth:            tls.RequireAndVerifyClientCert,
+		ClientCAs:             keys.Pool(),
+		GetCertificate:        keys.Reloader.GetCertificateFunc(),
+		VerifyPeerCertificate: verify,
+	}
+
+	config.GetConfigForClient = func(*tls.ClientHelloInfo) (*tls.Config, error) {
+		current := config.Clone()
+		current.GetConfigForClient = nil
+		current.ClientCAs = keys.Pool()
+
+		return current, nil
+	}
+
+	return config
+}
+
+// StageCA generates the next CA, it is trusted everywhere but nothing is signed by it yet
+// Phases are idempotent so the phase can be repeated when distributing it to some node failed
+func (keys *Keys) StageCA() error {
+	keys.lock.Lock()
+	defer keys.lock.Unlock()
+
+	if keys.Retired != nil {
+		return ERROR_ROLLOVER_IN_PROGRESS
+	}
+
+	if keys.Staged != nil {
+		return nil
+	}
+
+	staged := NewCA()
+	err := staged.Generate()
+
+	if err != nil {
+		return err
+	}
+
+	err = staged.CrossSign(keys.CA)
+
+	if err != nil {
+		return err
+	}
+
+	keys.Staged = staged
+	return nil
+}
+
+// PromoteCA makes the staged CA the signing one, previous CA stays trusted until rollover is finished
+func (keys *Keys) PromoteCA() error {
+	keys.lock.Lock()
+	defer keys.lock.Unlock()
+
+	if keys.Staged == nil {
+		if keys.Retired != nil {
+			return nil
+		}
+
+		return ERROR_NOTHING_STAGED
+	}
+
+	keys.Retired = keys.CA
+	keys.CA = keys.Staged
+	keys.Staged = nil
+
+	return nil
+}
+
+// FinishCA stops trusting the retired CA
+func (keys *Keys) FinishCA() error {
+	keys.lock.Lock()
+	defer keys.lock.Unlock()
+
+	if keys.Staged != nil {
+		return ERROR_NOT_PROMOTED
+	}
+
+	keys.Retired = nil
+	return nil
+}
+
+func (keys *Keys) GetTrust() *Trust {
+	keys.lock.RLock()
+	defer keys.lock.RUnlock()
+
+	return &Trust{CA: keys.CA, Staged: keys.Staged, Retired: keys.Retired}
+}
+
+// SetTrust replaces CAs with ones received from the node running rollover, returns true if signing CA changed
+func (keys *Keys) SetTrust(trust *Trust) (bool, error) {
+	for _, ca := range []*CA{trust.CA, trust.Staged, trust.Retired} {
+		if ca == nil {
+			continue
+		}
+
+		if err := ca.Load(); err != nil {
+			return false, err
+		}
+	}
+
+	if trust.CA == nil {
+		return false, errors.New("signing certificate authority is missing")
+	}
+
+	keys.lock.Lock()
+	defer keys.lock.Unlock()
+
+	changed := !keys.CA.Certificate.Equal(trust.CA.Certificate)
+
+	keys.CA = trust.CA
+	keys.Staged = trust.Staged
+	keys.Retired = trust.Retired
+
+	return changed, nil
+}
+
+// ReadTrust loads CAs of the rollover in progress and writes trust bundle used by the raft transport
+func (keys *Keys) ReadTrust(directory string) error {
+	keys.lock.Lock()
+
+	for name, ca := range map[string]**CA{CA_STAGED: &keys.Staged, CA_RETIRED: &keys.Retired} {
+		*ca = nil
+		tmp := NewCA()
+
+		err := tmp.ReadAs(directory, name)
+
+		if err == nil {
+			*ca = tmp
+		} else if !errors.Is(err, os.ErrNotExist) {
+			keys.lock.Unlock()
+			return err
+		}
+	}
+
+	keys.lock.Unlock()
+
+	return keys.writeBundle(directory)
+}
+
+// WriteTrust persists CAs and removes the ones no longer part of the rollover
+func (keys *Keys) WriteTrust(directory string) error {
+	trust := keys.GetTrust()
+
+	err := trust.CA.WriteAs(directory, CA_NAME)
+
+	if err != nil {
+		return err
+	}
+
+	for name, ca := range map[string]*CA{CA_STAGED: trust.Staged, CA_RETIRED: trust.Retired} {
+		if ca != nil {
+			err = ca.WriteAs(directory, name)
+		} else {
+			err = remove(directory, name)
+		}
+
+		if err != nil {
+			return err
+		}
+	}
+
+	return keys.writeBundle(directory)
+}
+
+func (keys *Keys) writeBundle(directory string) error {
+	trust := keys.GetTrust()
+	bundle := make([]byte, 0)
+
+	for _, ca := range []*CA{trust.CA, trust.Staged, trust.Retired} {
+		if ca == nil {
+			continue
+		}
+
+		PemCertificate, err := PEMEncode(CERTIFICATE, ca.CertificateBytes)
+
+		if err != nil {
+			return err
+		}
+
+		bundle = append(bundle, PemCertificate...)
+	}
+
+	keys.TrustPath = fmt.Sprintf("%s/%s", directory, TRUST_BUNDLE)
+	return os.WriteFile(keys.TrustPath, bundle, 0644)
+}
+
+func remove(directory string, name string) error {
+	for _, file := range []string{"%s/%s.crt", "%s/%s.key", "%s/%s-cross.crt"} {
+		err := os.Remove(fmt.Sprintf(file, directory, name))
+
+		if err != nil && !os.IsNotExist(err) {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// Info lists certificates held by the node with their expiry
+func (keys *Keys) Info(node string) []Info {
+	trust := keys.GetTrust()
+	infos := make([]Info, 0)
+
+	for i, ca := range []*CA{trust.CA, trust.Staged, trust.Retired} {
+		if ca != nil && ca.Certificate != nil {
+			infos = append(infos, newInfo(node, TYPE_CA, CA_NAME, ca.Certificate, []string{STATUS_SIGNING, STATUS_STAGED, STATUS_RETIRED}[i]))
+		}
+	}
+
+	if keys.Server != nil && keys.Server.Certificate != nil {
+		infos = append(infos, newInfo(node, TYPE_SERVER, keys.Server.Certificate.Subject.CommonName, keys.Server.Certificate, ""))
+	}
+
+	if client, ok := keys.Clients[node]; ok && client.Certificate != nil {
+		infos = append(infos, newInfo(node, TYPE_CLIENT, node, client.Certificate, ""))
+	}
+
+	return infos
+}
+
+func newInfo(node string, kind string, name string, certificate *x509.Certificate, status string) Info {
+	return Info{
+		Node:      node,
+		Type:      kind,
+		Name:      name,
+		Serial:    certificate.SerialNumber.Text(16),
+		NotBefore: certificate.NotBefore,
+		NotAfter:  certificate.NotAfter,
+		Status:    status,
+	}
+}
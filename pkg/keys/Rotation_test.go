@@ -0,0 +1,116 @@
+package keys
+
+import (
+	"crypto/x509"
+	"github.com/simplecontainer/smr/pkg/configuration"
+	"github.com/stretchr/testify/assert"
+	"testing"
+	"time"
+)
+
+func verify(t *testing.T, server *Server, roots ...*CA) error {
+	pool := x509.NewCertPool()
+
+	for _, root := range roots {
+		pool.AddCert(root.Certificate)
+	}
+
+	intermediates := x509.NewCertPool()
+
+	if len(server.ChainBytes) > 0 {
+		cross, err := x509.ParseCertificate(server.ChainBytes)
+		assert.NoError(t, err)
+
+		intermediates.AddCert(cross)
+	}
+
+	_, err := server.Certificate.Verify(x509.VerifyOptions{
+		Roots:         pool,
+		Intermediates: intermediates,
+		KeyUsages:     []x509.ExtKeyUsage{x509.ExtKeyUsageServerAuth},
+	})
+
+	return err
+}
+
+func TestRollover(t *testing.T) {
+	keys := NewKeys()
+	assert.NoError(t, keys.GenerateCA())
+	assert.NoError(t, keys.GenerateServer(configuration.NewDomains([]string{"localhost"}), configuration.NewIPs([]string{"127.0.0.1"}), time.Hour))
+
+	previous := keys.CA
+
+	assert.ErrorIs(t, keys.PromoteCA(), ERROR_NOTHING_STAGED)
+	assert.NoError(t, keys.StageCA())
+	assert.NoError(t, keys.StageCA())
+
+	staged := keys.Staged
+	assert.ErrorIs(t, keys.FinishCA(), ERROR_NOT_PROMOTED)
+
+	assert.NoError(t, keys.PromoteCA())
+	assert.Equal(t, staged, keys.CA)
+	assert.Equal(t, previous, keys.Retired)
+	assert.ErrorIs(t, keys.StageCA(), ERROR_ROLLOVER_IN_PROGRESS)
+
+	serial := keys.Server.Certificate.SerialNumber
+	publicKey := keys.Server.Certificate.PublicKey
+
+	assert.NoError(t, keys.Server.Renew(keys.CA, time.Hour))
+	assert.NotEqual(t, serial, keys.Server.Certificate.SerialNumber)
+	assert.Equal(t, publicKey, keys.Server.Certificate.PublicKey)
+
+	// Peers that loaded only previous CA still accept renewed certificate through cross-signed chain
+	assert.NoError(t, verify(t, keys.Server, previous))
+	assert.NoError(t, verify(t, keys.Server, keys.CA))
+
+	assert.NoError(t, keys.FinishCA())
+	assert.Nil(t, keys.Retired)
+}
+
+func TestTrustPersistence(t *testing.T) {
+	directory := t.TempDir()
+
+	keys := NewKeys()
+	assert.NoError(t, keys.GenerateCA())
+	assert.NoError(t, keys.StageCA())
+	assert.NoError(t, keys.WriteTrust(directory))
+
+	read := NewKeys()
+	assert.NoError(t, read.CA.Read(directory))
+	assert.NoError(t, read.ReadTrust(directory))
+	assert.NotNil(t, read.Staged)
+	assert.Equal(t, keys.Staged.CertificateBytes, read.Staged.CertificateBytes)
+	assert.Equal(t, keys.Staged.CrossBytes, read.Staged.CrossBytes)
+
+	assert.NoError(t, keys.PromoteCA())
+	assert.NoError(t, keys.FinishCA())
+	assert.NoError(t, keys.WriteTrust(directory))
+
+	read = NewKeys()
+	assert.NoError(t, read.CA.Read(directory))
+	assert.NoError(t, read.ReadTrust(directory))
+	assert.Nil(t, read.Staged)
+	assert.Nil(t, read.Retired)
+	assert.Equal(t, keys.CA.CertificateBytes, read.CA.CertificateBytes)
+}
+
+func TestRenewable(t *testing.T) {
+	now := time.Now()
+
+	tests := []struct {
+		name      string
+		notBefore time.Time
+		notAfter  time.Time
+		expected  bool
+	}{
+		{name: "Fresh", notBefore: now, notAfter: now.Add(90 * time.Hour), expected: false},
+		{name: "Third left", notBefore: now.Add(-61 * time.Hour), notAfter: now.Add(29 * time.Hour), expected: true},
+		{name: "Expired", notBefore: now.Add(-2 * time.Hour), notAfter: now.Add(-time.Hour), expected: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.expected, Renewable(&x509.Certificate{NotBefore: tt.notBefore, NotAfter: tt.notAfter}, now))
+		})
+	}
+}
@@ -7,6 +7,7 @@ import (
 	"crypto/sha1"
 	"crypto/x509"
 	"crypto/x509/pkix"
+	"encoding/pem"
 	"fmt"
 	"github.com/simplecontainer/smr/pkg/configuration"
 	"github.com/simplecontainer/smr/pkg/static"
@@ -21,7 +22,7 @@ func NewServer() *Server {
 	}
 }
 
-func (server *Server) Generate(ca *CA, domains *configuration.Domains, ips *configuration.IPs, CN string) error {
+func (server *Server) Generate(ca *CA, domains *configuration.Domains, ips *configuration.IPs, CN string, expiry time.Duration) error {
 	var err error
 
 	server.Sni = generateSerialNumber()
@@ -55,7 +56,7 @@ func (server *Server) Generate(ca *CA, domains *configuration.Domains, ips *conf
 		DNSNames:     append(domains.ToStringSlice(), []string{fmt.Sprintf("%s.%s", static.SMR_ENDPOINT_NAME, static.SMR_LOCAL_DOMAIN)}...),
 		IPAddresses:  append(ips.ToIPNetSlice(), net.IPv6loopback),
 		NotBefore:    time.Now(),
-		NotAfter:     time.Now().AddDate(10, 0, 0),
+		NotAfter:     notAfter(expiry),
 		SubjectKeyId: SubjectKeyIdentifier[:],
 		ExtKeyUsage:  []x509.ExtKeyUsage{x509.ExtKeyUsageClientAuth, x509.ExtKeyUsageServerAuth},
 		KeyUsage:     x509.KeyUsageDigitalSignature | x509.KeyUsageKeyEncipherment,
@@ -67,6 +68,8 @@ func (server *Server) Generate(ca *CA, domains *configuration.Domains, ips *conf
 		return err
 	}
 
+	server.ChainBytes = ca.CrossBytes
+
 	server.Certificate, err = x509.ParseCertificate(server.CertificateBytes)
 
 	if err != nil {
@@ -88,6 +91,17 @@ func (server *Server) Write(directory string, agent string) error {
 		return err
 	}
 
+	if len(server.ChainBytes) > 0 {
+		var PemChain []byte
+		PemChain, err = PEMEncode(CERTIFICATE, server.ChainBytes)
+
+		if err != nil {
+			return err
+		}
+
+		PemCertificate = append(PemCertificate, PemChain...)
+	}
+
 	PemPrivateKey, err := PEMEncode(PRIVATE_KEY, server.PrivateKeyBytes)
 
 	if err != nil {
@@ -132,6 +146,12 @@ func (server *Server) Read(directory string, agent string) error {
 		return err
 	}
 
+	if _, rest := pem.Decode(PemCertificate); len(rest) > 0 {
+		if block, _ := pem.Decode(rest); block != nil {
+			server.ChainBytes = block.Bytes
+		}
+	}
+
 	var PrivateKeyTmp any
 	PrivateKeyTmp, err = x509.ParsePKCS8PrivateKey(PEMDecode(PemPrivateKey))
 
@@ -149,3 +169,19 @@ func (server *Server) Read(directory string, agent string) error {
 
 	return nil
 }
+
+// Renew re-signs the certificate for the same key pair, peers and contexts holding the key keep working
+func (server *Server) Renew(ca *CA, expiry time.Duration) error {
+	var err error
+
+	server.Certificate, server.CertificateBytes, err = renew(server.Certificate, &server.PrivateKey.PublicKey, ca, expiry)
+
+	if err != nil {
+		return err
+	}
+
+	server.Sni = server.Certificate.SerialNumber
+	server.ChainBytes = ca.CrossBytes
+
+	return nil
+}
@@ -3,15 +3,55 @@ package keys
 import (
 	"crypto/ecdsa"
 	"crypto/x509"
+	"github.com/simplecontainer/smr/pkg/encrypt"
 	"math/big"
+	"sync"
+	"time"
 )
 
+const CA_NAME = "ca"
+const CA_STAGED = "ca-staged"
+const CA_RETIRED = "ca-retired"
+const TRUST_BUNDLE = "trust.crt"
+
+// NODE_UNIT is organizational unit of client certificates identifying cluster nodes
+const NODE_UNIT = "node"
+
+const TYPE_CA = "ca"
+const TYPE_SERVER = "server"
+const TYPE_CLIENT = "client"
+
+const STATUS_SIGNING = "signing"
+const STATUS_STAGED = "staged"
+const STATUS_RETIRED = "retired"
+
 type Keys struct {
-	CA       *CA
-	Server   *Server
-	Clients  map[string]*Client
-	Reloader *keypairReloader `json:"-"`
-	Sni      uint64
+	CA         *CA
+	Staged     *CA
+	Retired    *CA
+	Server     *Server
+	Clients    map[string]*Client
+	Encryption *encrypt.KeyRing `json:"-"`
+	Reloader   *keypairReloader `json:"-"`
+	TrustPath  string           `json:"-"`
+	Sni        uint64
+	lock       sync.RWMutex
+}
+
+type Trust struct {
+	CA      *CA
+	Staged  *CA
+	Retired *CA
+}
+
+type Info struct {
+	Node      string    `json:"node"`
+	Type      string    `json:"type"`
+	Name      string    `json:"name"`
+	Serial    string    `json:"serial"`
+	NotBefore time.Time `json:"notBefore"`
+	NotAfter  time.Time `json:"notAfter"`
+	Status    string    `json:"status"`
 }
 
 type Encrypted struct {
@@ -25,6 +65,7 @@ type CA struct {
 	CertificatePath  string            `json:"-"`
 	CertificateBytes []byte
 	PrivateKeyBytes  []byte
+	CrossBytes       []byte
 	Sni              *big.Int
 }
 
@@ -35,6 +76,7 @@ type Server struct {
 	CertificatePath  string            `json:"-"`
 	CertificateBytes []byte
 	PrivateKeyBytes  []byte
+	ChainBytes       []byte
 	Sni              *big.Int
 }
 
@@ -13,7 +13,10 @@ import (
 	"github.com/simplecontainer/smr/pkg/kinds/httpauth"
 	"github.com/simplecontainer/smr/pkg/kinds/network"
 	"github.com/simplecontainer/smr/pkg/kinds/node"
+	"github.com/simplecontainer/smr/pkg/kinds/notification"
 	"github.com/simplecontainer/smr/pkg/kinds/resource"
+	"github.com/simplecontainer/smr/pkg/kinds/role"
+	"github.com/simplecontainer/smr/pkg/kinds/rolebinding"
 	"github.com/simplecontainer/smr/pkg/kinds/secret"
 	"github.com/simplecontainer/smr/pkg/kinds/volume"
 	"github.com/simplecontainer/smr/pkg/logger"
@@ -44,6 +47,12 @@ func New(kind string, mgr *manager.Manager) (ikinds.Kind, error) {
 		return secret.New(mgr), nil
 	case "volume":
 		return volume.New(mgr), nil
+	case "role":
+		return role.New(mgr), nil
+	case "rolebinding":
+		return rolebinding.New(mgr), nil
+	case "notification":
+		return notification.New(mgr), nil
 	default:
 		return nil, errors.New(fmt.Sprintf("%s kind does not exist", kind))
 	}
@@ -9,9 +9,11 @@ import (
 	"github.com/simplecontainer/smr/pkg/contracts/iobjects"
 	"github.com/simplecontainer/smr/pkg/definitions"
 	"github.com/simplecontainer/smr/pkg/f"
+	"github.com/simplecontainer/smr/pkg/logger"
 	"github.com/simplecontainer/smr/pkg/network"
 	"github.com/simplecontainer/smr/pkg/objects"
 	"github.com/simplecontainer/smr/pkg/static"
+	"go.uber.org/zap"
 	"net/http"
 	"strings"
 )
@@ -76,6 +78,12 @@ func (request *Request) Action(action string, client *clients.Http, user *authen
 	switch action {
 	case "apply":
 		_, err = request.Definition.Apply(format, obj)
+
+		if err == nil && obj.ChangeDetected() {
+			if revisionErr := request.AddRevision(client, user); revisionErr != nil {
+				logger.Log.Error("failed to record definition revision", zap.Error(revisionErr))
+			}
+		}
 		break
 	case "state":
 		_, err = request.Definition.State(format, obj)
@@ -85,6 +93,12 @@ func (request *Request) Action(action string, client *clients.Http, user *authen
 		break
 	case "remove":
 		_, err = request.Definition.Delete(format, obj)
+
+		if err == nil {
+			if revisionErr := request.RemoveRevisions(client, user); revisionErr != nil {
+				logger.Log.Error("failed to remove definition revisions", zap.Error(revisionErr))
+			}
+		}
 		break
 	}
 
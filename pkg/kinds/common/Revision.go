@@ -0,0 +1,107 @@
+package common
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"github.com/simplecontainer/smr/pkg/authentication"
+	"github.com/simplecontainer/smr/pkg/clients"
+	"github.com/simplecontainer/smr/pkg/definitions"
+	"github.com/simplecontainer/smr/pkg/encrypt"
+	"github.com/simplecontainer/smr/pkg/f"
+	"github.com/simplecontainer/smr/pkg/objects"
+	"github.com/simplecontainer/smr/pkg/static"
+	"time"
+)
+
+// AddRevision records accepted definition in the object history stored next to the object
+func (request *Request) AddRevision(client *clients.Http, user *authentication.User) error {
+	bytes, err := request.Definition.ToJSON()
+
+	if err != nil {
+		return err
+	}
+
+	// History lives outside kind category so it is sealed here rather than on store
+	bytes, err = definitions.Seal(request.Definition.GetKind(), bytes, encrypt.Ring)
+
+	if err != nil {
+		return err
+	}
+
+	format := f.New(request.Definition.GetPrefix(), static.CATEGORY_REVISION, request.Definition.GetKind(), request.Definition.GetMeta().Group, request.Definition.GetMeta().Name)
+	obj := objects.New(client.Get(user.Username), user)
+
+	err = obj.Find(format)
+
+	if err != nil {
+		return err
+	}
+
+	history := make([]Revision, 0)
+
+	if obj.Exists() {
+		err = json.Unmarshal(obj.GetDefinitionByte(), &history)
+
+		if err != nil {
+			return err
+		}
+
+		// History is opened when read so earlier revisions are sealed again before it is stored
+		for i := range history {
+			history[i].Definition, err = definitions.Reseal(request.Definition.GetKind(), history[i].Definition, encrypt.Ring)
+
+			if err != nil {
+				return err
+			}
+		}
+	}
+
+	bytes, err = json.Marshal(AppendRevision(history, bytes, time.Now()))
+
+	if err != nil {
+		return err
+	}
+
+	return obj.AddLocal(format, bytes)
+}
+
+// RemoveRevisions drops object history so definition applied later under the same name starts fresh
+func (request *Request) RemoveRevisions(client *clients.Http, user *authentication.User) error {
+	format := f.New(request.Definition.GetPrefix(), static.CATEGORY_REVISION, request.Definition.GetKind(), request.Definition.GetMeta().Group, request.Definition.GetMeta().Name)
+	obj := objects.New(client.Get(user.Username), user)
+
+	_, err := obj.RemoveLocal(format)
+	return err
+}
+
+// AppendRevision adds definition as the next revision and keeps only last REVISION_HISTORY_LIMIT revisions
+func AppendRevision(history []Revision, definition []byte, created time.Time) []Revision {
+	next := uint64(1)
+
+	if len(history) > 0 {
+		next = history[len(history)-1].Revision + 1
+	}
+
+	history = append(history, Revision{
+		Revision:   next,
+		Created:    created,
+		Definition: definition,
+	})
+
+	if len(history) > REVISION_HISTORY_LIMIT {
+		history = history[len(history)-REVISION_HISTORY_LIMIT:]
+	}
+
+	return history
+}
+
+func FindRevision(history []Revision, revision uint64) (*Revision, error) {
+	for i := range history {
+		if history[i].Revision == revision {
+			return &history[i], nil
+		}
+	}
+
+	return nil, errors.New(fmt.Sprintf("revision %d not found in history", revision))
+}
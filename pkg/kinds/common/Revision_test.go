@@ -0,0 +1,32 @@
+package common
+
+import (
+	"fmt"
+	"github.com/stretchr/testify/assert"
+	"testing"
+	"time"
+)
+
+func TestAppendRevision(t *testing.T) {
+	history := make([]Revision, 0)
+
+	for i := 0; i < REVISION_HISTORY_LIMIT+2; i++ {
+		history = AppendRevision(history, []byte(fmt.Sprintf(`{"revision":%d}`, i)), time.Now())
+	}
+
+	assert.Len(t, history, REVISION_HISTORY_LIMIT)
+	assert.Equal(t, uint64(3), history[0].Revision)
+	assert.Equal(t, uint64(REVISION_HISTORY_LIMIT+2), history[len(history)-1].Revision)
+}
+
+func TestFindRevision(t *testing.T) {
+	history := AppendRevision(nil, []byte(`{"spec":"first"}`), time.Now())
+	history = AppendRevision(history, []byte(`{"spec":"second"}`), time.Now())
+
+	revision, err := FindRevision(history, 1)
+	assert.NoError(t, err)
+	assert.JSONEq(t, `{"spec":"first"}`, string(revision.Definition))
+
+	_, err = FindRevision(history, 3)
+	assert.Error(t, err)
+}
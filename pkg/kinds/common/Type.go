@@ -1,8 +1,10 @@
 package common
 
 import (
+	"encoding/json"
 	"github.com/simplecontainer/smr/pkg/contracts/ievents"
 	"github.com/simplecontainer/smr/pkg/definitions"
+	"time"
 )
 
 type Request struct {
@@ -11,3 +13,11 @@ type Request struct {
 	Kind       string
 	Error      error
 }
+
+type Revision struct {
+	Revision   uint64          `json:"revision"`
+	Created    time.Time       `json:"created"`
+	Definition json.RawMessage `json:"definition"`
+}
+
+const REVISION_HISTORY_LIMIT = 10
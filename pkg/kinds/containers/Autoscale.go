@@ -0,0 +1,87 @@
+package containers
+
+import (
+	"encoding/json"
+	v1 "github.com/simplecontainer/smr/pkg/definitions/v1"
+	"github.com/simplecontainer/smr/pkg/events/events"
+	"github.com/simplecontainer/smr/pkg/kinds/containers/platforms/autoscale"
+	"github.com/simplecontainer/smr/pkg/kinds/containers/platforms/types"
+	"github.com/simplecontainer/smr/pkg/logger"
+	"go.uber.org/zap"
+	"time"
+)
+
+// scale records new replica count in the autoscale status if average usage of replicas is off the autoscale targets,
+// definition itself is left as applied and every node reconciles its replicas on the autoscaled event
+func (containers *Containers) scale(definition *v1.ContainersDefinition) {
+	a, err := autoscale.New(definition)
+
+	if err != nil {
+		logger.Log.Error("invalid autoscale", zap.String("group", definition.Meta.Group), zap.String("name", definition.Meta.Name), zap.Error(err))
+		return
+	}
+
+	samples := make([]*types.Usage, 0)
+
+	for _, containerObj := range containers.Shared.Registry.FindGroup(definition.GetPrefix(), definition.Meta.Group) {
+		if containerObj.GetName() == definition.Meta.Name {
+			samples = append(samples, containerObj.GetRuntime().Usage)
+		}
+	}
+
+	status := containers.autoscaleStatus(definition)
+
+	now := time.Now()
+	decision := a.Desired(a.Replicas(definition, status), autoscale.Fresh(samples, now))
+
+	if decision.To == decision.From {
+		return
+	}
+
+	if status != nil && !a.Allowed(status.Scaled, now) {
+		return
+	}
+
+	// Cooldown is part of the status so it survives leader change
+	err = containers.proposeState(autoscale.STATE_AUTOSCALE, definition, autoscale.Status{
+		Replicas: decision.To,
+		Scaled:   now,
+		Decision: decision,
+	}, true)
+
+	if err != nil {
+		logger.Log.Error("failed to store autoscale status", zap.String("group", a.Group), zap.String("name", a.Name), zap.Error(err))
+		return
+	}
+
+	logger.Log.Info("autoscaled replicas", zap.String("group", a.Group), zap.String("name", a.Name),
+		zap.Uint64("from", decision.From), zap.Uint64("to", decision.To), zap.Float64("cpu", decision.CPU), zap.Float64("memory", decision.Memory))
+
+	data, _ := json.Marshal(decision)
+
+	events.Dispatch(
+		events.NewKindEvent(events.EVENT_AUTOSCALED, definition, data),
+		containers.Shared, containers.Shared.Manager.Cluster.Node.NodeID,
+	)
+}
+
+// desired sets replica count chosen by the autoscaler on the definition in memory, stored definition is untouched
+func (containers *Containers) desired(definition *v1.ContainersDefinition) {
+	a, err := autoscale.New(definition)
+
+	if err != nil || a == nil {
+		return
+	}
+
+	definition.Spec.Replicas = a.Replicas(definition, containers.autoscaleStatus(definition))
+}
+
+func (containers *Containers) autoscaleStatus(definition *v1.ContainersDefinition) *autoscale.Status {
+	status := &autoscale.Status{}
+
+	if !containers.findState(autoscale.STATE_AUTOSCALE, definition, status) {
+		return nil
+	}
+
+	return status
+}
@@ -1,6 +1,7 @@
 package containers
 
 import (
+	"github.com/simplecontainer/smr/pkg/kinds/containers/platforms/rollout"
 	"github.com/simplecontainer/smr/pkg/kinds/containers/shared"
 	"github.com/simplecontainer/smr/pkg/manager"
 )
@@ -14,5 +15,6 @@ func New(mgr *manager.Manager) *Containers {
 			DnsCache: mgr.DnsCache,
 			User:     mgr.User,
 		},
+		Rollouts: make(map[string]*rollout.Rollout),
 	}
 }
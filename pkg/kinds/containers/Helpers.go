@@ -17,7 +17,7 @@ func (containers *Containers) Create(cs []platforms.IContainer, exists bool, use
 		if !exists || existingWatcher == nil {
 			s := status.CREATED
 
-			if exists && containerObj.IsGhost() {
+			if exists && containers.ownedByAnotherNode(containerObj) {
 				s = status.TRANSFERING
 			}
 
@@ -25,7 +25,7 @@ func (containers *Containers) Create(cs []platforms.IContainer, exists bool, use
 			containers.Shared.Watchers.AddOrUpdate(groupIdentifier, w)
 			containers.Shared.Registry.AddOrUpdate(containerObj.GetGroup(), containerObj.GetGeneratedName(), containerObj)
 
-			containerObj.GetStatus().QueueState(status.CREATED, time.Now())
+			containerObj.GetStatus().QueueState(s, time.Now())
 			w.Logger.Info("container object created")
 
 			go reconcile.HandleTickerAndEvents(containers.Shared, w, func(w *watcher.Container) error {
@@ -74,3 +74,14 @@ func (containers *Containers) Destroy(cs []platforms.IContainer, exists bool) {
 		}
 	}
 }
+
+// ownedByAnotherNode checks if replicated state of the container is still owned by another node
+func (containers *Containers) ownedByAnotherNode(containerObj platforms.IContainer) bool {
+	remote := containers.Shared.Registry.FindRemote(containerObj.GetDefinition().GetPrefix(), containerObj.GetGroup(), containerObj.GetGeneratedName())
+
+	if remote == nil || remote.GetNode() == nil {
+		return false
+	}
+
+	return remote.GetNode().NodeID != containers.Shared.Manager.Cluster.Node.NodeID
+}
@@ -3,6 +3,7 @@ package containers
 import (
 	"errors"
 	"fmt"
+	"github.com/simplecontainer/smr/internal/helpers"
 	"github.com/simplecontainer/smr/pkg/authentication"
 	"github.com/simplecontainer/smr/pkg/contracts/ievents"
 	"github.com/simplecontainer/smr/pkg/contracts/iresponse"
@@ -12,8 +13,10 @@ import (
 	"github.com/simplecontainer/smr/pkg/events/platform/listener"
 	"github.com/simplecontainer/smr/pkg/kinds/common"
 	"github.com/simplecontainer/smr/pkg/kinds/containers/platforms"
+	"github.com/simplecontainer/smr/pkg/kinds/containers/platforms/autoscale"
 	"github.com/simplecontainer/smr/pkg/kinds/containers/platforms/engines/docker"
 	"github.com/simplecontainer/smr/pkg/kinds/containers/platforms/replicas"
+	"github.com/simplecontainer/smr/pkg/kinds/containers/platforms/rollout"
 	"github.com/simplecontainer/smr/pkg/kinds/containers/registry"
 	"github.com/simplecontainer/smr/pkg/kinds/containers/shared"
 	"github.com/simplecontainer/smr/pkg/kinds/containers/status"
@@ -25,6 +28,7 @@ import (
 	"go.uber.org/zap"
 	"net/http"
 	"os"
+	"strings"
 	"time"
 )
 
@@ -51,6 +55,7 @@ func (containers *Containers) Start() error {
 
 	// Start listening events based on the platform and for internal events
 	go listener.Listen(containers.Shared, containers.Shared.Manager.Config.Platform)
+	go containers.Monitor()
 
 	logger.Log.Info(fmt.Sprintf("started listening events for simplecontainer and platform: %s", containers.Shared.Manager.Config.Platform))
 
@@ -66,6 +71,26 @@ func (containers *Containers) Apply(user *authentication.User, definition []byte
 		return common.Response(http.StatusBadRequest, "invalid definition sent", err, nil), err
 	}
 
+	containersDefinition := request.Definition.Definition.(*v1.ContainersDefinition)
+
+	r, err := rollout.New(containersDefinition)
+
+	if err != nil {
+		return common.Response(http.StatusBadRequest, "invalid update strategy", err, nil), err
+	}
+
+	_, err = replicas.NewPlacement(containersDefinition)
+
+	if err != nil {
+		return common.Response(http.StatusBadRequest, "invalid placement", err, nil), err
+	}
+
+	_, err = autoscale.New(containersDefinition)
+
+	if err != nil {
+		return common.Response(http.StatusBadRequest, "invalid autoscale", err, nil), err
+	}
+
 	obj, err := request.Apply(containers.Shared.Client, user)
 
 	if request.Definition.GetState() != nil && !request.Definition.GetState().GetOpt("replay").IsEmpty() {
@@ -80,11 +105,15 @@ func (containers *Containers) Apply(user *authentication.User, definition []byte
 		return common.Response(http.StatusBadRequest, "", err, nil), err
 	}
 
+	// Replica count chosen by the autoscaler is kept apart from the definition so applies don't revert it
+	containers.desired(containersDefinition)
+	r.Replicas = containersDefinition.Spec.Replicas
+
 	var create []platforms.IContainer
 	var update []platforms.IContainer
 	var destroy []platforms.IContainer
 
-	create, update, destroy, err = GenerateContainers(containers.Shared, request.Definition.Definition.(*v1.ContainersDefinition), obj.GetDiff())
+	create, update, destroy, err = GenerateContainers(containers.Shared, containersDefinition, obj.GetDiff())
 
 	if err != nil {
 		return common.Response(http.StatusInternalServerError, "failed to generate replica counts", err, nil), err
@@ -94,11 +123,33 @@ func (containers *Containers) Apply(user *authentication.User, definition []byte
 		containers.Destroy(destroy, obj.Exists())
 	}
 
-	if len(update) > 0 {
-		if len(obj.GetDiff()) == 1 {
-			if obj.GetDiff()[0].Path == "/spec/replicas" {
-				update = nil
-			}
+	templateChanged := len(obj.GetDiff()) == 0
+
+	// Scaling, either manual or by autoscaler, doesn't require recreating existing replicas
+	for _, diff := range obj.GetDiff() {
+		if diff.Path != "/spec/replicas" && !strings.HasPrefix(diff.Path, "/spec/autoscale") {
+			templateChanged = true
+		}
+	}
+
+	// Template change is held back while failed rollout waits for resume, resume rolls out the latest template
+	if obj.Exists() && templateChanged && containers.IsPaused(containersDefinition) {
+		logger.Log.Info("rollout is paused, replicas are not updated", zap.String("group", r.Group), zap.String("name", r.Name))
+	} else if obj.Exists() && templateChanged && r.IsRolling() {
+		// Surge replicas are generated on every node, even ones without replicas to update, so gating is same everywhere
+		var surge []platforms.IContainer
+		surge, err = GenerateSurgeContainers(containers.Shared, containersDefinition, r.MaxSurge)
+
+		if err != nil {
+			return common.Response(http.StatusInternalServerError, "failed to generate surge replicas", err, nil), err
+		}
+
+		if len(update) > 0 || len(surge) > 0 {
+			go containers.Rollout(r, containersDefinition, update, surge, user)
+		}
+	} else if len(update) > 0 {
+		if !templateChanged {
+			update = nil
 		}
 
 		containers.Update(update, obj.Exists())
@@ -138,6 +189,14 @@ func (containers *Containers) Delete(user *authentication.User, definition []byt
 		return common.Response(http.StatusTeapot, "", err, nil), err
 	}
 
+	if containers.IsPaused(request.Definition.Definition.(*v1.ContainersDefinition)) {
+		helpers.LogIfError(containers.pauseRollout(request.Definition.Definition.(*v1.ContainersDefinition), rollout.Status{}))
+	}
+
+	if containers.autoscaleStatus(request.Definition.Definition.(*v1.ContainersDefinition)) != nil {
+		helpers.LogIfError(containers.proposeState(autoscale.STATE_AUTOSCALE, request.Definition.Definition.(*v1.ContainersDefinition), nil, false))
+	}
+
 	var destroy []platforms.IContainer
 	destroy, err = GetContainers(containers.Shared, request.Definition.Definition.(*v1.ContainersDefinition))
 
@@ -172,6 +231,20 @@ func (containers *Containers) Event(event ievents.Event) error {
 				continue
 			}
 
+			// Mounted secret is refreshed in place, container is restarted only if it depends on it otherwise
+			if event.GetKind() == static.KIND_SECRET && containerWatcher.Container.HasSecret(event.GetGroup(), event.GetName()) &&
+				!containerWatcher.Container.HasDependencyOn(event.GetKind(), event.GetGroup(), event.GetName()) {
+				err := containerWatcher.Container.RefreshSecrets(containers.Shared.Client, containerWatcher.User)
+
+				if err != nil {
+					containerWatcher.Logger.Error("failed to refresh mounted secret", zap.Error(err))
+				} else {
+					containerWatcher.Logger.Info("refreshed mounted secret", zap.String("event", fmt.Sprintf("%s/%s/%s", event.GetKind(), event.GetGroup(), event.GetName())))
+				}
+
+				continue
+			}
+
 			if containerWatcher.Container.HasDependencyOn(event.GetKind(), event.GetGroup(), event.GetName()) {
 				if containerWatcher.GetAllowPlatformEvents() {
 					err := containerWatcher.Container.GetStatus().QueueState(status.RESTART, time.Now())
@@ -210,6 +283,22 @@ func (containers *Containers) Event(event ievents.Event) error {
 		containerW.Container.GetStatus().QueueState(status.RESTART, time.Now())
 		containerW.SendToQueue(containerObj, 5*time.Second)
 
+		break
+	case events.EVENT_AUTOSCALED:
+		definition, err := containers.definition(event.GetGroup(), event.GetName())
+
+		if err != nil {
+			return err
+		}
+
+		// Event listener is sequential - don't block it while replicas are created or destroyed
+		go containers.reschedule(definition)
+		break
+	case events.EVENT_RESUME:
+		return containers.Resume(event.GetGroup(), event.GetName())
+	case events.EVENT_NODE_UNREACHABLE, events.EVENT_NODE_REACHABLE:
+		// Event listener is sequential - don't block it while replicas are rescheduled
+		go containers.Reschedule()
 		break
 	}
 
@@ -220,6 +309,10 @@ func GenerateContainers(shared *shared.Shared, definition *v1.ContainersDefiniti
 	r := replicas.New(shared.Manager.Cluster.Node.NodeID, shared.Manager.Cluster.Cluster.Nodes)
 	return r.GenerateContainers(shared.Registry, definition, shared.Manager.Config)
 }
+func GenerateSurgeContainers(shared *shared.Shared, definition *v1.ContainersDefinition, surge uint64) ([]platforms.IContainer, error) {
+	r := replicas.New(shared.Manager.Cluster.Node.NodeID, shared.Manager.Cluster.Cluster.Nodes)
+	return r.GenerateSurgeContainers(shared.Registry, definition, shared.Manager.Config, surge)
+}
 func GetContainers(shared *shared.Shared, definition *v1.ContainersDefinition) ([]platforms.IContainer, error) {
 	r := replicas.New(shared.Manager.Cluster.Node.NodeID, shared.Manager.Cluster.Cluster.Nodes)
 	return r.RemoveContainers(shared.Registry, definition)
@@ -0,0 +1,95 @@
+package containers
+
+import (
+	"errors"
+	"fmt"
+	v1 "github.com/simplecontainer/smr/pkg/definitions/v1"
+	"github.com/simplecontainer/smr/pkg/f"
+	"github.com/simplecontainer/smr/pkg/kinds/common"
+	"github.com/simplecontainer/smr/pkg/logger"
+	"github.com/simplecontainer/smr/pkg/objects"
+	"github.com/simplecontainer/smr/pkg/static"
+	"go.uber.org/zap"
+)
+
+// Reschedule recalculates replica placement of every containers definition after cluster membership changed
+func (containers *Containers) Reschedule() {
+	for _, definition := range containers.definitions() {
+		containers.reschedule(definition)
+	}
+}
+
+// reschedule creates and destroys local replicas so the placement matches desired replica count of the definition
+func (containers *Containers) reschedule(definition *v1.ContainersDefinition) {
+	containers.desired(definition)
+
+	create, _, destroy, err := GenerateContainers(containers.Shared, definition, nil)
+
+	if err != nil {
+		logger.Log.Error("failed to reschedule replicas", zap.String("group", definition.Meta.Group), zap.String("name", definition.Meta.Name), zap.Error(err))
+		return
+	}
+
+	if len(create) > 0 || len(destroy) > 0 {
+		logger.Log.Info("rescheduling replicas", zap.String("group", definition.Meta.Group), zap.String("name", definition.Meta.Name), zap.Int("create", len(create)), zap.Int("destroy", len(destroy)))
+	}
+
+	if len(destroy) > 0 {
+		containers.Destroy(destroy, true)
+	}
+
+	if len(create) > 0 {
+		containers.Create(create, true, containers.Shared.User)
+	}
+}
+
+// definitions lists every containers definition stored in the cluster
+func (containers *Containers) definitions() []*v1.ContainersDefinition {
+	format := f.New(static.SMR_PREFIX, static.CATEGORY_KIND, static.KIND_CONTAINERS)
+	obj := objects.New(containers.Shared.Client.Clients[containers.Shared.User.Username], containers.Shared.User)
+
+	definitions := make([]*v1.ContainersDefinition, 0)
+	objs, err := obj.FindMany(format)
+
+	if err != nil {
+		logger.Log.Error("failed to list containers definitions", zap.Error(err))
+		return definitions
+	}
+
+	for _, o := range objs {
+		request, err := common.NewRequestFromJson(static.KIND_CONTAINERS, o.GetDefinitionByte())
+
+		if err != nil {
+			logger.Log.Error("failed to parse containers definition", zap.Error(err))
+			continue
+		}
+
+		definitions = append(definitions, request.Definition.Definition.(*v1.ContainersDefinition))
+	}
+
+	return definitions
+}
+
+// definition finds containers definition stored in the cluster
+func (containers *Containers) definition(group string, name string) (*v1.ContainersDefinition, error) {
+	format := f.New(static.SMR_PREFIX, static.CATEGORY_KIND, static.KIND_CONTAINERS, group, name)
+	obj := objects.New(containers.Shared.Client.Clients[containers.Shared.User.Username], containers.Shared.User)
+
+	err := obj.Find(format)
+
+	if err != nil {
+		return nil, err
+	}
+
+	if !obj.Exists() {
+		return nil, errors.New(fmt.Sprintf("containers definition %s/%s not found", group, name))
+	}
+
+	request, err := common.NewRequestFromJson(static.KIND_CONTAINERS, obj.GetDefinitionByte())
+
+	if err != nil {
+		return nil, err
+	}
+
+	return request.Definition.Definition.(*v1.ContainersDefinition), nil
+}
@@ -0,0 +1,378 @@
+package containers
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"github.com/simplecontainer/smr/pkg/authentication"
+	v1 "github.com/simplecontainer/smr/pkg/definitions/v1"
+	"github.com/simplecontainer/smr/pkg/events/events"
+	"github.com/simplecontainer/smr/pkg/f"
+	"github.com/simplecontainer/smr/pkg/kinds/common"
+	"github.com/simplecontainer/smr/pkg/kinds/containers/platforms"
+	containerplatform "github.com/simplecontainer/smr/pkg/kinds/containers/platforms/containers"
+	"github.com/simplecontainer/smr/pkg/kinds/containers/platforms/rollout"
+	"github.com/simplecontainer/smr/pkg/logger"
+	"github.com/simplecontainer/smr/pkg/objects"
+	"github.com/simplecontainer/smr/pkg/static"
+	"go.uber.org/zap"
+	"sort"
+	"time"
+)
+
+// Rollout replaces local replicas batch by batch and waits for each of them to become ready before moving on
+func (containers *Containers) Rollout(r *rollout.Rollout, definition *v1.ContainersDefinition, update []platforms.IContainer, surge []platforms.IContainer, user *authentication.User) {
+	containers.startRollout(r)
+	defer containers.stopRollout(r)
+
+	logger.Log.Info("rollout started", zap.String("group", r.Group), zap.String("name", r.Name), zap.Int("replicas", len(update)), zap.Int("surge", len(surge)))
+
+	if len(surge) > 0 {
+		containers.Create(surge, true, user)
+	}
+
+	sort.Slice(update, func(i, j int) bool {
+		a, _ := update[i].GetIndex()
+		b, _ := update[j].GetIndex()
+		return a < b
+	})
+
+	replaced := make([]platforms.IContainer, 0)
+
+	for _, containerObj := range update {
+		index, err := containerObj.GetIndex()
+
+		if err != nil {
+			containers.failRollout(r, definition, replaced, surge, err)
+			return
+		}
+
+		previous := containers.Shared.Registry.FindLocal(containerObj.GetGroup(), containerObj.GetGeneratedName())
+
+		if previous != nil && previous.GetSpecHash() == r.SpecHash {
+			continue
+		}
+
+		err = r.Wait(func() (bool, error) {
+			decision, err := r.Gate(containers.replicas(definition), index)
+			return decision == rollout.PROCEED, err
+		})
+
+		if err != nil {
+			containers.failRollout(r, definition, replaced, surge, err)
+			return
+		}
+
+		if previous != nil {
+			replaced = append(replaced, previous)
+		}
+
+		containers.Update([]platforms.IContainer{containerObj}, true)
+
+		err = r.Wait(func() (bool, error) {
+			replica := toReplica(containerObj, index)
+
+			if replica.IsFailed() {
+				return false, rollout.ERROR_REPLICA_FAILED
+			}
+
+			return replica.IsReady(), nil
+		})
+
+		if err != nil {
+			containers.failRollout(r, definition, replaced, surge, err)
+			return
+		}
+	}
+
+	if len(surge) > 0 {
+		err := r.Wait(func() (bool, error) {
+			return r.Completed(containers.replicas(definition))
+		})
+
+		if err != nil {
+			containers.failRollout(r, definition, replaced, surge, err)
+			return
+		}
+
+		containers.Destroy(surge, true)
+	}
+
+	logger.Log.Info("rollout completed", zap.String("group", r.Group), zap.String("name", r.Name))
+
+	data, _ := json.Marshal(map[string]string{
+		"hash": r.SpecHash,
+	})
+
+	events.Dispatch(
+		events.NewKindEvent(events.EVENT_ROLLOUT_COMPLETED, definition, data),
+		containers.Shared, containers.Shared.Manager.Cluster.Node.NodeID,
+	)
+}
+
+func (containers *Containers) failRollout(r *rollout.Rollout, definition *v1.ContainersDefinition, replaced []platforms.IContainer, surge []platforms.IContainer, err error) {
+	// Superseded by newer apply - new rollout takes over from here
+	if errors.Is(err, context.Canceled) {
+		logger.Log.Info("rollout canceled", zap.String("group", r.Group), zap.String("name", r.Name))
+		return
+	}
+
+	logger.Log.Error("rollout failed", zap.String("group", r.Group), zap.String("name", r.Name), zap.String("action", r.OnFailure), zap.Error(err))
+
+	if r.OnFailure == rollout.ON_FAILURE_ROLLBACK {
+		rollbackErr := containers.rollback(r, definition)
+
+		// Replicas are still restored locally if previous revision can't be proposed
+		if rollbackErr != nil {
+			logger.Log.Error("failed to propose previous revision", zap.String("group", r.Group), zap.String("name", r.Name), zap.Error(rollbackErr))
+
+			for _, previous := range replaced {
+				restored, err := containerplatform.New(static.PLATFORM_DOCKER, previous.GetGeneratedName(), containers.Shared.Manager.Config, previous.GetDefinition())
+
+				if err != nil {
+					logger.Log.Error("failed to rollback replica", zap.String("container", previous.GetGeneratedName()), zap.Error(err))
+					continue
+				}
+
+				containers.Update([]platforms.IContainer{restored}, true)
+			}
+		}
+
+		if len(surge) > 0 {
+			containers.Destroy(surge, true)
+		}
+	} else {
+		pauseErr := containers.pauseRollout(definition, rollout.Status{
+			Paused:    true,
+			SpecHash:  r.SpecHash,
+			Error:     err.Error(),
+			Timestamp: time.Now(),
+		})
+
+		if pauseErr != nil {
+			logger.Log.Error("failed to pause rollout", zap.String("group", r.Group), zap.String("name", r.Name), zap.Error(pauseErr))
+		}
+	}
+
+	data, _ := json.Marshal(map[string]string{
+		"hash":   r.SpecHash,
+		"action": r.OnFailure,
+		"error":  err.Error(),
+	})
+
+	events.Dispatch(
+		events.NewKindEvent(events.EVENT_ROLLOUT_FAILED, definition, data),
+		containers.Shared, containers.Shared.Manager.Cluster.Node.NodeID,
+	)
+}
+
+// rollback proposes the last revision with different template so rollback is replicated and stored as the
+// definition, every node then rolls out the previous template on its own
+func (containers *Containers) rollback(r *rollout.Rollout, definition *v1.ContainersDefinition) error {
+	current, err := containers.definition(r.Group, r.Name)
+
+	if err != nil {
+		return err
+	}
+
+	// Other node already proposed rollback or newer definition was applied meanwhile
+	if rollout.SpecHash(current) != r.SpecHash {
+		return nil
+	}
+
+	format := f.New(definition.GetPrefix(), static.CATEGORY_REVISION, static.KIND_CONTAINERS, r.Group, r.Name)
+	obj := objects.New(containers.Shared.Client.Clients[containers.Shared.User.Username], containers.Shared.User)
+
+	err = obj.Find(format)
+
+	if err != nil {
+		return err
+	}
+
+	if !obj.Exists() {
+		return errors.New(fmt.Sprintf("no revision history for %s/%s", r.Group, r.Name))
+	}
+
+	history := make([]common.Revision, 0)
+
+	err = json.Unmarshal(obj.GetDefinitionByte(), &history)
+
+	if err != nil {
+		return err
+	}
+
+	request, err := previousRevision(history, r.SpecHash)
+
+	if err != nil {
+		return err
+	}
+
+	request.Definition.SetState(nil)
+
+	client := containers.Shared.Client.Clients[containers.Shared.User.Username]
+
+	logger.Log.Info("rollout rolling back", zap.String("group", r.Group), zap.String("name", r.Name))
+
+	return request.ProposeApply(client.Http, client.API)
+}
+
+// previousRevision returns the newest revision whose template differs from the failed one
+func previousRevision(history []common.Revision, hash string) (*common.Request, error) {
+	for i := len(history) - 1; i >= 0; i-- {
+		request, err := common.NewRequestFromJson(static.KIND_CONTAINERS, history[i].Definition)
+
+		if err != nil {
+			return nil, err
+		}
+
+		if rollout.SpecHash(request.Definition.Definition.(*v1.ContainersDefinition)) != hash {
+			return request, nil
+		}
+	}
+
+	return nil, errors.New(fmt.Sprintf("no revision before %s in history", hash))
+}
+
+// Resume clears paused rollout and continues replacing replicas still running the old template
+func (containers *Containers) Resume(group string, name string) error {
+	definition, err := containers.definition(group, name)
+
+	if err != nil {
+		return err
+	}
+
+	// Every node receives resume event and the first one clears the status, others still continue local rollout
+	if containers.IsPaused(definition) {
+		err = containers.pauseRollout(definition, rollout.Status{})
+
+		if err != nil {
+			return err
+		}
+	}
+
+	if containers.isRolling(group, name) {
+		return nil
+	}
+
+	containers.desired(definition)
+
+	r, err := rollout.New(definition)
+
+	if err != nil {
+		return err
+	}
+
+	_, update, _, err := GenerateContainers(containers.Shared, definition, nil)
+
+	if err != nil {
+		return err
+	}
+
+	surge, err := GenerateSurgeContainers(containers.Shared, definition, r.MaxSurge)
+
+	if err != nil {
+		return err
+	}
+
+	logger.Log.Info("rollout resumed", zap.String("group", group), zap.String("name", name))
+
+	if len(update) > 0 || len(surge) > 0 {
+		go containers.Rollout(r, definition, update, surge, containers.Shared.User)
+	}
+
+	return nil
+}
+
+// IsPaused returns true if the last rollout of the definition failed and waits for resume
+func (containers *Containers) IsPaused(definition *v1.ContainersDefinition) bool {
+	status := containers.rolloutStatus(definition)
+	return status != nil && status.Paused
+}
+
+// pauseRollout stores the status cluster wide, empty status removes it
+func (containers *Containers) pauseRollout(definition *v1.ContainersDefinition, status rollout.Status) error {
+	if !status.Paused {
+		return containers.proposeState(rollout.STATE_ROLLOUT, definition, nil, false)
+	}
+
+	return containers.proposeState(rollout.STATE_ROLLOUT, definition, status, false)
+}
+
+func (containers *Containers) rolloutStatus(definition *v1.ContainersDefinition) *rollout.Status {
+	status := &rollout.Status{}
+
+	if !containers.findState(rollout.STATE_ROLLOUT, definition, status) {
+		return nil
+	}
+
+	return status
+}
+
+// startRollout cancels rollout of the same definition that is still in progress
+func (containers *Containers) startRollout(r *rollout.Rollout) {
+	containers.RolloutsLock.Lock()
+	defer containers.RolloutsLock.Unlock()
+
+	identifier := common.GroupIdentifier(r.Group, r.Name)
+
+	if existing, ok := containers.Rollouts[identifier]; ok {
+		existing.Cancel()
+	}
+
+	r.Ctx, r.Cancel = context.WithCancel(context.Background())
+	containers.Rollouts[identifier] = r
+}
+
+func (containers *Containers) isRolling(group string, name string) bool {
+	containers.RolloutsLock.Lock()
+	defer containers.RolloutsLock.Unlock()
+
+	_, ok := containers.Rollouts[common.GroupIdentifier(group, name)]
+	return ok
+}
+
+func (containers *Containers) stopRollout(r *rollout.Rollout) {
+	containers.RolloutsLock.Lock()
+	defer containers.RolloutsLock.Unlock()
+
+	identifier := common.GroupIdentifier(r.Group, r.Name)
+
+	if existing, ok := containers.Rollouts[identifier]; ok && existing == r {
+		delete(containers.Rollouts, identifier)
+	}
+
+	r.Cancel()
+}
+
+// replicas collects state of every replica across the cluster from the replicated registry
+func (containers *Containers) replicas(definition *v1.ContainersDefinition) []rollout.Replica {
+	replicas := make([]rollout.Replica, 0)
+
+	for _, containerObj := range containers.Shared.Registry.FindGroup(definition.GetPrefix(), definition.Meta.Group) {
+		if containerObj.GetName() != definition.Meta.Name {
+			continue
+		}
+
+		index, err := containerObj.GetIndex()
+
+		if err != nil {
+			continue
+		}
+
+		replicas = append(replicas, toReplica(containerObj, index))
+	}
+
+	return replicas
+}
+
+func toReplica(containerObj platforms.IContainer, index uint64) rollout.Replica {
+	s := containerObj.GetStatus()
+
+	return rollout.Replica{
+		Index:           index,
+		SpecHash:        containerObj.GetSpecHash(),
+		State:           s.GetState(),
+		ReadinessFailed: !s.LastReadiness && !s.LastReadinessTimestamp.IsZero(),
+	}
+}
@@ -0,0 +1,33 @@
+package containers
+
+import (
+	v1 "github.com/simplecontainer/smr/pkg/definitions/v1"
+	"github.com/simplecontainer/smr/pkg/kinds/common"
+	"github.com/simplecontainer/smr/pkg/kinds/containers/platforms/rollout"
+	"github.com/stretchr/testify/assert"
+	"testing"
+	"time"
+)
+
+func TestPreviousRevision(t *testing.T) {
+	stable := []byte(`{"kind":"containers","prefix":"simplecontainer.io/v1","meta":{"group":"example","name":"nginx"},"spec":{"image":"nginx","tag":"1.25","replicas":1}}`)
+	scaled := []byte(`{"kind":"containers","prefix":"simplecontainer.io/v1","meta":{"group":"example","name":"nginx"},"spec":{"image":"nginx","tag":"1.25","replicas":3}}`)
+	broken := []byte(`{"kind":"containers","prefix":"simplecontainer.io/v1","meta":{"group":"example","name":"nginx"},"spec":{"image":"nginx","tag":"broken","replicas":3}}`)
+
+	history := make([]common.Revision, 0)
+
+	for _, definition := range [][]byte{stable, scaled, broken} {
+		history = common.AppendRevision(history, definition, time.Now())
+	}
+
+	failed, err := previousRevision(history[2:], "")
+	assert.NoError(t, err)
+
+	request, err := previousRevision(history, rollout.SpecHash(failed.Definition.Definition.(*v1.ContainersDefinition)))
+	assert.NoError(t, err)
+	assert.Equal(t, "1.25", request.Definition.Definition.(*v1.ContainersDefinition).Spec.Tag)
+	assert.Equal(t, uint64(3), request.Definition.Definition.(*v1.ContainersDefinition).Spec.Replicas)
+
+	_, err = previousRevision(history[2:], rollout.SpecHash(failed.Definition.Definition.(*v1.ContainersDefinition)))
+	assert.Error(t, err)
+}
@@ -0,0 +1,47 @@
+package containers
+
+import (
+	"encoding/json"
+	v1 "github.com/simplecontainer/smr/pkg/definitions/v1"
+	"github.com/simplecontainer/smr/pkg/f"
+	"github.com/simplecontainer/smr/pkg/objects"
+	"github.com/simplecontainer/smr/pkg/static"
+)
+
+// findState reads status kept for the definition next to replica states, false if there is none
+func (containers *Containers) findState(kind string, definition *v1.ContainersDefinition, status any) bool {
+	format := f.New(definition.GetPrefix(), static.CATEGORY_STATE, kind, definition.Meta.Group, definition.Meta.Name)
+	obj := objects.New(containers.Shared.Client.Clients[containers.Shared.User.Username], containers.Shared.User)
+
+	err := obj.Find(format)
+
+	if err != nil || !obj.Exists() {
+		return false
+	}
+
+	return json.Unmarshal(obj.GetDefinitionByte(), status) == nil
+}
+
+// proposeState replicates status of the definition to every node, nil status removes it - wait blocks until it is
+// stored locally so it must not be used from the replication listener
+func (containers *Containers) proposeState(kind string, definition *v1.ContainersDefinition, status any, wait bool) error {
+	format := f.New(definition.GetPrefix(), static.CATEGORY_STATE, kind, definition.Meta.Group, definition.Meta.Name)
+	obj := objects.New(containers.Shared.Client.Clients[containers.Shared.User.Username], containers.Shared.User)
+
+	var bytes []byte
+
+	if status != nil {
+		var err error
+		bytes, err = json.Marshal(status)
+
+		if err != nil {
+			return err
+		}
+	}
+
+	if wait {
+		return obj.Wait(format, bytes)
+	}
+
+	return obj.Propose(format, bytes)
+}
@@ -1,13 +1,17 @@
 package containers
 
 import (
+	"github.com/simplecontainer/smr/pkg/kinds/containers/platforms/rollout"
 	"github.com/simplecontainer/smr/pkg/kinds/containers/shared"
 	"github.com/simplecontainer/smr/pkg/static"
+	"sync"
 )
 
 type Containers struct {
-	Started bool
-	Shared  *shared.Shared
+	Started      bool
+	Shared       *shared.Shared
+	Rollouts     map[string]*rollout.Rollout
+	RolloutsLock sync.Mutex
 }
 
 const KIND string = static.KIND_CONTAINERS
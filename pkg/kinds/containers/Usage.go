@@ -0,0 +1,113 @@
+package containers
+
+import (
+	"fmt"
+	"github.com/simplecontainer/smr/pkg/kinds/containers/platforms/autoscale"
+	"github.com/simplecontainer/smr/pkg/kinds/containers/platforms/types"
+	"github.com/simplecontainer/smr/pkg/kinds/containers/status"
+	"github.com/simplecontainer/smr/pkg/metrics"
+	"go.uber.org/zap"
+	"time"
+)
+
+// Monitor samples usage of local replicas on every node for metrics and autoscaling, only the leader scales
+func (containers *Containers) Monitor() {
+	ticker := time.NewTicker(autoscale.SAMPLE_INTERVAL)
+	defer ticker.Stop()
+
+	exported := make(map[string][]string)
+
+	for range ticker.C {
+		exported = containers.sample(exported)
+
+		cluster := containers.Shared.Manager.Cluster
+
+		if cluster == nil || cluster.RaftNode == nil || !cluster.RaftNode.IsLeader.Load() {
+			continue
+		}
+
+		for _, definition := range containers.definitions() {
+			if definition.Spec.Autoscale != nil {
+				containers.scale(definition)
+			}
+		}
+	}
+}
+
+// sample exports usage of running local replicas as metrics and stores it in the replicated registry for autoscaling
+func (containers *Containers) sample(previous map[string][]string) map[string][]string {
+	exported := make(map[string][]string)
+
+	for _, containerW := range containers.Shared.Watchers.GetSnapshot() {
+		if containerW == nil || containerW.IsDone() {
+			continue
+		}
+
+		containerObj := containerW.Container
+
+		if containerObj.GetStatus().GetState() != status.RUNNING {
+			continue
+		}
+
+		usage, err := containerObj.GetUsage()
+
+		if err != nil {
+			containerW.Logger.Debug("failed to sample usage", zap.Error(err))
+			continue
+		}
+
+		index, _ := containerObj.GetIndex()
+		labels := []string{containerObj.GetGroup(), containerObj.GetName(), fmt.Sprintf("%d", index), containers.Shared.Manager.Config.NodeName}
+
+		exportUsage(labels, usage)
+		exported[containerObj.GetGroupIdentifier()] = labels
+
+		definition := containerObj.GetGlobalDefinition()
+
+		if definition == nil || definition.Spec.Autoscale == nil {
+			continue
+		}
+
+		// Replicating every sample would cost a raft proposal per replica each interval
+		if !autoscale.Changed(containerObj.GetRuntime().Usage, usage) {
+			continue
+		}
+
+		containerObj.GetRuntime().Usage = usage
+
+		err = containers.Shared.Registry.Sync(containerObj.GetGroup(), containerObj.GetGeneratedName())
+
+		if err != nil {
+			containerW.Logger.Error("failed to sync usage", zap.Error(err))
+		}
+	}
+
+	// Replicas that stopped or moved away shouldn't report stale values
+	for identifier, labels := range previous {
+		if _, ok := exported[identifier]; !ok {
+			unexportUsage(labels)
+		}
+	}
+
+	return exported
+}
+
+func exportUsage(labels []string, usage *types.Usage) {
+	metrics.ContainerCPU.Set(usage.CPU, labels...)
+	metrics.ContainerMemoryUsage.Set(float64(usage.MemoryUsage), labels...)
+	metrics.ContainerMemoryLimit.Set(float64(usage.MemoryLimit), labels...)
+	metrics.ContainerNetworkRx.Set(float64(usage.NetworkRx), labels...)
+	metrics.ContainerNetworkTx.Set(float64(usage.NetworkTx), labels...)
+	metrics.ContainerBlockRead.Set(float64(usage.BlockRead), labels...)
+	metrics.ContainerBlockWrite.Set(float64(usage.BlockWrite), labels...)
+}
+
+func unexportUsage(labels []string) {
+	metrics.ContainerCPU.Delete(labels...)
+	metrics.ContainerMemoryUsage.Delete(labels...)
+	metrics.ContainerMemoryLimit.Delete(labels...)
+	metrics.ContainerNetworkRx.Delete(labels...)
+	metrics.ContainerNetworkTx.Delete(labels...)
+	metrics.ContainerBlockRead.Delete(labels...)
+	metrics.ContainerBlockWrite.Delete(labels...)
+}
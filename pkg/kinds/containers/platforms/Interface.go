@@ -10,6 +10,7 @@ import (
 	v1 "github.com/simplecontainer/smr/pkg/definitions/v1"
 	"github.com/simplecontainer/smr/pkg/dns"
 	"github.com/simplecontainer/smr/pkg/kinds/containers/platforms/image"
+	"github.com/simplecontainer/smr/pkg/kinds/containers/platforms/liveness"
 	"github.com/simplecontainer/smr/pkg/kinds/containers/platforms/readiness"
 	"github.com/simplecontainer/smr/pkg/kinds/containers/platforms/state"
 	"github.com/simplecontainer/smr/pkg/kinds/containers/platforms/types"
@@ -25,15 +26,18 @@ type IContainer interface {
 	PostRun(config *configuration.Configuration, dnsCache *dns.Records) error
 	InitContainer(definition *v1.ContainersInternal, config *configuration.Configuration, client *clients.Http, user *authentication.User) error
 	MountResources() error
+	RefreshSecrets(client *clients.Http, user *authentication.User) error
 
 	UpdateDns(dnsCache *dns.Records) error
 	RemoveDns(dnsCache *dns.Records, networkId string) error
 	SyncNetwork() error
 
 	HasDependencyOn(string, string, string) bool
+	HasSecret(string, string) bool
 	HasOwner() bool
 
 	GetReadiness() []*readiness.Readiness
+	GetLiveness() []*liveness.Liveness
 	GetState() (*state.State, error)
 	GetEngineState() string
 	GetRuntime() *types.Runtime
@@ -43,6 +47,7 @@ type IContainer interface {
 	GetId() string
 	GetGlobalDefinition() *v1.ContainersDefinition
 	GetDefinition() idefinitions.IDefinition
+	GetSpecHash() string
 	GetLabels() map[string]string
 	GetGeneratedName() string
 	GetName() string
@@ -56,6 +61,7 @@ type IContainer interface {
 	GetHeadlessDomain(network string) string
 	GetInit() IPlatform
 	GetInitDefinition() *v1.ContainersInternal
+	GetUsage() (*types.Usage, error)
 
 	IsGhost() bool
 	SetGhost(bool)
@@ -85,12 +91,14 @@ type IPlatform interface {
 	PostRun(config *configuration.Configuration, dnsCache *dns.Records) error
 	InitContainer(definition *v1.ContainersInternal, config *configuration.Configuration, client *clients.Http, user *authentication.User, runtime *types.Runtime) error
 	MountResources() error
+	RefreshSecrets(client *clients.Http, user *authentication.User) error
 
 	UpdateDns(dnsCache *dns.Records) error
 	RemoveDns(dnsCache *dns.Records, networkId string) error
 	SyncNetwork() error
 
 	GetReadiness() []*readiness.Readiness
+	GetLiveness() []*liveness.Liveness
 
 	GetState() (*state.State, error)
 	GetEngineState() string
@@ -108,6 +116,7 @@ type IPlatform interface {
 	GetHeadlessDomain(networkName string) string
 	GetInit() IPlatform
 	GetInitDefinition() *v1.ContainersInternal
+	GetUsage() (*types.Usage, error)
 
 	CreateVolume(definition *v1.VolumeDefinition) error
 	DeleteVolume(id string, force bool) error
@@ -135,7 +144,9 @@ type Registry interface {
 	Remove(prefix string, group string, name string) error
 	FindLocal(group string, name string) IContainer
 	Find(prefix string, group string, name string) IContainer
+	FindRemote(prefix string, group string, name string) IContainer
 	FindGroup(prefix string, group string) []IContainer
+	FindAll(prefix string) []IContainer
 	Name(client *clients.Http, prefix string, group string, name string) (string, []uint64, error)
 	NameReplica(group string, name string, index uint64) string
 	BackOff(group string, name string) error
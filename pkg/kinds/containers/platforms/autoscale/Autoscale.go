@@ -0,0 +1,133 @@
+package autoscale
+
+import (
+	"errors"
+	v1 "github.com/simplecontainer/smr/pkg/definitions/v1"
+	"github.com/simplecontainer/smr/pkg/kinds/containers/platforms/types"
+	"math"
+	"time"
+)
+
+var (
+	ERROR_INVALID_RANGE  = errors.New("autoscale minReplicas must be at least 1 and not greater than maxReplicas")
+	ERROR_MISSING_TARGET = errors.New("autoscale needs targetCPU or targetMemory")
+)
+
+// New returns nil if definition has no autoscale block
+func New(definition *v1.ContainersDefinition) (*Autoscale, error) {
+	spec := definition.Spec.Autoscale
+
+	if spec == nil {
+		return nil, nil
+	}
+
+	a := &Autoscale{
+		Group:        definition.Meta.Group,
+		Name:         definition.Meta.Name,
+		MinReplicas:  spec.MinReplicas,
+		MaxReplicas:  spec.MaxReplicas,
+		TargetCPU:    float64(spec.TargetCPU),
+		TargetMemory: float64(spec.TargetMemory),
+		Cooldown:     DEFAULT_COOLDOWN,
+	}
+
+	if a.MinReplicas == 0 {
+		a.MinReplicas = 1
+	}
+
+	if a.MaxReplicas < a.MinReplicas {
+		return nil, ERROR_INVALID_RANGE
+	}
+
+	if a.TargetCPU == 0 && a.TargetMemory == 0 {
+		return nil, ERROR_MISSING_TARGET
+	}
+
+	if spec.Cooldown != "" {
+		cooldown, err := time.ParseDuration(spec.Cooldown)
+
+		if err != nil {
+			return nil, err
+		}
+
+		a.Cooldown = cooldown
+	}
+
+	return a, nil
+}
+
+// Desired calculates replica count so that average utilisation lands on the target, bounded by min and max
+func (a *Autoscale) Desired(current uint64, samples []*types.Usage) Decision {
+	decision := Decision{
+		From: current,
+		To:   current,
+	}
+
+	if len(samples) > 0 && current > 0 {
+		for _, sample := range samples {
+			decision.CPU += sample.CPU
+			decision.Memory += sample.Memory
+		}
+
+		decision.CPU /= float64(len(samples))
+		decision.Memory /= float64(len(samples))
+
+		ratio := 0.0
+
+		if a.TargetCPU > 0 {
+			ratio = math.Max(ratio, decision.CPU/a.TargetCPU)
+		}
+
+		if a.TargetMemory > 0 {
+			ratio = math.Max(ratio, decision.Memory/a.TargetMemory)
+		}
+
+		if math.Abs(ratio-1) > TOLERANCE {
+			decision.To = uint64(math.Ceil(float64(current) * ratio))
+		}
+	}
+
+	decision.To = min(max(decision.To, a.MinReplicas), a.MaxReplicas)
+
+	return decision
+}
+
+// Allowed reports whether cooldown since the last scaling decision has passed
+func (a *Autoscale) Allowed(last time.Time, now time.Time) bool {
+	return last.IsZero() || now.Sub(last) >= a.Cooldown
+}
+
+// Replicas returns replica count the definition should run, decision of the autoscaler within the current bounds
+// takes precedence over replicas from the definition
+func (a *Autoscale) Replicas(definition *v1.ContainersDefinition, status *Status) uint64 {
+	if status == nil || status.Replicas == 0 {
+		return definition.Spec.Replicas
+	}
+
+	return min(max(status.Replicas, a.MinReplicas), a.MaxReplicas)
+}
+
+// Changed reports whether the sample should be replicated, usage is shared cluster wide only when it moved or
+// before the last replicated sample goes stale
+func Changed(previous *types.Usage, current *types.Usage) bool {
+	if previous == nil {
+		return true
+	}
+
+	return current.SampledAt.Sub(previous.SampledAt) >= SYNC_INTERVAL ||
+		math.Abs(current.CPU-previous.CPU) >= USAGE_DELTA ||
+		math.Abs(current.Memory-previous.Memory) >= USAGE_DELTA
+}
+
+// Fresh filters out samples too old to describe current load
+func Fresh(samples []*types.Usage, now time.Time) []*types.Usage {
+	fresh := make([]*types.Usage, 0)
+
+	for _, sample := range samples {
+		if sample != nil && now.Sub(sample.SampledAt) <= SYNC_INTERVAL+2*SAMPLE_INTERVAL {
+			fresh = append(fresh, sample)
+		}
+	}
+
+	return fresh
+}
@@ -0,0 +1,121 @@
+package autoscale
+
+import (
+	"github.com/simplecontainer/smr/pkg/definitions/commonv1"
+	v1 "github.com/simplecontainer/smr/pkg/definitions/v1"
+	"github.com/simplecontainer/smr/pkg/kinds/containers/platforms/types"
+	"github.com/stretchr/testify/assert"
+	"testing"
+	"time"
+)
+
+func definition(spec *v1.ContainersAutoscale) *v1.ContainersDefinition {
+	return &v1.ContainersDefinition{
+		Meta: &commonv1.Meta{Group: "example", Name: "web"},
+		Spec: &v1.ContainersInternal{
+			Image:     "nginx",
+			Tag:       "latest",
+			Replicas:  2,
+			Autoscale: spec,
+		},
+	}
+}
+
+func TestNew(t *testing.T) {
+	a, err := New(definition(nil))
+	assert.NoError(t, err)
+	assert.Nil(t, a)
+
+	_, err = New(definition(&v1.ContainersAutoscale{MinReplicas: 3, MaxReplicas: 2, TargetCPU: 50}))
+	assert.ErrorIs(t, err, ERROR_INVALID_RANGE)
+
+	_, err = New(definition(&v1.ContainersAutoscale{MaxReplicas: 2}))
+	assert.ErrorIs(t, err, ERROR_MISSING_TARGET)
+
+	_, err = New(definition(&v1.ContainersAutoscale{MaxReplicas: 2, TargetCPU: 50, Cooldown: "later"}))
+	assert.Error(t, err)
+
+	a, err = New(definition(&v1.ContainersAutoscale{MaxReplicas: 5, TargetMemory: 70}))
+	assert.NoError(t, err)
+	assert.Equal(t, uint64(1), a.MinReplicas)
+	assert.Equal(t, DEFAULT_COOLDOWN, a.Cooldown)
+}
+
+func TestDesired(t *testing.T) {
+	a := &Autoscale{MinReplicas: 1, MaxReplicas: 6, TargetCPU: 50, TargetMemory: 80}
+
+	tests := []struct {
+		name     string
+		current  uint64
+		samples  []*types.Usage
+		expected uint64
+	}{
+		{name: "No samples keeps replicas", current: 2, samples: nil, expected: 2},
+		{name: "Within tolerance", current: 2, samples: []*types.Usage{{CPU: 52}, {CPU: 50}}, expected: 2},
+		{name: "Scale up on cpu", current: 2, samples: []*types.Usage{{CPU: 100}, {CPU: 80}}, expected: 4},
+		{name: "Scale up on memory", current: 2, samples: []*types.Usage{{CPU: 10, Memory: 120}, {CPU: 10, Memory: 120}}, expected: 3},
+		{name: "Scale down", current: 4, samples: []*types.Usage{{CPU: 10}, {CPU: 10}, {CPU: 10}, {CPU: 10}}, expected: 1},
+		{name: "Capped at max", current: 4, samples: []*types.Usage{{CPU: 400}}, expected: 6},
+		{name: "Raised to min", current: 0, samples: nil, expected: 1},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			decision := a.Desired(tt.current, tt.samples)
+
+			assert.Equal(t, tt.current, decision.From)
+			assert.Equal(t, tt.expected, decision.To)
+		})
+	}
+}
+
+func TestAllowedAndFresh(t *testing.T) {
+	a := &Autoscale{Cooldown: time.Minute}
+	now := time.Now()
+
+	assert.True(t, a.Allowed(time.Time{}, now))
+	assert.False(t, a.Allowed(now.Add(-30*time.Second), now))
+	assert.True(t, a.Allowed(now.Add(-2*time.Minute), now))
+
+	samples := []*types.Usage{
+		{CPU: 10, SampledAt: now},
+		{CPU: 90, SampledAt: now.Add(-time.Hour)},
+		nil,
+	}
+
+	assert.Len(t, Fresh(samples, now), 1)
+}
+
+func TestChanged(t *testing.T) {
+	now := time.Now()
+	previous := &types.Usage{CPU: 40, Memory: 60, SampledAt: now}
+
+	tests := []struct {
+		name     string
+		previous *types.Usage
+		current  *types.Usage
+		expected bool
+	}{
+		{"Never replicated", nil, &types.Usage{CPU: 40, SampledAt: now}, true},
+		{"Steady usage", previous, &types.Usage{CPU: 42, Memory: 61, SampledAt: now.Add(SAMPLE_INTERVAL)}, false},
+		{"CPU moved", previous, &types.Usage{CPU: 50, Memory: 60, SampledAt: now.Add(SAMPLE_INTERVAL)}, true},
+		{"Memory moved", previous, &types.Usage{CPU: 40, Memory: 50, SampledAt: now.Add(SAMPLE_INTERVAL)}, true},
+		{"Replicated sample aging", previous, &types.Usage{CPU: 40, Memory: 60, SampledAt: now.Add(SYNC_INTERVAL)}, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.expected, Changed(tt.previous, tt.current))
+		})
+	}
+}
+
+func TestReplicas(t *testing.T) {
+	d := definition(&v1.ContainersAutoscale{MinReplicas: 2, MaxReplicas: 4, TargetCPU: 50})
+	a, err := New(d)
+	assert.NoError(t, err)
+
+	assert.Equal(t, uint64(2), a.Replicas(d, nil))
+	assert.Equal(t, uint64(3), a.Replicas(d, &Status{Replicas: 3}))
+	assert.Equal(t, uint64(4), a.Replicas(d, &Status{Replicas: 6}))
+}
@@ -0,0 +1,45 @@
+package autoscale
+
+import (
+	"time"
+)
+
+type Autoscale struct {
+	Group        string
+	Name         string
+	MinReplicas  uint64
+	MaxReplicas  uint64
+	TargetCPU    float64
+	TargetMemory float64
+	Cooldown     time.Duration
+}
+
+// Decision is recorded as an event every time replicas are scaled
+type Decision struct {
+	From   uint64
+	To     uint64
+	CPU    float64
+	Memory float64
+}
+
+// Status holds replica count chosen by the autoscaler, definition keeps replicas as applied by the user
+type Status struct {
+	Replicas uint64    `json:"replicas"`
+	Scaled   time.Time `json:"scaled"`
+	Decision Decision  `json:"decision"`
+}
+
+const DEFAULT_COOLDOWN = 3 * time.Minute
+const SAMPLE_INTERVAL = 15 * time.Second
+
+// SYNC_INTERVAL is how often unchanged usage is replicated so the leader doesn't consider it stale
+const SYNC_INTERVAL = time.Minute
+
+// USAGE_DELTA in percents is change of utilisation replicated right away
+const USAGE_DELTA = 5.0
+
+// STATE_AUTOSCALE is kind segment of the key holding autoscale status in the state category
+const STATE_AUTOSCALE = "autoscale"
+
+// TOLERANCE prevents flapping when utilisation is close to the target
+const TOLERANCE = 0.1
@@ -16,7 +16,9 @@ import (
 	"github.com/simplecontainer/smr/pkg/kinds/containers/platforms"
 	"github.com/simplecontainer/smr/pkg/kinds/containers/platforms/engines/docker"
 	"github.com/simplecontainer/smr/pkg/kinds/containers/platforms/image"
+	"github.com/simplecontainer/smr/pkg/kinds/containers/platforms/liveness"
 	"github.com/simplecontainer/smr/pkg/kinds/containers/platforms/readiness"
+	"github.com/simplecontainer/smr/pkg/kinds/containers/platforms/rollout"
 	"github.com/simplecontainer/smr/pkg/kinds/containers/platforms/state"
 	"github.com/simplecontainer/smr/pkg/kinds/containers/platforms/types"
 	"github.com/simplecontainer/smr/pkg/kinds/containers/status"
@@ -46,7 +48,8 @@ func New(platform string, name string, config *configuration.Configuration, defi
 					ObjectDependencies: make([]f.Format, 0),
 					Node:               node.NewNodeDefinition(config.KVStore.Cluster, config.KVStore.Node.NodeID),
 				},
-				Status: statusObj,
+				Status:   statusObj,
+				SpecHash: rollout.SpecHash(definition.(*v1.ContainersDefinition)),
 			},
 			Type: static.PLATFORM_DOCKER,
 		}, nil
@@ -118,6 +121,9 @@ func (c *Container) InitContainer(definition *v1.ContainersInternal, config *con
 func (c *Container) MountResources() error {
 	return c.Platform.MountResources()
 }
+func (c *Container) RefreshSecrets(client *clients.Http, user *authentication.User) error {
+	return c.Platform.RefreshSecrets(client, user)
+}
 func (c *Container) UpdateDns(cache *dns.Records) error {
 	return c.Platform.UpdateDns(cache)
 }
@@ -138,6 +144,16 @@ func (c *Container) HasDependencyOn(kind string, group string, name string) bool
 	return false
 }
 
+func (c *Container) HasSecret(group string, name string) bool {
+	for _, secret := range c.GetDefinition().(*v1.ContainersDefinition).Spec.Secrets {
+		if secret.Group == group && secret.Name == name {
+			return true
+		}
+	}
+
+	return false
+}
+
 func (c *Container) HasOwner() bool {
 	return c.GetDefinition().GetRuntime().GetOwner().IsEmpty()
 }
@@ -145,6 +161,9 @@ func (c *Container) HasOwner() bool {
 func (c *Container) GetReadiness() []*readiness.Readiness {
 	return c.Platform.GetReadiness()
 }
+func (c *Container) GetLiveness() []*liveness.Liveness {
+	return c.Platform.GetLiveness()
+}
 
 func (c *Container) GetState() (*state.State, error) {
 	return c.Platform.GetState()
@@ -174,6 +193,9 @@ func (c *Container) GetGlobalDefinition() *v1.ContainersDefinition {
 func (c *Container) GetDefinition() idefinitions.IDefinition {
 	return c.Platform.GetDefinition()
 }
+func (c *Container) GetSpecHash() string {
+	return c.General.SpecHash
+}
 func (c *Container) GetLabels() map[string]string {
 	return c.General.Labels
 }
@@ -210,6 +232,9 @@ func (c *Container) GetInit() platforms.IPlatform {
 func (c *Container) GetInitDefinition() *v1.ContainersInternal {
 	return c.Platform.GetInitDefinition()
 }
+func (c *Container) GetUsage() (*types.Usage, error) {
+	return c.Platform.GetUsage()
+}
 func (c *Container) SetGhost(ghost bool) {
 	c.ghost = ghost
 }
@@ -262,6 +287,22 @@ func (c *Container) Clean() error {
 }
 
 func (c *Container) ToJSON() ([]byte, error) {
+	if c.General == nil || c.General.Status == nil {
+		return c.toJSON()
+	}
+
+	var bytes []byte
+	var err error
+
+	// Liveness probes write status and their results while the container is serialized
+	c.General.Status.View(func() {
+		bytes, err = c.toJSON()
+	})
+
+	return bytes, err
+}
+
+func (c *Container) toJSON() ([]byte, error) {
 	var output = make(map[string]json.RawMessage)
 	var err error
 
@@ -18,9 +18,10 @@ type Container struct {
 }
 
 type General struct {
-	Labels  map[string]string
-	Runtime *types.Runtime
-	Status  *status.Status
+	Labels   map[string]string
+	Runtime  *types.Runtime
+	Status   *status.Status
+	SpecHash string
 }
 
 const SPREAD_SPECIFIC string = "specific"
@@ -5,10 +5,12 @@ import (
 	"bytes"
 	"compress/gzip"
 	v1 "github.com/simplecontainer/smr/pkg/definitions/v1"
+	"github.com/simplecontainer/smr/pkg/kinds/containers/platforms/engines/docker/internal"
 	"io"
 	"os"
 	"path/filepath"
 	"strings"
+	"time"
 )
 
 func (container *Docker) archive(buf *bytes.Buffer, hostPath, mountPoint string, permissions *v1.FileInfo) error {
@@ -68,3 +70,43 @@ func (container *Docker) archive(buf *bytes.Buffer, hostPath, mountPoint string,
 		return nil
 	})
 }
+
+func (container *Docker) archiveFiles(buf *bytes.Buffer, mountPoint string, files map[string][]byte, permissions *v1.FileInfo) error {
+	gzw := gzip.NewWriter(buf)
+	defer gzw.Close()
+
+	tw := tar.NewWriter(gzw)
+	defer tw.Close()
+
+	for path, content := range files {
+		header := &tar.Header{
+			Typeflag: tar.TypeReg,
+			Name:     strings.ReplaceAll(filepath.Join(mountPoint, path), "\\", "/"),
+			Size:     int64(len(content)),
+			Mode:     internal.DEFAULT_SECRET_MODE,
+			ModTime:  time.Now(),
+		}
+
+		if permissions != nil {
+			if permissions.Owner != nil {
+				header.Uid = *permissions.Owner
+			}
+			if permissions.Group != nil {
+				header.Gid = *permissions.Group
+			}
+			if permissions.Permissions != nil {
+				header.Mode = int64(*permissions.Permissions)
+			}
+		}
+
+		if err := tw.WriteHeader(header); err != nil {
+			return err
+		}
+
+		if _, err := tw.Write(content); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
@@ -24,6 +24,7 @@ import (
 	"github.com/simplecontainer/smr/pkg/logger"
 	"github.com/simplecontainer/smr/pkg/smaps"
 	"github.com/simplecontainer/smr/pkg/static"
+	"go.uber.org/zap"
 	"io"
 	"net"
 	"strconv"
@@ -55,6 +56,20 @@ func New(name string, definition idefinitions.IDefinition) (*Docker, error) {
 		definition.(*v1.ContainersDefinition).Spec.Tag = "latest"
 	}
 
+	var livenesses *internal.Livenesses
+	livenesses, err = internal.NewLivenesses(definition.(*v1.ContainersDefinition).Spec.Liveness)
+
+	if err != nil {
+		return nil, err
+	}
+
+	var limits *internal.Limits
+	limits, err = internal.NewLimits(definition.(*v1.ContainersDefinition).Spec.Limits, definition.(*v1.ContainersDefinition).Spec.Requests)
+
+	if err != nil {
+		return nil, err
+	}
+
 	var readinesses *internal.Readinesses
 	readinesses, err = internal.NewReadinesses(definition.(*v1.ContainersDefinition).Spec.Readiness)
 
@@ -81,7 +96,10 @@ func New(name string, definition idefinitions.IDefinition) (*Docker, error) {
 		Networks:       internal.NewNetworks(definition.(*v1.ContainersDefinition).Spec.Networks),
 		Ports:          internal.NewPorts(definition.(*v1.ContainersDefinition).Spec.Ports),
 		Readiness:      readinesses,
+		Liveness:       livenesses,
 		Resources:      internal.NewResources(definition.(*v1.ContainersDefinition).Spec.Resources),
+		Secrets:        internal.NewSecrets(definition.(*v1.ContainersDefinition).Spec.Secrets),
+		Limits:         limits,
 		Configurations: internal.NewConfigurations(definition.(*v1.ContainersDefinition).Spec.Configurations),
 		Volumes:        volumes,
 		VolumeInternal: TDVolume.Volume{},
@@ -151,7 +169,7 @@ func (container *Docker) Run() error {
 		resp, err = cli.ContainerCreate(ctx, &TDContainer.Config{
 			Hostname:     container.GeneratedName,
 			Labels:       container.Labels.ToMap(),
-			Image:        container.Image + ":" + container.Tag,
+			Image:        container.GetImageWithTag(),
 			Env:          container.Env,
 			Entrypoint:   container.Entrypoint,
 			Cmd:          container.Args,
@@ -166,6 +184,8 @@ func (container *Docker) Run() error {
 			NetworkMode:  TDContainer.NetworkMode(container.NetworkMode),
 			Privileged:   container.Privileged,
 			CapAdd:       container.Capabilities,
+			Resources:    container.Limits.ToResources(),
+			ShmSize:      container.Limits.ToShmSize(),
 		}, container.BuildNetwork(), nil, container.GeneratedName)
 
 		if err != nil {
@@ -181,6 +201,12 @@ func (container *Docker) Run() error {
 			return err
 		}
 
+		err = container.MountSecrets()
+
+		if err != nil {
+			return err
+		}
+
 		if err = cli.ContainerStart(ctx, resp.ID, TDContainer.StartOptions{}); err != nil {
 			return err
 		}
@@ -233,6 +259,12 @@ func (container *Docker) PreRun(config *configuration.Configuration, client *cli
 		return err
 	}
 
+	err = container.PrepareSecrets(config, client, user)
+
+	if err != nil {
+		return err
+	}
+
 	err = container.PrepareLabels(runtime)
 
 	if err != nil {
@@ -251,6 +283,12 @@ func (container *Docker) PreRun(config *configuration.Configuration, client *cli
 		return err
 	}
 
+	err = container.PrepareLiveness(runtime)
+
+	if err != nil {
+		return err
+	}
+
 	err = container.PrepareAuth(runtime)
 
 	if err != nil {
@@ -428,6 +466,12 @@ func (container *Docker) Delete() error {
 		}
 	}(cli)
 
+	err = container.scrubSecrets()
+
+	if err != nil && !errdefs.IsNotFound(err) {
+		logger.Log.Error("failed to scrub secrets of the container", zap.String("container", container.GetGeneratedName()), zap.Error(err))
+	}
+
 	// Resource cleanup after delete is not that fast - hence rename before delete
 	container.Rename(fmt.Sprintf("%s-delete-%d", container.GetGeneratedName(), time.Now().UnixMicro()))
 
@@ -772,7 +816,7 @@ func (container *Docker) Usage() (*TDContainer.StatsResponse, error) {
 			return nil, fmt.Errorf("failed to read stats data: %w", err)
 		}
 
-		var stats *TDContainer.StatsResponse
+		stats := &TDContainer.StatsResponse{}
 		if err := json.Unmarshal(statsData, stats); err != nil {
 			return nil, fmt.Errorf("failed to unmarshal stats JSON: %w", err)
 		}
@@ -783,6 +827,17 @@ func (container *Docker) Usage() (*TDContainer.StatsResponse, error) {
 	}
 }
 
+// GetUsage converts engine stats the same way docker stats does
+func (container *Docker) GetUsage() (*types.Usage, error) {
+	stats, err := container.Usage()
+
+	if err != nil {
+		return nil, err
+	}
+
+	return internal.ToUsage(stats, time.Now()), nil
+}
+
 func (container *Docker) ToJSON() ([]byte, error) {
 	return json.Marshal(container)
 }
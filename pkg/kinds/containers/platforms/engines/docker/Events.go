@@ -104,6 +104,7 @@ func NewEvent(event DTEvents.Message) platform.Event {
 			Name:        name,
 			Managed:     managed,
 			Type:        types.EVENT_DIE,
+			Data:        []byte(event.Actor.Attributes["exitCode"]),
 		}
 	default:
 		return platform.Event{
@@ -8,6 +8,7 @@ import (
 	"github.com/simplecontainer/smr/pkg/kinds/containers/platforms"
 	"github.com/simplecontainer/smr/pkg/kinds/containers/platforms/engines/docker/internal"
 	"github.com/simplecontainer/smr/pkg/kinds/containers/platforms/image"
+	"github.com/simplecontainer/smr/pkg/kinds/containers/platforms/liveness"
 	"github.com/simplecontainer/smr/pkg/kinds/containers/platforms/readiness"
 	"github.com/simplecontainer/smr/pkg/kinds/containers/platforms/state"
 	"github.com/simplecontainer/smr/pkg/static"
@@ -30,6 +31,14 @@ func (container *Docker) GetReadiness() []*readiness.Readiness {
 	return container.Readiness.Readinesses
 }
 
+func (container *Docker) GetLiveness() []*liveness.Liveness {
+	if container.Liveness == nil {
+		return nil
+	}
+
+	return container.Liveness.Livenesses
+}
+
 func (container *Docker) GetState() (*state.State, error) {
 	dockerContainer, err := internal.Get(container.GetGeneratedName())
 	if err != nil {
@@ -112,7 +121,7 @@ func (container *Docker) GetImageState() *image.ImageState {
 }
 
 func (container *Docker) GetImageWithTag() string {
-	return fmt.Sprintf("%s:%s", container.Image, container.Tag)
+	return image.Reference(container.Image, container.Tag)
 }
 
 func (container *Docker) GetDomain(network string) string {
@@ -39,7 +39,7 @@ func (container *Docker) PullImage(ctx context.Context, cli *client.Client) erro
 			}
 		}
 
-		reader, err := cli.ImagePull(ctx, container.Image+":"+container.Tag, pullOpts)
+		reader, err := cli.ImagePull(ctx, container.GetImageWithTag(), pullOpts)
 		if err != nil {
 			container.ImageState.SetStatus(image.StatusFailed)
 			return err
@@ -87,10 +87,22 @@ func (container *Docker) CheckIfImagePresent(ctx context.Context, cli *client.Cl
 		return err
 	}
 
-	searchingFor := fmt.Sprintf("%s:%s", container.Image, container.Tag)
+	searchingFor := container.GetImageWithTag()
+	pinned := strings.Contains(searchingFor, "@")
 
-	for _, image := range images {
-		for _, tag := range image.RepoTags {
+	if pinned {
+		searchingFor = fmt.Sprintf("%s@%s", container.Image, container.Tag[strings.LastIndex(container.Tag, "@")+1:])
+	}
+
+	for _, img := range images {
+		// Images pulled by digest are listed by repo digest only
+		references := img.RepoTags
+
+		if pinned {
+			references = img.RepoDigests
+		}
+
+		for _, tag := range references {
 			registryTo, imageTo := splitReposSearchTerm(tag)
 			registryFrom, imageFrom := splitReposSearchTerm(searchingFor)
 
@@ -253,3 +253,39 @@ func (container *Docker) PrepareReadiness(runtime *types.Runtime) error {
 
 	return nil
 }
+
+func (container *Docker) PrepareLiveness(runtime *types.Runtime) error {
+	var err error
+
+	for _, l := range container.Liveness.Livenesses {
+		for index, val := range l.Body {
+			container.Lock.Lock()
+			l.BodyUnpack[index], _, err = template.Parse(index, val, nil, nil, runtime.Configuration, 0)
+			container.Lock.Unlock()
+
+			if err != nil {
+				return err
+			}
+		}
+
+		l.URL, _, err = template.Parse("liveness-url", l.URL, nil, nil, runtime.Configuration, 0)
+
+		if err != nil {
+			return err
+		}
+
+		l.CommandUnpacked = make([]string, len(l.Command))
+
+		for index, val := range l.Command {
+			container.Lock.Lock()
+			l.CommandUnpacked[index], _, err = template.Parse("liveness-command", val, nil, nil, runtime.Configuration, 0)
+			container.Lock.Unlock()
+
+			if err != nil {
+				return err
+			}
+		}
+	}
+
+	return nil
+}
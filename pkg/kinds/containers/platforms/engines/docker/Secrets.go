@@ -0,0 +1,212 @@
+package docker
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	TDContainer "github.com/docker/docker/api/types/container"
+	IDClient "github.com/docker/docker/client"
+	"github.com/simplecontainer/smr/pkg/authentication"
+	"github.com/simplecontainer/smr/pkg/clients"
+	"github.com/simplecontainer/smr/pkg/configuration"
+	v1 "github.com/simplecontainer/smr/pkg/definitions/v1"
+	"github.com/simplecontainer/smr/pkg/f"
+	"github.com/simplecontainer/smr/pkg/kinds/containers/platforms/engines/docker/internal"
+	"github.com/simplecontainer/smr/pkg/objects"
+	"github.com/simplecontainer/smr/pkg/secrets"
+	"github.com/simplecontainer/smr/pkg/static"
+	"path/filepath"
+	"strconv"
+	"time"
+)
+
+// PrepareSecrets resolves secret files and binds tmpfs directory of the host for each secret mount
+func (container *Docker) PrepareSecrets(config *configuration.Configuration, client *clients.Http, user *authentication.User) error {
+	container.Volumes.RemoveSecrets()
+
+	mounts := configuration.DEFAULT_SECRETS_MOUNTS
+
+	if config.Secrets != nil && config.Secrets.Mounts != "" {
+		mounts = config.Secrets.Mounts
+	}
+
+	container.Secrets.Lock.Lock()
+	defer container.Secrets.Lock.Unlock()
+
+	for k, secret := range container.Secrets.Secrets {
+		files, err := container.fetchSecret(client, user, secret)
+
+		if err != nil {
+			return err
+		}
+
+		container.Secrets.Secrets[k].Files = files
+
+		err = container.Volumes.Add(container.GetGeneratedName(), v1.ContainersVolume{
+			Type:       internal.VOLUME_SECRETS,
+			HostPath:   filepath.Join(mounts, config.NodeName, container.GetGeneratedName(), strconv.Itoa(k)),
+			MountPoint: secret.Reference.MountPoint,
+		})
+
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// MountSecrets writes secret files into the bound directories, it works on created and running containers
+func (container *Docker) MountSecrets() error {
+	container.Secrets.Lock.RLock()
+	defer container.Secrets.Lock.RUnlock()
+
+	for _, secret := range container.Secrets.Secrets {
+		err := container.copySecret(secret, secret.Files)
+
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// RefreshSecrets rewrites secret files of the running container so it doesn't need to be recreated
+func (container *Docker) RefreshSecrets(client *clients.Http, user *authentication.User) error {
+	if container.DockerID == "" {
+		return errors.New("container is not created")
+	}
+
+	container.Secrets.Lock.Lock()
+	defer container.Secrets.Lock.Unlock()
+
+	for _, secret := range container.Secrets.Secrets {
+		files, err := container.fetchSecret(client, user, secret)
+
+		if err != nil {
+			return err
+		}
+
+		written := make(map[string][]byte, len(files))
+
+		for path, content := range files {
+			written[path] = content
+		}
+
+		// Keys removed from the secret are truncated since files can't be deleted through the daemon
+		for _, path := range secret.Removed(files) {
+			written[path] = []byte{}
+		}
+
+		err = container.copySecret(secret, written)
+
+		if err != nil {
+			return err
+		}
+
+		secret.Files = files
+	}
+
+	return nil
+}
+
+// scrubSecrets truncates secret files before the container is removed since host directory outlives the container
+func (container *Docker) scrubSecrets() error {
+	if container.DockerID == "" {
+		return nil
+	}
+
+	container.Secrets.Lock.RLock()
+	defer container.Secrets.Lock.RUnlock()
+
+	for _, secret := range container.Secrets.Secrets {
+		empty := make(map[string][]byte, len(secret.Files))
+
+		for path := range secret.Files {
+			empty[path] = []byte{}
+		}
+
+		err := container.copySecret(secret, empty)
+
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func (container *Docker) fetchSecret(client *clients.Http, user *authentication.User, secret *internal.Secret) (map[string][]byte, error) {
+	format := f.New(static.SMR_PREFIX, static.CATEGORY_KIND, static.KIND_SECRET, secret.Reference.Group, secret.Reference.Name)
+
+	obj := objects.New(client.Get(user.Username), user)
+	obj.Find(format)
+
+	if !obj.Exists() {
+		return nil, errors.New(fmt.Sprintf("failed to fetch secret from the kv store %s", format.ToString()))
+	}
+
+	definition := v1.SecretDefinition{}
+
+	err := json.Unmarshal(obj.GetDefinitionByte(), &definition)
+
+	if err != nil {
+		return nil, err
+	}
+
+	files, err := secret.Select(definition.Spec.Data)
+
+	if err != nil {
+		return nil, err
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	// Only selected keys are resolved so unrelated provider references don't block the mount
+	for path, content := range files {
+		value, err := secrets.Value(ctx, string(content))
+
+		if err != nil {
+			return nil, err
+		}
+
+		files[path] = []byte(value)
+	}
+
+	return files, nil
+}
+
+func (container *Docker) copySecret(secret *internal.Secret, files map[string][]byte) error {
+	if len(files) == 0 {
+		return nil
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	cli, err := IDClient.NewClientWithOpts(IDClient.FromEnv, IDClient.WithAPIVersionNegotiation())
+	if err != nil {
+		return err
+	}
+
+	defer func(cli *IDClient.Client) {
+		err = cli.Close()
+		if err != nil {
+			return
+		}
+	}(cli)
+
+	var buf bytes.Buffer
+
+	err = container.archiveFiles(&buf, secret.Reference.MountPoint, files, secret.Reference.Permissions)
+
+	if err != nil {
+		return err
+	}
+
+	return cli.CopyToContainer(ctx, container.DockerID, "/", &buf, TDContainer.CopyToContainerOptions{})
+}
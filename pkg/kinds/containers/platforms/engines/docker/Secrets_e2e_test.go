@@ -0,0 +1,94 @@
+//go:build e2e
+// +build e2e
+
+package docker
+
+import (
+	"bytes"
+	"context"
+	TDContainer "github.com/docker/docker/api/types/container"
+	"github.com/docker/docker/api/types/image"
+	TDMount "github.com/docker/docker/api/types/mount"
+	IDClient "github.com/docker/docker/client"
+	"github.com/docker/docker/pkg/stdcopy"
+	v1 "github.com/simplecontainer/smr/pkg/definitions/v1"
+	"github.com/simplecontainer/smr/pkg/kinds/containers/platforms/engines/docker/internal"
+	"github.com/stretchr/testify/assert"
+	"io"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+// TestSecretsMount runs real container that reads its secret as the first thing it does, file has to be there
+// before the process starts
+func TestSecretsMount(t *testing.T) {
+	ctx, cancel := context.WithTimeout(context.Background(), 2*time.Minute)
+	defer cancel()
+
+	cli, err := IDClient.NewClientWithOpts(IDClient.FromEnv, IDClient.WithAPIVersionNegotiation())
+	if !assert.NoError(t, err) {
+		return
+	}
+	defer cli.Close()
+
+	pull, err := cli.ImagePull(ctx, "busybox:latest", image.PullOptions{})
+	if !assert.NoError(t, err) {
+		return
+	}
+	io.Copy(io.Discard, pull)
+	pull.Close()
+
+	host := t.TempDir()
+	volume := internal.Volume{Type: internal.VOLUME_SECRETS, HostPath: host, MountPoint: "/run/secrets/db"}
+
+	resp, err := cli.ContainerCreate(ctx, &TDContainer.Config{
+		Image: "busybox:latest",
+		Cmd:   []string{"cat", "/run/secrets/db/password"},
+	}, &TDContainer.HostConfig{Mounts: []TDMount.Mount{*volume.ToMount()}}, nil, nil, "")
+	if !assert.NoError(t, err) {
+		return
+	}
+	defer cli.ContainerRemove(context.Background(), resp.ID, TDContainer.RemoveOptions{Force: true})
+
+	container := &Docker{
+		DockerID: resp.ID,
+		Secrets: &internal.Secrets{Secrets: []*internal.Secret{{
+			Reference: v1.ContainersSecret{Group: "example", Name: "db", MountPoint: "/run/secrets/db"},
+			Files:     map[string][]byte{"password": []byte("s3cr3t")},
+		}}},
+	}
+
+	assert.NoError(t, container.MountSecrets())
+	assert.NoError(t, cli.ContainerStart(ctx, resp.ID, TDContainer.StartOptions{}))
+
+	waiting, errs := cli.ContainerWait(ctx, resp.ID, TDContainer.WaitConditionNotRunning)
+
+	select {
+	case result := <-waiting:
+		assert.Equal(t, int64(0), result.StatusCode)
+	case err = <-errs:
+		assert.NoError(t, err)
+	}
+
+	logs, err := cli.ContainerLogs(ctx, resp.ID, TDContainer.LogsOptions{ShowStdout: true, ShowStderr: true})
+	if !assert.NoError(t, err) {
+		return
+	}
+	defer logs.Close()
+
+	var stdout, stderr bytes.Buffer
+	_, err = stdcopy.StdCopy(&stdout, &stderr, logs)
+
+	assert.NoError(t, err)
+	assert.Equal(t, "s3cr3t", stdout.String())
+	assert.Empty(t, stderr.String())
+
+	// Host directory outlives the container so delete leaves nothing readable behind
+	assert.NoError(t, container.scrubSecrets())
+
+	content, err := os.ReadFile(filepath.Join(host, "password"))
+	assert.NoError(t, err)
+	assert.Empty(t, content)
+}
@@ -33,7 +33,10 @@ type Docker struct {
 	Volumes        *internal.Volumes
 	VolumeInternal TDVolume.Volume `json:"-"`
 	Readiness      *internal.Readinesses
+	Liveness       *internal.Livenesses
 	Resources      *internal.Resources
+	Secrets        *internal.Secrets
+	Limits         *internal.Limits
 	Configurations *internal.Configurations
 	Capabilities   []string
 	User           string
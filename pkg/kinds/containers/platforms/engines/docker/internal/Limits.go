@@ -0,0 +1,106 @@
+package internal
+
+import (
+	TDContainer "github.com/docker/docker/api/types/container"
+	v1 "github.com/simplecontainer/smr/pkg/definitions/v1"
+	"github.com/simplecontainer/smr/pkg/kinds/containers/platforms/limits"
+)
+
+type Limits struct {
+	Limits   *v1.ContainersLimits
+	Requests *v1.ContainersRequests
+	Docker   LimitsDocker
+}
+
+type LimitsDocker struct {
+	Resources TDContainer.Resources
+	ShmSize   int64
+}
+
+func NewLimits(limitsDefinition *v1.ContainersLimits, requestsDefinition *v1.ContainersRequests) (*Limits, error) {
+	limitsObj := &Limits{
+		Limits:   limitsDefinition,
+		Requests: requestsDefinition,
+		Docker:   LimitsDocker{},
+	}
+
+	err := limitsObj.Parse()
+
+	if err != nil {
+		return nil, err
+	}
+
+	return limitsObj, nil
+}
+
+// Parse translates limits and requests into the docker host config resources
+func (l *Limits) Parse() error {
+	resources := TDContainer.Resources{}
+
+	if l.Requests != nil {
+		requested, err := limits.FromRequests(l.Requests)
+
+		if err != nil {
+			return err
+		}
+
+		resources.CPUShares = requested.CPU * limits.CPU_SHARES_PER_CPU / limits.MILLICORES_PER_CPU
+		resources.MemoryReservation = requested.Memory
+	}
+
+	if l.Limits != nil {
+		cpu, err := limits.ParseCPU(l.Limits.CPU)
+		if err != nil {
+			return err
+		}
+
+		resources.NanoCPUs = cpu * limits.NANOCPUS_PER_MILLICORE
+
+		if l.Limits.CPUShares != 0 {
+			resources.CPUShares = l.Limits.CPUShares
+		}
+
+		resources.Memory, err = limits.ParseMemory(l.Limits.Memory)
+		if err != nil {
+			return err
+		}
+
+		if l.Limits.MemorySwap == "-1" {
+			resources.MemorySwap = -1
+		} else {
+			resources.MemorySwap, err = limits.ParseMemory(l.Limits.MemorySwap)
+			if err != nil {
+				return err
+			}
+		}
+
+		if l.Limits.Pids != 0 {
+			pids := l.Limits.Pids
+			resources.PidsLimit = &pids
+		}
+
+		for _, ulimit := range l.Limits.Ulimits {
+			resources.Ulimits = append(resources.Ulimits, &TDContainer.Ulimit{
+				Name: ulimit.Name,
+				Soft: ulimit.Soft,
+				Hard: ulimit.Hard,
+			})
+		}
+
+		l.Docker.ShmSize, err = limits.ParseMemory(l.Limits.ShmSize)
+		if err != nil {
+			return err
+		}
+	}
+
+	l.Docker.Resources = resources
+	return nil
+}
+
+func (l *Limits) ToResources() TDContainer.Resources {
+	return l.Docker.Resources
+}
+
+func (l *Limits) ToShmSize() int64 {
+	return l.Docker.ShmSize
+}
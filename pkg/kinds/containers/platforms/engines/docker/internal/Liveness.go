@@ -0,0 +1,39 @@
+package internal
+
+import (
+	v1 "github.com/simplecontainer/smr/pkg/definitions/v1"
+	"github.com/simplecontainer/smr/pkg/kinds/containers/platforms/liveness"
+)
+
+type Livenesses struct {
+	Livenesses []*liveness.Liveness
+}
+
+func NewLivenesses(livenesses []v1.ContainersLiveness) (*Livenesses, error) {
+	LivenessesObj := &Livenesses{
+		Livenesses: make([]*liveness.Liveness, 0),
+	}
+
+	var err error
+	for _, l := range livenesses {
+		err = LivenessesObj.Add(l)
+
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	return LivenessesObj, nil
+}
+
+func (Livenesses *Livenesses) Add(definition v1.ContainersLiveness) error {
+	l, err := liveness.NewLivenessFromDefinition(definition)
+
+	if err != nil {
+		return err
+	}
+
+	Livenesses.Livenesses = append(Livenesses.Livenesses, l)
+
+	return nil
+}
@@ -0,0 +1,105 @@
+package internal
+
+import (
+	"errors"
+	"fmt"
+	v1 "github.com/simplecontainer/smr/pkg/definitions/v1"
+	"path/filepath"
+	"sort"
+	"strings"
+	"sync"
+)
+
+const VOLUME_SECRETS = "secrets"
+const DEFAULT_SECRET_MODE = 0444
+
+type Secrets struct {
+	Secrets []*Secret
+	Lock    sync.RWMutex
+}
+
+type Secret struct {
+	Reference v1.ContainersSecret
+	Files     map[string][]byte `json:"-"`
+}
+
+func NewSecrets(secrets []v1.ContainersSecret) *Secrets {
+	secretsObj := &Secrets{
+		Secrets: make([]*Secret, 0),
+	}
+
+	for _, secret := range secrets {
+		secretsObj.Add(secret)
+	}
+
+	return secretsObj
+}
+
+func NewSecret(secret v1.ContainersSecret) *Secret {
+	return &Secret{
+		Reference: secret,
+		Files:     make(map[string][]byte),
+	}
+}
+
+func (secrets *Secrets) Add(secret v1.ContainersSecret) {
+	secrets.Secrets = append(secrets.Secrets, NewSecret(secret))
+}
+
+// Select maps keys of the secret data to file paths relative to the mount point
+func (secret *Secret) Select(data map[string]string) (map[string][]byte, error) {
+	files := make(map[string][]byte)
+
+	items := secret.Reference.Items
+
+	if len(items) == 0 {
+		keys := make([]string, 0, len(data))
+
+		for key := range data {
+			keys = append(keys, key)
+		}
+
+		sort.Strings(keys)
+
+		for _, key := range keys {
+			items = append(items, v1.ContainersSecretItem{Key: key})
+		}
+	}
+
+	for _, item := range items {
+		value, ok := data[item.Key]
+
+		if !ok {
+			return nil, errors.New(fmt.Sprintf("key %s doesnt exist in secret %s/%s", item.Key, secret.Reference.Group, secret.Reference.Name))
+		}
+
+		path := item.Path
+
+		if path == "" {
+			path = item.Key
+		}
+
+		path = filepath.Clean(path)
+
+		if filepath.IsAbs(path) || path == "." || strings.HasPrefix(path, "..") {
+			return nil, errors.New(fmt.Sprintf("secret file path must be relative to the mount point: %s", item.Path))
+		}
+
+		files[path] = []byte(value)
+	}
+
+	return files, nil
+}
+
+// Removed returns files written previously which are not part of the secret anymore
+func (secret *Secret) Removed(files map[string][]byte) []string {
+	removed := make([]string, 0)
+
+	for path := range secret.Files {
+		if _, ok := files[path]; !ok {
+			removed = append(removed, path)
+		}
+	}
+
+	return removed
+}
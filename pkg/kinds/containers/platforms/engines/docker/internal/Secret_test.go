@@ -0,0 +1,38 @@
+package internal
+
+import (
+	v1 "github.com/simplecontainer/smr/pkg/definitions/v1"
+	"github.com/stretchr/testify/assert"
+	"testing"
+)
+
+func TestSelect(t *testing.T) {
+	data := map[string]string{"username": "admin", "password": "hunter2"}
+
+	tests := []struct {
+		name     string
+		items    []v1.ContainersSecretItem
+		expected map[string][]byte
+		wantErr  bool
+	}{
+		{name: "All keys", items: nil, expected: map[string][]byte{"username": []byte("admin"), "password": []byte("hunter2")}},
+		{name: "Selected key with path", items: []v1.ContainersSecretItem{{Key: "password", Path: "db/password"}}, expected: map[string][]byte{"db/password": []byte("hunter2")}},
+		{name: "Missing key", items: []v1.ContainersSecretItem{{Key: "token"}}, wantErr: true},
+		{name: "Escaping mount point", items: []v1.ContainersSecretItem{{Key: "password", Path: "../etc/shadow"}}, wantErr: true},
+		{name: "Absolute path", items: []v1.ContainersSecretItem{{Key: "password", Path: "/etc/shadow"}}, wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			secret := NewSecret(v1.ContainersSecret{Name: "db", Group: "app", MountPoint: "/run/secrets", Items: tt.items})
+			files, err := secret.Select(data)
+
+			if tt.wantErr {
+				assert.Error(t, err)
+			} else {
+				assert.NoError(t, err)
+				assert.Equal(t, tt.expected, files)
+			}
+		})
+	}
+}
@@ -0,0 +1,60 @@
+package internal
+
+import (
+	TDContainer "github.com/docker/docker/api/types/container"
+	"github.com/simplecontainer/smr/pkg/kinds/containers/platforms/types"
+	"strings"
+	"time"
+)
+
+// ToUsage converts engine stats into utilisation, CPU is relative to the previous stats read by the engine
+func ToUsage(stats *TDContainer.StatsResponse, sampled time.Time) *types.Usage {
+	usage := &types.Usage{
+		SampledAt: sampled,
+	}
+
+	cpuDelta := float64(stats.CPUStats.CPUUsage.TotalUsage) - float64(stats.PreCPUStats.CPUUsage.TotalUsage)
+	systemDelta := float64(stats.CPUStats.SystemUsage) - float64(stats.PreCPUStats.SystemUsage)
+
+	cpus := float64(stats.CPUStats.OnlineCPUs)
+
+	if cpus == 0 {
+		cpus = float64(len(stats.CPUStats.CPUUsage.PercpuUsage))
+	}
+
+	if cpuDelta > 0 && systemDelta > 0 {
+		usage.CPU = (cpuDelta / systemDelta) * cpus * 100
+	}
+
+	memory := stats.MemoryStats.Usage
+
+	// Page cache is reclaimable so it is not counted as used memory
+	if inactive, ok := stats.MemoryStats.Stats["inactive_file"]; ok && inactive < memory {
+		memory -= inactive
+	} else if inactive, ok = stats.MemoryStats.Stats["total_inactive_file"]; ok && inactive < memory {
+		memory -= inactive
+	}
+
+	usage.MemoryUsage = memory
+	usage.MemoryLimit = stats.MemoryStats.Limit
+
+	if stats.MemoryStats.Limit > 0 {
+		usage.Memory = float64(memory) / float64(stats.MemoryStats.Limit) * 100
+	}
+
+	for _, network := range stats.Networks {
+		usage.NetworkRx += network.RxBytes
+		usage.NetworkTx += network.TxBytes
+	}
+
+	for _, entry := range stats.BlkioStats.IoServiceBytesRecursive {
+		switch strings.ToLower(entry.Op) {
+		case "read":
+			usage.BlockRead += entry.Value
+		case "write":
+			usage.BlockWrite += entry.Value
+		}
+	}
+
+	return usage
+}
@@ -0,0 +1,89 @@
+package internal
+
+import (
+	TDContainer "github.com/docker/docker/api/types/container"
+	"github.com/simplecontainer/smr/pkg/kinds/containers/platforms/types"
+	"github.com/stretchr/testify/assert"
+	"testing"
+	"time"
+)
+
+func cpu(total uint64, system uint64, online uint32, percpu []uint64) TDContainer.CPUStats {
+	return TDContainer.CPUStats{
+		CPUUsage:    TDContainer.CPUUsage{TotalUsage: total, PercpuUsage: percpu},
+		SystemUsage: system,
+		OnlineCPUs:  online,
+	}
+}
+
+func TestToUsage(t *testing.T) {
+	now := time.Now()
+
+	tests := []struct {
+		name     string
+		stats    TDContainer.StatsResponse
+		expected types.Usage
+	}{
+		{
+			name: "CPU relative to previous read",
+			stats: TDContainer.StatsResponse{
+				CPUStats:    cpu(300, 2000, 2, nil),
+				PreCPUStats: cpu(100, 1000, 2, nil),
+			},
+			expected: types.Usage{CPU: 40},
+		},
+		{
+			name: "CPU count from per cpu usage",
+			stats: TDContainer.StatsResponse{
+				CPUStats:    cpu(300, 2000, 0, []uint64{1, 2, 3, 4}),
+				PreCPUStats: cpu(100, 1000, 0, nil),
+			},
+			expected: types.Usage{CPU: 80},
+		},
+		{
+			name: "First read without previous stats",
+			stats: TDContainer.StatsResponse{
+				CPUStats: cpu(0, 2000, 2, nil),
+			},
+			expected: types.Usage{},
+		},
+		{
+			name: "Memory without page cache",
+			stats: TDContainer.StatsResponse{
+				MemoryStats: TDContainer.MemoryStats{Usage: 600, Limit: 1000, Stats: map[string]uint64{"inactive_file": 100}},
+			},
+			expected: types.Usage{Memory: 50, MemoryUsage: 500, MemoryLimit: 1000},
+		},
+		{
+			name: "Memory without page cache on cgroup v1",
+			stats: TDContainer.StatsResponse{
+				MemoryStats: TDContainer.MemoryStats{Usage: 600, Limit: 1000, Stats: map[string]uint64{"total_inactive_file": 200}},
+			},
+			expected: types.Usage{Memory: 40, MemoryUsage: 400, MemoryLimit: 1000},
+		},
+		{
+			name: "Memory without limit",
+			stats: TDContainer.StatsResponse{
+				MemoryStats: TDContainer.MemoryStats{Usage: 600},
+			},
+			expected: types.Usage{MemoryUsage: 600},
+		},
+		{
+			name: "Network and block io summed",
+			stats: TDContainer.StatsResponse{
+				Networks: map[string]TDContainer.NetworkStats{"eth0": {RxBytes: 10, TxBytes: 20}, "eth1": {RxBytes: 1, TxBytes: 2}},
+				BlkioStats: TDContainer.BlkioStats{IoServiceBytesRecursive: []TDContainer.BlkioStatEntry{
+					{Op: "Read", Value: 5}, {Op: "write", Value: 7}, {Op: "read", Value: 3}, {Op: "Total", Value: 15},
+				}},
+			},
+			expected: types.Usage{NetworkRx: 11, NetworkTx: 22, BlockRead: 8, BlockWrite: 7},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.expected.SampledAt = now
+			assert.Equal(t, &tt.expected, ToUsage(&tt.stats, now))
+		})
+	}
+}
@@ -85,6 +85,18 @@ func (volumes *Volumes) RemoveResources() error {
 	return nil
 }
 
+func (volumes *Volumes) RemoveSecrets() {
+	tmpSlice := make([]*Volume, 0)
+
+	for _, v := range volumes.Volumes {
+		if v.Type != VOLUME_SECRETS {
+			tmpSlice = append(tmpSlice, v)
+		}
+	}
+
+	volumes.Volumes = tmpSlice
+}
+
 func (volumes *Volumes) ToMounts() []mount.Mount {
 	mounts := make([]mount.Mount, 0)
 
@@ -120,6 +132,16 @@ func (vol *Volume) ToMount() *mount.Mount {
 				Subpath: vol.SubPath,
 			},
 		}
+	case VOLUME_SECRETS:
+		// Host directory is on tmpfs and created by the daemon, files are copied in before the container starts
+		return &mount.Mount{
+			Type:   mount.TypeBind,
+			Source: vol.HostPath,
+			Target: vol.MountPoint,
+			BindOptions: &mount.BindOptions{
+				CreateMountpoint: true,
+			},
+		}
 	default:
 		return nil
 	}
@@ -0,0 +1,19 @@
+package image
+
+import (
+	"fmt"
+	"strings"
+)
+
+// Reference joins image and tag, tag can be a digest eg. sha256:... or a tag pinned to digest eg. 1.25@sha256:...
+func Reference(image string, tag string) string {
+	if IsDigest(tag) {
+		return fmt.Sprintf("%s@%s", image, tag)
+	}
+
+	return fmt.Sprintf("%s:%s", image, tag)
+}
+
+func IsDigest(tag string) bool {
+	return strings.HasPrefix(tag, "sha256:")
+}
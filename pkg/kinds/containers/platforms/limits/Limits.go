@@ -0,0 +1,123 @@
+package limits
+
+import (
+	"errors"
+	"fmt"
+	v1 "github.com/simplecontainer/smr/pkg/definitions/v1"
+	"strconv"
+	"strings"
+)
+
+var memorySuffixes = []struct {
+	Suffix     string
+	Multiplier int64
+}{
+	{"Ki", 1 << 10},
+	{"Mi", 1 << 20},
+	{"Gi", 1 << 30},
+	{"Ti", 1 << 40},
+	{"k", 1000},
+	{"K", 1000},
+	{"M", 1000 * 1000},
+	{"G", 1000 * 1000 * 1000},
+	{"T", 1000 * 1000 * 1000 * 1000},
+}
+
+// ParseCPU accepts either cores (0.5, 2) or millicores (500m) and returns millicores
+func ParseCPU(cpu string) (int64, error) {
+	cpu = strings.TrimSpace(cpu)
+
+	if cpu == "" {
+		return 0, nil
+	}
+
+	if strings.HasSuffix(cpu, "m") {
+		millicores, err := strconv.ParseInt(strings.TrimSuffix(cpu, "m"), 10, 64)
+		if err != nil || millicores < 0 {
+			return 0, errors.New(fmt.Sprintf("invalid cpu quantity: %s", cpu))
+		}
+
+		return millicores, nil
+	}
+
+	cores, err := strconv.ParseFloat(cpu, 64)
+	if err != nil || cores < 0 {
+		return 0, errors.New(fmt.Sprintf("invalid cpu quantity: %s", cpu))
+	}
+
+	return int64(cores * float64(MILLICORES_PER_CPU)), nil
+}
+
+// ParseMemory accepts plain bytes or binary (Ki, Mi, Gi, Ti) and decimal (k, M, G, T) suffixes
+func ParseMemory(memory string) (int64, error) {
+	memory = strings.TrimSpace(memory)
+
+	if memory == "" {
+		return 0, nil
+	}
+
+	number := memory
+	multiplier := int64(1)
+
+	for _, s := range memorySuffixes {
+		if strings.HasSuffix(memory, s.Suffix) {
+			multiplier = s.Multiplier
+			number = strings.TrimSuffix(memory, s.Suffix)
+			break
+		}
+	}
+
+	value, err := strconv.ParseFloat(number, 64)
+	if err != nil || value < 0 {
+		return 0, errors.New(fmt.Sprintf("invalid memory quantity: %s", memory))
+	}
+
+	return int64(value * float64(multiplier)), nil
+}
+
+// FromRequests returns the per-replica amount scheduler needs to reserve on a node
+func FromRequests(requests *v1.ContainersRequests) (Quantity, error) {
+	if requests == nil {
+		return Quantity{}, nil
+	}
+
+	cpu, err := ParseCPU(requests.CPU)
+	if err != nil {
+		return Quantity{}, err
+	}
+
+	memory, err := ParseMemory(requests.Memory)
+	if err != nil {
+		return Quantity{}, err
+	}
+
+	return Quantity{
+		CPU:    cpu,
+		Memory: memory,
+	}, nil
+}
+
+// Fits reports whether q can be placed into capacity; zero capacity means capacity is unknown and accepts anything
+func (q Quantity) Fits(capacity Quantity) bool {
+	return q.FitsWith(Quantity{}, capacity)
+}
+
+// FitsWith reports whether q can be placed into capacity next to the amount already allocated there
+func (q Quantity) FitsWith(allocated Quantity, capacity Quantity) bool {
+	if capacity.CPU != 0 && q.CPU > 0 && allocated.CPU+q.CPU > capacity.CPU {
+		return false
+	}
+
+	if capacity.Memory != 0 && q.Memory > 0 && allocated.Memory+q.Memory > capacity.Memory {
+		return false
+	}
+
+	return true
+}
+
+func (q Quantity) Add(other Quantity) Quantity {
+	return Quantity{
+		CPU:    q.CPU + other.CPU,
+		Memory: q.Memory + other.Memory,
+	}
+}
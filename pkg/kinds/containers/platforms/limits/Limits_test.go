@@ -0,0 +1,77 @@
+package limits
+
+import (
+	v1 "github.com/simplecontainer/smr/pkg/definitions/v1"
+	"github.com/stretchr/testify/assert"
+	"testing"
+)
+
+func TestParseCPU(t *testing.T) {
+	tests := []struct {
+		name        string
+		cpu         string
+		expected    int64
+		expectError bool
+	}{
+		{name: "Empty", cpu: "", expected: 0},
+		{name: "Millicores", cpu: "250m", expected: 250},
+		{name: "Whole cores", cpu: "2", expected: 2000},
+		{name: "Fractional cores", cpu: "0.5", expected: 500},
+		{name: "Invalid", cpu: "two", expectError: true},
+		{name: "Negative", cpu: "-1", expectError: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result, err := ParseCPU(tt.cpu)
+
+			if tt.expectError {
+				assert.Error(t, err)
+				return
+			}
+
+			assert.NoError(t, err)
+			assert.Equal(t, tt.expected, result)
+		})
+	}
+}
+
+func TestParseMemory(t *testing.T) {
+	tests := []struct {
+		name        string
+		memory      string
+		expected    int64
+		expectError bool
+	}{
+		{name: "Empty", memory: "", expected: 0},
+		{name: "Bytes", memory: "1024", expected: 1024},
+		{name: "Mebibytes", memory: "512Mi", expected: 512 * 1024 * 1024},
+		{name: "Gibibytes", memory: "1Gi", expected: 1024 * 1024 * 1024},
+		{name: "Megabytes", memory: "100M", expected: 100 * 1000 * 1000},
+		{name: "Invalid", memory: "lots", expectError: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result, err := ParseMemory(tt.memory)
+
+			if tt.expectError {
+				assert.Error(t, err)
+				return
+			}
+
+			assert.NoError(t, err)
+			assert.Equal(t, tt.expected, result)
+		})
+	}
+}
+
+func TestQuantity_Fits(t *testing.T) {
+	requested, err := FromRequests(&v1.ContainersRequests{CPU: "500m", Memory: "1Gi"})
+	assert.NoError(t, err)
+
+	assert.True(t, requested.Fits(Quantity{CPU: 1000, Memory: 2 << 30}))
+	assert.False(t, requested.Fits(Quantity{CPU: 250, Memory: 2 << 30}))
+	assert.False(t, requested.Fits(Quantity{CPU: 1000, Memory: 512 << 20}))
+	assert.True(t, requested.Fits(Quantity{}), "unknown capacity should accept")
+}
@@ -0,0 +1,11 @@
+package limits
+
+// Quantity holds parsed compute amounts: CPU in millicores and memory in bytes
+type Quantity struct {
+	CPU    int64
+	Memory int64
+}
+
+const MILLICORES_PER_CPU int64 = 1000
+const NANOCPUS_PER_MILLICORE int64 = 1000000
+const CPU_SHARES_PER_CPU int64 = 1024
@@ -0,0 +1,88 @@
+package liveness
+
+import (
+	"errors"
+	"fmt"
+	v1 "github.com/simplecontainer/smr/pkg/definitions/v1"
+	"time"
+)
+
+func NewLivenessFromDefinition(liveness v1.ContainersLiveness) (*Liveness, error) {
+	l := &Liveness{
+		Name:             liveness.Name,
+		Type:             liveness.Type,
+		Method:           liveness.Method,
+		URL:              liveness.URL,
+		Command:          liveness.Command,
+		Network:          liveness.Network,
+		Port:             liveness.Port,
+		Timeout:          liveness.Timeout,
+		Period:           liveness.Period,
+		InitialDelay:     liveness.InitialDelay,
+		FailureThreshold: liveness.FailureThreshold,
+		BodyUnpack:       make(map[string]string),
+		Body:             liveness.Body,
+	}
+
+	switch l.GetType() {
+	case TYPE_URL, TYPE_COMMAND, TYPE_TCP:
+		break
+	default:
+		return nil, errors.New(fmt.Sprintf("liveness probe %s has unsupported type", l.Name))
+	}
+
+	for _, duration := range []string{l.Timeout, l.Period, l.InitialDelay} {
+		if duration != "" {
+			if _, err := time.ParseDuration(duration); err != nil {
+				return nil, err
+			}
+		}
+	}
+
+	return l, nil
+}
+
+func (l *Liveness) GetType() string {
+	switch {
+	case l.Type != "":
+		return l.Type
+	case l.URL != "":
+		return TYPE_URL
+	case len(l.Command) > 0:
+		return TYPE_COMMAND
+	case l.Port != "":
+		return TYPE_TCP
+	default:
+		return ""
+	}
+}
+
+func (l *Liveness) GetTimeout() time.Duration {
+	return parseOrDefault(l.Timeout, DEFAULT_TIMEOUT)
+}
+
+func (l *Liveness) GetPeriod() time.Duration {
+	return parseOrDefault(l.Period, DEFAULT_PERIOD)
+}
+
+func (l *Liveness) GetInitialDelay() time.Duration {
+	return parseOrDefault(l.InitialDelay, 0)
+}
+
+func (l *Liveness) GetFailureThreshold() uint64 {
+	if l.FailureThreshold == 0 {
+		return DEFAULT_FAILURE_THRESHOLD
+	}
+
+	return l.FailureThreshold
+}
+
+func parseOrDefault(value string, fallback time.Duration) time.Duration {
+	duration, err := time.ParseDuration(value)
+
+	if err != nil || duration <= 0 {
+		return fallback
+	}
+
+	return duration
+}
@@ -0,0 +1,40 @@
+package liveness
+
+import "time"
+
+type Liveness struct {
+	Name             string
+	Type             string
+	Method           string
+	URL              string
+	Command          []string
+	CommandUnpacked  []string
+	Network          string
+	Port             string
+	Timeout          string
+	Period           string
+	InitialDelay     string
+	FailureThreshold uint64
+	Failures         uint64
+	LastProbe        time.Time
+	LastError        string
+	BodyUnpack       map[string]string `json:"-"`
+	Body             map[string]string `json:"-"`
+}
+
+type LivenessState struct {
+	State int8
+	Probe string
+	Error error
+}
+
+const SUCCESS = 1
+const FAILED = 2
+
+const TYPE_URL = "url"
+const TYPE_COMMAND = "command"
+const TYPE_TCP = "tcp"
+
+const DEFAULT_TIMEOUT = 5 * time.Second
+const DEFAULT_PERIOD = 10 * time.Second
+const DEFAULT_FAILURE_THRESHOLD uint64 = 3
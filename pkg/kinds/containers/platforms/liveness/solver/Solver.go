@@ -0,0 +1,152 @@
+package solver
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"github.com/simplecontainer/smr/pkg/authentication"
+	"github.com/simplecontainer/smr/pkg/clients"
+	"github.com/simplecontainer/smr/pkg/kinds/containers/platforms"
+	"github.com/simplecontainer/smr/pkg/kinds/containers/platforms/liveness"
+	"github.com/simplecontainer/smr/pkg/kinds/containers/platforms/probe"
+	"github.com/simplecontainer/smr/pkg/kinds/containers/status"
+	"go.uber.org/zap"
+	"sync"
+	"time"
+)
+
+var (
+	ERROR_LIVENESS_FAILED       = errors.New("liveness probe failed")
+	ERROR_LIVENESS_INVALID_TYPE = errors.New("liveness probe type is not supported")
+)
+
+// Alive blocks while container is alive and returns error once any probe exceeds its failure threshold
+// Returns nil if context is canceled or container has no liveness probes
+func Alive(ctx context.Context, client *clients.Http, container platforms.IContainer, user *authentication.User, logger *zap.Logger) *liveness.LivenessState {
+	probes := container.GetLiveness()
+
+	if len(probes) == 0 {
+		return nil
+	}
+
+	var wg sync.WaitGroup
+
+	// Probes are stopped and waited for so none of them writes results after Alive returns
+	ctx, cancel := context.WithCancel(ctx)
+	defer wg.Wait()
+	defer cancel()
+
+	failed := make(chan *liveness.LivenessState, len(probes))
+
+	for _, l := range probes {
+		wg.Add(1)
+
+		go func(l *liveness.Liveness) {
+			defer wg.Done()
+			watch(ctx, client, container, user, logger, l, failed)
+		}(l)
+	}
+
+	select {
+	case <-ctx.Done():
+		return nil
+	case state := <-failed:
+		return state
+	}
+}
+
+func watch(ctx context.Context, client *clients.Http, container platforms.IContainer, user *authentication.User, logger *zap.Logger, l *liveness.Liveness, failed chan *liveness.LivenessState) {
+	st := container.GetStatus()
+
+	st.Update(func() {
+		l.Failures = 0
+	})
+
+	select {
+	case <-ctx.Done():
+		return
+	case <-time.After(l.GetInitialDelay()):
+	}
+
+	ticker := time.NewTicker(l.GetPeriod())
+	defer ticker.Stop()
+
+	for {
+		err := SolveLiveness(ctx, client, user, container, l)
+
+		if ctx.Err() != nil {
+			return
+		}
+
+		var failures uint64
+
+		// Probe results are serialized with the container so they are written under the status lock
+		st.Update(func() {
+			l.LastProbe = time.Now()
+			st.LastLivenessTimestamp = l.LastProbe
+			st.LastLiveness = err == nil
+
+			if err != nil {
+				l.Failures += 1
+				l.LastError = err.Error()
+			} else {
+				l.Failures = 0
+				l.LastError = ""
+			}
+
+			failures = l.Failures
+		})
+
+		if err != nil {
+			logger.Info("liveness probe failed",
+				zap.String("probe", l.Name),
+				zap.Uint64("failures", failures),
+				zap.Uint64("threshold", l.GetFailureThreshold()),
+				zap.Error(err),
+			)
+
+			if failures >= l.GetFailureThreshold() {
+				failed <- &liveness.LivenessState{
+					State: liveness.FAILED,
+					Probe: l.Name,
+					Error: fmt.Errorf("%w: %s: %s", ERROR_LIVENESS_FAILED, l.Name, err.Error()),
+				}
+
+				return
+			}
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+func SolveLiveness(ctx context.Context, client *clients.Http, user *authentication.User, container platforms.IContainer, l *liveness.Liveness) error {
+	if !container.GetStatus().IfStateIs(status.RUNNING) {
+		// Liveness only makes sense while running - other states are handled by reconciler
+		return nil
+	}
+
+	probeCtx, probeCancel := context.WithTimeout(ctx, l.GetTimeout())
+	defer probeCancel()
+
+	switch l.GetType() {
+	case liveness.TYPE_URL:
+		return probe.URL(probeCtx, client, user, l.Method, l.URL, l.BodyUnpack)
+	case liveness.TYPE_COMMAND:
+		command := l.CommandUnpacked
+
+		if len(command) == 0 {
+			command = l.Command
+		}
+
+		return probe.Command(probeCtx, probeCancel, container, command)
+	case liveness.TYPE_TCP:
+		return probe.TCP(probeCtx, container, l.Network, l.Port, l.GetTimeout())
+	default:
+		return ERROR_LIVENESS_INVALID_TYPE
+	}
+}
@@ -0,0 +1,129 @@
+package solver
+
+import (
+	"context"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/simplecontainer/smr/pkg/kinds/containers/platforms/liveness"
+	"github.com/simplecontainer/smr/pkg/kinds/containers/status"
+	"github.com/simplecontainer/smr/pkg/kinds/containers/tests"
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/zap"
+)
+
+func TestAlive_FailsAfterThreshold(t *testing.T) {
+	requests := 0
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests++
+		w.WriteHeader(http.StatusInternalServerError)
+	}))
+	defer server.Close()
+
+	probe := &liveness.Liveness{
+		Name:             "http",
+		URL:              server.URL,
+		Method:           "GET",
+		Period:           "10ms",
+		FailureThreshold: 3,
+	}
+
+	container := tests.NewContainerBuilder().
+		WithStatus(status.RUNNING).
+		WithLiveness(probe).
+		Build()
+
+	httpClient := tests.NewHttpClientBuilder().WithDefaultClient(server.Client()).Build()
+	user := tests.NewUserBuilder().Build()
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	result := Alive(ctx, httpClient, container, user, zap.NewNop())
+
+	assert.NotNil(t, result)
+	assert.Equal(t, int8(liveness.FAILED), result.State)
+	assert.Equal(t, "http", result.Probe)
+	assert.ErrorIs(t, result.Error, ERROR_LIVENESS_FAILED)
+	assert.Equal(t, 3, requests)
+	assert.False(t, container.GetStatus().LastLiveness)
+}
+
+func TestSolveLiveness_URLTimeout(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		select {
+		case <-r.Context().Done():
+		case <-time.After(time.Second):
+		}
+
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer server.Close()
+
+	probe := &liveness.Liveness{
+		Name:    "slow",
+		URL:     server.URL,
+		Timeout: "50ms",
+	}
+
+	container := tests.NewContainerBuilder().WithStatus(status.RUNNING).Build()
+	httpClient := tests.NewHttpClientBuilder().WithDefaultClient(server.Client()).Build()
+
+	started := time.Now()
+	err := SolveLiveness(context.Background(), httpClient, tests.NewUserBuilder().Build(), container, probe)
+
+	assert.Error(t, err)
+	assert.Less(t, time.Since(started), 500*time.Millisecond)
+}
+
+func TestAlive_HealthyUntilCanceled(t *testing.T) {
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	assert.NoError(t, err)
+	defer listener.Close()
+
+	go func() {
+		for {
+			conn, err := listener.Accept()
+			if err != nil {
+				return
+			}
+			conn.Close()
+		}
+	}()
+
+	_, port, _ := net.SplitHostPort(listener.Addr().String())
+
+	probe := &liveness.Liveness{
+		Name:   "tcp",
+		Port:   port,
+		Period: "10ms",
+	}
+
+	container := tests.NewContainerBuilder().
+		WithStatus(status.RUNNING).
+		WithNetwork("cluster", net.ParseIP("127.0.0.1")).
+		WithLiveness(probe).
+		Build()
+
+	// Cancel instead of deadline so dialer doesn't inherit the deadline and time out on its own
+	ctx, cancel := context.WithCancel(context.Background())
+	time.AfterFunc(200*time.Millisecond, cancel)
+
+	result := Alive(ctx, tests.NewHttpClientBuilder().Build(), container, tests.NewUserBuilder().Build(), zap.NewNop())
+
+	assert.Nil(t, result)
+	assert.Equal(t, uint64(0), probe.Failures)
+	assert.True(t, container.GetStatus().LastLiveness)
+}
+
+func TestAlive_NoProbes(t *testing.T) {
+	container := tests.NewContainerBuilder().WithStatus(status.RUNNING).Build()
+
+	result := Alive(context.Background(), tests.NewHttpClientBuilder().Build(), container, tests.NewUserBuilder().Build(), zap.NewNop())
+
+	assert.Nil(t, result)
+}
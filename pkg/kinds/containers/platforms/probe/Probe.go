@@ -0,0 +1,167 @@
+package probe
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"github.com/simplecontainer/smr/pkg/authentication"
+	"github.com/simplecontainer/smr/pkg/clients"
+	"github.com/simplecontainer/smr/pkg/exec"
+	"github.com/simplecontainer/smr/pkg/kinds/containers/platforms"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials/insecure"
+	healthpb "google.golang.org/grpc/health/grpc_health_v1"
+	"net"
+	"net/http"
+	"sort"
+	"time"
+)
+
+var (
+	ERROR_INVALID_METHOD_FOR_URL  = errors.New("invalid method for url")
+	ERROR_REQUEST_CREATION_FAILED = errors.New("probe request creation failed")
+	ERROR_REQUEST_FAILED          = errors.New("probe request failed")
+	ERROR_COMMAND_FAILED          = errors.New("probe command failed")
+	ERROR_COMMAND_NOT_RUNNING     = errors.New("probe command failed - container not running")
+	ERROR_NETWORK_NOT_FOUND       = errors.New("probe target network not found on the container")
+	ERROR_TCP_FAILED              = errors.New("probe tcp connect failed")
+	ERROR_GRPC_FAILED             = errors.New("probe grpc health check failed")
+	ERROR_GRPC_NOT_SERVING        = errors.New("probe grpc service is not serving")
+)
+
+// URL sends request and expects 200 OK as healthy response, request is bound to the context deadline
+func URL(ctx context.Context, client *clients.Http, user *authentication.User, method string, url string, body map[string]string) error {
+	switch method {
+	case "":
+		method = http.MethodGet
+	case http.MethodGet, http.MethodPost:
+		// allowed
+	default:
+		return ERROR_INVALID_METHOD_FOR_URL
+	}
+
+	jsonBytes, err := json.Marshal(body)
+	if err != nil {
+		return ERROR_REQUEST_CREATION_FAILED
+	}
+
+	req, err := http.NewRequestWithContext(ctx, method, url, bytes.NewBuffer(jsonBytes))
+	if err != nil {
+		return ERROR_REQUEST_CREATION_FAILED
+	}
+
+	req.Header.Set("Content-Type", "application/json")
+
+	resp, err := client.Get(user.Username).Http.Do(req)
+	if err != nil {
+		return ERROR_REQUEST_FAILED
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode == http.StatusOK {
+		return nil
+	}
+
+	return ERROR_REQUEST_FAILED
+}
+
+// Command executes command inside the container and expects exit code 0
+func Command(ctx context.Context, cancel context.CancelFunc, container platforms.IContainer, command []string) error {
+	c, err := container.GetState()
+	if err != nil || c.State != "running" {
+		return ERROR_COMMAND_NOT_RUNNING
+	}
+
+	session, err := exec.Create(ctx, cancel, nil, container, command, false, "", "")
+	if err != nil {
+		return fmt.Errorf("probe creating command failed: %w", err)
+	}
+
+	result, err := session.Output(container)
+	if err != nil {
+		return fmt.Errorf("probe command failed: %w", err)
+	}
+
+	if result.Exit != 0 {
+		return ERROR_COMMAND_FAILED
+	}
+
+	return nil
+}
+
+// TCP opens connection to the container overlay IP on the specified network and port
+func TCP(ctx context.Context, container platforms.IContainer, network string, port string, timeout time.Duration) error {
+	address, err := Address(container, network, port)
+	if err != nil {
+		return err
+	}
+
+	dialer := net.Dialer{Timeout: timeout}
+
+	conn, err := dialer.DialContext(ctx, "tcp", address)
+	if err != nil {
+		return fmt.Errorf("%w: %s", ERROR_TCP_FAILED, err.Error())
+	}
+
+	return conn.Close()
+}
+
+// GRPC calls grpc.health.v1.Health/Check on the container overlay IP and expects SERVING status
+func GRPC(ctx context.Context, container platforms.IContainer, network string, port string, service string) error {
+	address, err := Address(container, network, port)
+	if err != nil {
+		return err
+	}
+
+	conn, err := grpc.NewClient(address, grpc.WithTransportCredentials(insecure.NewCredentials()))
+	if err != nil {
+		return fmt.Errorf("%w: %s", ERROR_GRPC_FAILED, err.Error())
+	}
+	defer conn.Close()
+
+	response, err := healthpb.NewHealthClient(conn).Check(ctx, &healthpb.HealthCheckRequest{
+		Service: service,
+	})
+
+	if err != nil {
+		return fmt.Errorf("%w: %s", ERROR_GRPC_FAILED, err.Error())
+	}
+
+	if response.GetStatus() != healthpb.HealthCheckResponse_SERVING {
+		return fmt.Errorf("%w: %s", ERROR_GRPC_NOT_SERVING, response.GetStatus().String())
+	}
+
+	return nil
+}
+
+// Address resolves host:port from the container IP on the network; empty network picks first network alphabetically
+func Address(container platforms.IContainer, network string, port string) (string, error) {
+	networks := container.GetNetwork()
+
+	if network != "" {
+		ip, ok := networks[network]
+
+		if !ok || ip == nil {
+			return "", ERROR_NETWORK_NOT_FOUND
+		}
+
+		return net.JoinHostPort(ip.String(), port), nil
+	}
+
+	names := make([]string, 0, len(networks))
+	for name := range networks {
+		names = append(names, name)
+	}
+
+	sort.Strings(names)
+
+	for _, name := range names {
+		if networks[name] != nil {
+			return net.JoinHostPort(networks[name].String(), port), nil
+		}
+	}
+
+	return "", ERROR_NETWORK_NOT_FOUND
+}
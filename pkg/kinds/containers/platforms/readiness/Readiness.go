@@ -8,23 +8,24 @@ import (
 
 func NewReadinessFromDefinition(readiness v1.ContainersReadiness) (*Readiness, error) {
 	return &Readiness{
-		Name:    readiness.Name,
-		URL:     readiness.URL,
-		Command: readiness.Command,
-		Body:    readiness.Body,
-		Timeout: readiness.Timeout,
-		Method:  readiness.Method,
-		Ctx:     nil,
-		Cancel:  nil,
+		Name:       readiness.Name,
+		Type:       readiness.Type,
+		URL:        readiness.URL,
+		Command:    readiness.Command,
+		Network:    readiness.Network,
+		Port:       readiness.Port,
+		Service:    readiness.Service,
+		BodyUnpack: make(map[string]string),
+		Body:       readiness.Body,
+		Timeout:    readiness.Timeout,
+		Method:     readiness.Method,
+		Ctx:        nil,
+		Cancel:     nil,
 	}, nil
 }
 
 func (r *Readiness) Reset(ctx context.Context) error {
-	if r.Timeout == "" {
-		r.Timeout = "30s"
-	}
-
-	timeout, err := time.ParseDuration(r.Timeout)
+	timeout, err := r.GetTimeout()
 
 	if err != nil {
 		return err
@@ -33,3 +34,11 @@ func (r *Readiness) Reset(ctx context.Context) error {
 	r.Ctx, r.Cancel = context.WithTimeout(ctx, timeout)
 	return nil
 }
+
+func (r *Readiness) GetTimeout() (time.Duration, error) {
+	if r.Timeout == "" {
+		r.Timeout = "30s"
+	}
+
+	return time.ParseDuration(r.Timeout)
+}
@@ -9,6 +9,9 @@ type Readiness struct {
 	CommandUnpacked []string
 	Command         []string
 	Type            string
+	Network         string
+	Port            string
+	Service         string
 	Timeout         string
 	Solved          bool
 	BodyUnpack      map[string]string  `json:"-"`
@@ -30,6 +33,8 @@ const CANCELED = 3
 
 const TYPE_URL = "url"
 const TYPE_COMMAND = "command"
+const TYPE_TCP = "tcp"
+const TYPE_GRPC = "grpc"
 
 type ReadinessResult struct {
 	Data string
@@ -1,20 +1,17 @@
 package solver
 
 import (
-	"bytes"
 	"context"
-	"encoding/json"
 	"errors"
 	"fmt"
 	"github.com/cenkalti/backoff/v4"
 	"github.com/simplecontainer/smr/pkg/authentication"
 	"github.com/simplecontainer/smr/pkg/clients"
-	"github.com/simplecontainer/smr/pkg/exec"
 	"github.com/simplecontainer/smr/pkg/kinds/containers/platforms"
+	"github.com/simplecontainer/smr/pkg/kinds/containers/platforms/probe"
 	"github.com/simplecontainer/smr/pkg/kinds/containers/platforms/readiness"
 	"github.com/simplecontainer/smr/pkg/kinds/containers/status"
 	"go.uber.org/zap"
-	"net/http"
 	"time"
 )
 
@@ -23,11 +20,10 @@ var (
 	ERROR_CONTEXT_CANCELED         = errors.New("context canceled")
 	ERROR_CONTEXT_TIMEOUT          = errors.New("context time out")
 	ERROR_READINESS_RESET_FAILED   = errors.New("readiness reset failed")
-	ERROR_INVALID_METHOD_FOR_URL   = errors.New("invalid method for url")
-	ERROR_REQUEST_CREATION_FAILED  = errors.New("readiness request creation failed")
 	ERROR_READINESS_REQUEST_FAILED = errors.New("readiness request failed")
 	ERROR_COMMAND_FAILED           = errors.New("readiness command failed")
-	ERROR_COMMAND_NOT_RUNNING      = errors.New("readiness command failed - container not running")
+	ERROR_TCP_FAILED               = errors.New("readiness tcp probe failed")
+	ERROR_GRPC_FAILED              = errors.New("readiness grpc probe failed")
 )
 
 func Ready(ctx context.Context, client *clients.Http, container platforms.IContainer, user *authentication.User, channel chan *readiness.ReadinessState, logger *zap.Logger) (bool, error) {
@@ -100,62 +96,69 @@ func SolveReadiness(client *clients.Http, user *authentication.User, container p
 		return ERROR_READINESS_INVALID_STATE
 	}
 
-	if r.URL != "" {
-		r.Type = readiness.TYPE_URL
-	}
-	if len(r.Command) > 0 {
-		r.Type = readiness.TYPE_COMMAND
+	switch r.Type {
+	case readiness.TYPE_TCP, readiness.TYPE_GRPC:
+		// Explicit network probes - target is resolved through container network
+		break
+	default:
+		if r.URL != "" {
+			r.Type = readiness.TYPE_URL
+		}
+		if len(r.Command) > 0 {
+			r.Type = readiness.TYPE_COMMAND
+		}
+		if r.Type == "" && r.Port != "" {
+			r.Type = readiness.TYPE_TCP
+		}
 	}
 
 	switch r.Type {
 	case readiness.TYPE_URL:
-		switch r.Method {
-		case "GET", "POST":
-			// allowed
-		default:
-			return ERROR_INVALID_METHOD_FOR_URL
+		logger.Info("readiness probe", zap.String("URL", r.URL))
+
+		err := probe.URL(r.Ctx, client, user, r.Method, r.URL, r.BodyUnpack)
+		if err != nil {
+			return fmt.Errorf("%w: %w", ERROR_READINESS_REQUEST_FAILED, err)
 		}
 
-		jsonBytes, err := json.Marshal(r.BodyUnpack)
-		logger.Info("readiness probe", zap.String("URL", r.URL), zap.String("data", string(jsonBytes)))
+		r.Solved = true
+		return nil
 
-		req, err := http.NewRequest(r.Method, r.URL, bytes.NewBuffer(jsonBytes))
+	case readiness.TYPE_COMMAND:
+		err := probe.Command(r.Ctx, r.Cancel, container, r.Command)
 		if err != nil {
-			return ERROR_REQUEST_CREATION_FAILED
+			return fmt.Errorf("%w: %w", ERROR_COMMAND_FAILED, err)
 		}
 
-		req.Header.Set("Content-Type", "application/json")
+		r.Solved = true
+		return nil
+
+	case readiness.TYPE_TCP:
+		logger.Info("readiness probe", zap.String("type", r.Type), zap.String("network", r.Network), zap.String("port", r.Port))
 
-		resp, err := client.Get(user.Username).Http.Do(req)
+		timeout, err := r.GetTimeout()
 		if err != nil {
-			return ERROR_READINESS_REQUEST_FAILED
+			return err
 		}
-		if resp.StatusCode == http.StatusOK {
-			r.Solved = true
-			return nil
+
+		err = probe.TCP(r.Ctx, container, r.Network, r.Port, timeout)
+		if err != nil {
+			return fmt.Errorf("%w: %w", ERROR_TCP_FAILED, err)
 		}
-		return ERROR_READINESS_REQUEST_FAILED
 
-	case readiness.TYPE_COMMAND:
-		c, err := container.GetState()
-		if err == nil && c.State == "running" {
-			session, err := exec.Create(r.Ctx, r.Cancel, nil, container, r.Command, false, "", "")
-			if err != nil {
-				return fmt.Errorf("readiness creating command failed: %w", err)
-			}
+		r.Solved = true
+		return nil
 
-			result, err := session.Output(container)
-			if err != nil {
-				return fmt.Errorf("readiness command failed: %w", err)
-			}
+	case readiness.TYPE_GRPC:
+		logger.Info("readiness probe", zap.String("type", r.Type), zap.String("network", r.Network), zap.String("port", r.Port), zap.String("service", r.Service))
 
-			if result.Exit == 0 {
-				r.Solved = true
-				return nil
-			}
-			return ERROR_COMMAND_FAILED
+		err := probe.GRPC(r.Ctx, container, r.Network, r.Port, r.Service)
+		if err != nil {
+			return fmt.Errorf("%w: %w", ERROR_GRPC_FAILED, err)
 		}
-		return ERROR_COMMAND_NOT_RUNNING
+
+		r.Solved = true
+		return nil
 
 	default:
 		return nil
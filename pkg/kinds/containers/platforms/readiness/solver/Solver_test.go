@@ -2,6 +2,7 @@ package solver
 
 import (
 	"context"
+	"net"
 	"net/http"
 	"net/http/httptest"
 	"testing"
@@ -12,6 +13,9 @@ import (
 	"github.com/simplecontainer/smr/pkg/kinds/containers/tests"
 	"github.com/stretchr/testify/assert"
 	"go.uber.org/zap"
+	"google.golang.org/grpc"
+	grpchealth "google.golang.org/grpc/health"
+	healthpb "google.golang.org/grpc/health/grpc_health_v1"
 )
 
 // ============================================================================
@@ -325,3 +329,81 @@ func TestReady_ContextCancellation_UsingBuilder(t *testing.T) {
 	assert.False(t, result)
 	assert.Equal(t, context.Canceled, err)
 }
+
+// ============================================================================
+// TCP AND GRPC PROBES
+// ============================================================================
+
+func TestReady_TCPProbe(t *testing.T) {
+	logger := zap.NewNop()
+	channel := make(chan *readiness.ReadinessState, 10)
+
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	assert.NoError(t, err)
+	defer listener.Close()
+
+	_, port, _ := net.SplitHostPort(listener.Addr().String())
+
+	probe := tests.NewReadinessBuilder().WithTCP("cluster", port).Build()
+	container := tests.NewContainerBuilder().
+		WithState("running").
+		WithStatus(status.READINESS_CHECKING).
+		WithNetwork("cluster", net.ParseIP("127.0.0.1")).
+		WithReadiness(probe).
+		Build()
+
+	result, err := Ready(context.Background(), tests.NewHttpClientBuilder().Build(), container, tests.NewUserBuilder().Build(), channel, logger)
+
+	assert.NoError(t, err)
+	assert.True(t, result)
+	assert.True(t, probe.Solved)
+}
+
+func TestReady_GRPCProbe(t *testing.T) {
+	cases := []struct {
+		name           string
+		service        string
+		servingStatus  healthpb.HealthCheckResponse_ServingStatus
+		expectedSolved bool
+	}{
+		{"serving", "api", healthpb.HealthCheckResponse_SERVING, true},
+		{"not serving", "api", healthpb.HealthCheckResponse_NOT_SERVING, false},
+	}
+
+	for _, tt := range cases {
+		t.Run(tt.name, func(t *testing.T) {
+			logger := zap.NewNop()
+			channel := make(chan *readiness.ReadinessState, 10)
+
+			listener, err := net.Listen("tcp", "127.0.0.1:0")
+			assert.NoError(t, err)
+
+			server := grpc.NewServer()
+			health := grpchealth.NewServer()
+			health.SetServingStatus(tt.service, tt.servingStatus)
+			healthpb.RegisterHealthServer(server, health)
+
+			go server.Serve(listener)
+			defer server.Stop()
+
+			_, port, _ := net.SplitHostPort(listener.Addr().String())
+
+			probe := tests.NewReadinessBuilder().WithGRPC("cluster", port, tt.service).WithTimeout("1s").Build()
+			container := tests.NewContainerBuilder().
+				WithState("running").
+				WithStatus(status.READINESS_CHECKING).
+				WithNetwork("cluster", net.ParseIP("127.0.0.1")).
+				WithReadiness(probe).
+				Build()
+
+			result, err := Ready(context.Background(), tests.NewHttpClientBuilder().Build(), container, tests.NewUserBuilder().Build(), channel, logger)
+
+			assert.Equal(t, tt.expectedSolved, result)
+			assert.Equal(t, tt.expectedSolved, probe.Solved)
+
+			if !tt.expectedSolved {
+				assert.Error(t, err)
+			}
+		})
+	}
+}
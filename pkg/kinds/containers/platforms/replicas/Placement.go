@@ -0,0 +1,85 @@
+package replicas
+
+import (
+	"errors"
+	"fmt"
+	v1 "github.com/simplecontainer/smr/pkg/definitions/v1"
+	"github.com/simplecontainer/smr/pkg/selector"
+	"sort"
+)
+
+// NewPlacement compiles nodeSelector, affinity and anti-affinity of the definition into node constraints
+func NewPlacement(definition *v1.ContainersDefinition) (*Placement, error) {
+	placement := &Placement{
+		Selector: &selector.Selector{
+			Requirements: make([]selector.Requirement, 0),
+		},
+		AntiSelector: &selector.Selector{
+			Requirements: make([]selector.Requirement, 0),
+		},
+	}
+
+	if definition == nil || definition.Spec == nil {
+		return placement, nil
+	}
+
+	keys := make([]string, 0, len(definition.Spec.NodeSelector))
+	for key := range definition.Spec.NodeSelector {
+		keys = append(keys, key)
+	}
+
+	sort.Strings(keys)
+
+	for _, key := range keys {
+		placement.Selector.Requirements = append(placement.Selector.Requirements, selector.Requirement{
+			Key:      key,
+			Operator: selector.OPERATOR_EQUALS,
+			Values:   []string{definition.Spec.NodeSelector[key]},
+		})
+	}
+
+	if definition.Spec.Affinity != nil {
+		affinity, err := selector.Parse(definition.Spec.Affinity.Nodes)
+
+		if err != nil {
+			return nil, errors.New(fmt.Sprintf("invalid affinity: %s", err.Error()))
+		}
+
+		placement.Selector.Requirements = append(placement.Selector.Requirements, affinity.Requirements...)
+	}
+
+	if definition.Spec.AntiAffinity != nil {
+		anti, err := selector.Parse(definition.Spec.AntiAffinity.Nodes)
+
+		if err != nil {
+			return nil, errors.New(fmt.Sprintf("invalid anti-affinity: %s", err.Error()))
+		}
+
+		placement.AntiSelector = anti
+		placement.OnePerNode = definition.Spec.AntiAffinity.Replicas
+	}
+
+	return placement, nil
+}
+
+// Allows returns true if node with given labels can run replicas
+func (placement *Placement) Allows(labels map[string]string) bool {
+	if placement == nil {
+		return true
+	}
+
+	if !placement.Selector.Matches(labels) {
+		return false
+	}
+
+	return placement.AntiSelector.IsEmpty() || !placement.AntiSelector.Matches(labels)
+}
+
+// Limit caps wanted replicas so no node runs more than one replica when replica anti-affinity is set
+func (placement *Placement) Limit(replicasWanted uint64, nodes []uint64) uint64 {
+	if placement != nil && placement.OnePerNode && replicasWanted > uint64(len(nodes)) {
+		return uint64(len(nodes))
+	}
+
+	return replicasWanted
+}
@@ -0,0 +1,114 @@
+package replicas
+
+import (
+	"github.com/simplecontainer/smr/pkg/definitions/commonv1"
+	v1 "github.com/simplecontainer/smr/pkg/definitions/v1"
+	"github.com/simplecontainer/smr/pkg/kinds/containers/platforms/limits"
+	"github.com/simplecontainer/smr/pkg/node"
+	"github.com/stretchr/testify/assert"
+	"testing"
+)
+
+func cluster() []*node.Node {
+	return []*node.Node{
+		{NodeID: 1, Labels: map[string]string{"disk": "ssd", "zone": "a"}},
+		{NodeID: 2, Labels: map[string]string{"disk": "hdd", "zone": "a"}},
+		{NodeID: 3, Labels: map[string]string{"disk": "ssd", "zone": "b", "role": "gateway"}},
+	}
+}
+
+func definition(spec *v1.ContainersInternal) *v1.ContainersDefinition {
+	spec.Image = "nginx"
+	spec.Tag = "latest"
+	spec.Spread = &v1.ContainersSpread{Spread: "uniform"}
+
+	return &v1.ContainersDefinition{
+		Meta: &commonv1.Meta{Group: "example", Name: "web"},
+		Spec: spec,
+	}
+}
+
+func TestNewPlacement(t *testing.T) {
+	_, err := NewPlacement(definition(&v1.ContainersInternal{Affinity: &v1.ContainersAffinity{Nodes: "zone in (a"}}))
+	assert.Error(t, err)
+
+	_, err = NewPlacement(definition(&v1.ContainersInternal{AntiAffinity: &v1.ContainersAntiAffinity{Nodes: "=gateway"}}))
+	assert.Error(t, err)
+
+	placement, err := NewPlacement(definition(&v1.ContainersInternal{
+		NodeSelector: map[string]string{"disk": "ssd"},
+		AntiAffinity: &v1.ContainersAntiAffinity{Nodes: "role=gateway"},
+	}))
+
+	assert.NoError(t, err)
+	assert.True(t, placement.Allows(map[string]string{"disk": "ssd"}))
+	assert.False(t, placement.Allows(map[string]string{"disk": "hdd"}))
+	assert.False(t, placement.Allows(map[string]string{"disk": "ssd", "role": "gateway"}))
+}
+
+func TestGetReplicaNumbers_Placement(t *testing.T) {
+	tests := []struct {
+		name     string
+		spec     *v1.ContainersInternal
+		replicas uint64
+		expected map[uint64][]uint64
+	}{
+		{
+			name:     "No placement spreads over all nodes",
+			spec:     &v1.ContainersInternal{},
+			replicas: 3,
+			expected: map[uint64][]uint64{1: {1}, 2: {2}, 3: {3}},
+		},
+		{
+			name:     "Node selector",
+			spec:     &v1.ContainersInternal{NodeSelector: map[string]string{"disk": "ssd"}},
+			replicas: 4,
+			expected: map[uint64][]uint64{1: {1, 2}, 2: {}, 3: {3, 4}},
+		},
+		{
+			name:     "Affinity",
+			spec:     &v1.ContainersInternal{Affinity: &v1.ContainersAffinity{Nodes: "zone in (a)"}},
+			replicas: 2,
+			expected: map[uint64][]uint64{1: {1}, 2: {2}, 3: {}},
+		},
+		{
+			name:     "Anti-affinity to nodes",
+			spec:     &v1.ContainersInternal{AntiAffinity: &v1.ContainersAntiAffinity{Nodes: "role=gateway"}},
+			replicas: 2,
+			expected: map[uint64][]uint64{1: {1}, 2: {2}, 3: {}},
+		},
+		{
+			name:     "Never two replicas on the same node",
+			spec:     &v1.ContainersInternal{AntiAffinity: &v1.ContainersAntiAffinity{Replicas: true}},
+			replicas: 5,
+			expected: map[uint64][]uint64{1: {1}, 2: {2}, 3: {3}},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			d := definition(tt.spec)
+			placement, err := NewPlacement(d)
+			assert.NoError(t, err)
+
+			for nodeID, expected := range tt.expected {
+				r := New(nodeID, cluster())
+				create, _ := r.GetReplicaNumbers(d.Spec.Spread, tt.replicas, []uint64{}, limits.Quantity{}, placement)
+
+				assert.ElementsMatch(t, expected, create, "node %d", nodeID)
+			}
+		})
+	}
+}
+
+func TestGetReplicaNumbers_DestroyOnIneligibleNode(t *testing.T) {
+	d := definition(&v1.ContainersInternal{NodeSelector: map[string]string{"disk": "ssd"}})
+	placement, err := NewPlacement(d)
+	assert.NoError(t, err)
+
+	r := New(2, cluster())
+	create, destroy := r.GetReplicaNumbers(d.Spec.Spread, 3, []uint64{2}, limits.Quantity{}, placement)
+
+	assert.Empty(t, create)
+	assert.Equal(t, []uint64{2}, destroy)
+}
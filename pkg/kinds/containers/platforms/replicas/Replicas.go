@@ -5,6 +5,7 @@ import (
 	v1 "github.com/simplecontainer/smr/pkg/definitions/v1"
 	"github.com/simplecontainer/smr/pkg/kinds/containers/platforms"
 	"github.com/simplecontainer/smr/pkg/kinds/containers/platforms/containers"
+	"github.com/simplecontainer/smr/pkg/kinds/containers/platforms/limits"
 	"github.com/simplecontainer/smr/pkg/node"
 	"github.com/simplecontainer/smr/pkg/static"
 	"slices"
@@ -13,16 +14,34 @@ import (
 
 func New(nodeID uint64, nodes []*node.Node) *Replicas {
 	cluster := make([]uint64, 0)
+	capacity := make(map[uint64]limits.Quantity)
+	labels := make(map[uint64]map[string]string)
+	unreachable := make([]uint64, 0)
 
 	for _, n := range nodes {
+		// Unreachable nodes don't get replicas so they are redistributed to the healthy ones
+		if !n.Reachable() {
+			unreachable = append(unreachable, n.NodeID)
+			continue
+		}
+
 		cluster = append(cluster, n.NodeID)
+		capacity[n.NodeID] = limits.Quantity{
+			CPU:    n.Resources.CPU,
+			Memory: n.Resources.Memory,
+		}
+		labels[n.NodeID] = n.Labels
 	}
 
 	return &Replicas{
-		NodeID:  nodeID,
-		Create:  []uint64{0},
-		Destroy: []uint64{0},
-		Cluster: cluster,
+		NodeID:      nodeID,
+		Create:      []uint64{0},
+		Destroy:     []uint64{0},
+		Cluster:     cluster,
+		Capacity:    capacity,
+		Allocated:   make(map[uint64]limits.Quantity),
+		Labels:      labels,
+		Unreachable: unreachable,
 	}
 }
 
@@ -67,6 +86,59 @@ func (replicas *Replicas) GenerateContainers(registry platforms.Registry, defini
 	return createContainers, updateContainers, destroyContainers, nil
 }
 
+// GenerateSurgeContainers creates extra replicas for this node that run above desired count during rolling update
+func (replicas *Replicas) GenerateSurgeContainers(registry platforms.Registry, definition *v1.ContainersDefinition, config *configuration.Configuration, surge uint64) ([]platforms.IContainer, error) {
+	surgeContainers := make([]platforms.IContainer, 0)
+
+	if surge == 0 {
+		return surgeContainers, nil
+	}
+
+	requested, err := limits.FromRequests(definition.Spec.Requests)
+
+	if err != nil {
+		return nil, err
+	}
+
+	placement, err := NewPlacement(definition)
+
+	if err != nil {
+		return nil, err
+	}
+
+	spread := definition.Spec.Spread
+
+	if spread == nil {
+		spread = &v1.ContainersSpread{
+			Spread: "specific",
+			Agents: []uint64{definition.GetRuntime().GetNode()},
+		}
+	}
+
+	// Replicas of the definition keep running next to the surge so they take their share of the capacity
+	replicas.Allocate(registry, definition, true)
+
+	indexes, _ := replicas.GetReplicaNumbers(spread, surge, []uint64{}, requested, placement)
+
+	for _, index := range indexes {
+		generatedName := registry.NameReplica(definition.Meta.Group, definition.Meta.Name, definition.Spec.Replicas+index)
+
+		if registry.FindLocal(definition.Meta.Group, generatedName) != nil {
+			continue
+		}
+
+		newContainer, err := containers.New(static.PLATFORM_DOCKER, generatedName, config, definition)
+
+		if err != nil {
+			return surgeContainers, err
+		}
+
+		surgeContainers = append(surgeContainers, newContainer)
+	}
+
+	return surgeContainers, nil
+}
+
 func (replicas *Replicas) RemoveContainers(registry platforms.Registry, definition *v1.ContainersDefinition) ([]platforms.IContainer, error) {
 	indexes, err := registry.GetIndexes(definition.Prefix, definition.Meta.Group, definition.Meta.Name)
 
@@ -95,50 +167,171 @@ func (replicas *Replicas) GetContainersIndexes(registry platforms.Registry, defi
 		return nil, nil, err
 	}
 
+	requested, err := limits.FromRequests(definition.Spec.Requests)
+
+	if err != nil {
+		return nil, nil, err
+	}
+
+	placement, err := NewPlacement(definition)
+
+	if err != nil {
+		return nil, nil, err
+	}
+
+	replicas.Allocate(registry, definition, false)
+
 	if definition.Spec.Spread == nil {
-		// No spread so create only for node who sourced the object
-		replicas.Recalculate(&v1.ContainersSpread{
+		spread := &v1.ContainersSpread{
 			Spread: "specific",
 			Agents: []uint64{definition.GetRuntime().GetNode()},
-		}, definition.Spec.Replicas, indexes)
+		}
+
+		// No spread so create only for node who sourced the object, unless it is gone - then spread over healthy nodes
+		if slices.Contains(replicas.Unreachable, definition.GetRuntime().GetNode()) {
+			spread = &v1.ContainersSpread{
+				Spread: containers.SPREAD_UNIFORM,
+			}
+		}
+
+		replicas.Recalculate(spread, definition.Spec.Replicas, indexes, requested, placement)
 	} else {
-		replicas.Recalculate(definition.Spec.Spread, definition.Spec.Replicas, indexes)
+		replicas.Recalculate(definition.Spec.Spread, definition.Spec.Replicas, indexes, requested, placement)
 	}
 
 	return replicas.Create, replicas.Destroy, nil
 }
 
-func (replicas *Replicas) Recalculate(spread *v1.ContainersSpread, replicasDefined uint64, existingIndexes []uint64) {
-	replicas.Create, replicas.Destroy = replicas.GetReplicaNumbers(spread, replicasDefined, existingIndexes)
+func (replicas *Replicas) Recalculate(spread *v1.ContainersSpread, replicasDefined uint64, existingIndexes []uint64, requested limits.Quantity, placement *Placement) {
+	replicas.Create, replicas.Destroy = replicas.GetReplicaNumbers(spread, replicasDefined, existingIndexes, requested, placement)
 }
 
-func (replicas *Replicas) GetReplicaNumbers(spread *v1.ContainersSpread, replicasDefined uint64, existingIndexes []uint64) ([]uint64, []uint64) {
+func (replicas *Replicas) GetReplicaNumbers(spread *v1.ContainersSpread, replicasDefined uint64, existingIndexes []uint64, requested limits.Quantity, placement *Placement) ([]uint64, []uint64) {
+	// Node without enough capacity for a single replica or not matching placement doesn't run any replica
+	if !requested.FitsWith(replicas.Allocated[replicas.NodeID], replicas.Capacity[replicas.NodeID]) || !placement.Allows(replicas.Labels[replicas.NodeID]) {
+		return []uint64{}, existingIndexes
+	}
+
+	var nodes []uint64
+
 	switch spread.Spread {
 	case containers.SPREAD_SPECIFIC:
-		return Specific(replicasDefined, existingIndexes, spread.Agents, replicas.NodeID)
+		nodes = replicas.Schedulable(spread.Agents, requested, placement)
+		slices.Sort(nodes)
+		return Specific(replicas.Distribute(placement.Limit(replicasDefined, nodes), nodes, requested), existingIndexes, nodes, replicas.NodeID)
 	case containers.SPREAD_UNIFORM:
-		return Uniform(replicasDefined, existingIndexes, replicas.Cluster, replicas.NodeID)
+		nodes = replicas.Schedulable(replicas.Cluster, requested, placement)
+		return Uniform(replicas.Distribute(placement.Limit(replicasDefined, nodes), nodes, requested), existingIndexes, nodes, replicas.NodeID)
 	default:
-		return Specific(replicasDefined, existingIndexes, spread.Agents, replicas.NodeID)
+		nodes = replicas.Schedulable(spread.Agents, requested, placement)
+		slices.Sort(nodes)
+		return Specific(replicas.Distribute(placement.Limit(replicasDefined, nodes), nodes, requested), existingIndexes, nodes, replicas.NodeID)
 	}
 }
 
-func Uniform(replicasWanted uint64, existingIndexes []uint64, cluster []uint64, member uint64) ([]uint64, []uint64) {
-	var create = make([][]uint64, 0)
-	var destroy = make([]uint64, 0)
+// Schedulable filters out nodes that can't fit the requested resources of a single replica or don't match placement
+func (replicas *Replicas) Schedulable(nodes []uint64, requested limits.Quantity, placement *Placement) []uint64 {
+	schedulable := make([]uint64, 0)
+
+	for _, n := range nodes {
+		if slices.Contains(replicas.Unreachable, n) {
+			continue
+		}
+
+		if requested.FitsWith(replicas.Allocated[n], replicas.Capacity[n]) && placement.Allows(replicas.Labels[n]) {
+			schedulable = append(schedulable, n)
+		}
+	}
+
+	return schedulable
+}
+
+// Allocate sums requests of replicas already placed on every node, replicas of the definition itself are skipped
+// since they are placed again unless they keep running next to the new ones
+func (replicas *Replicas) Allocate(registry platforms.Registry, definition *v1.ContainersDefinition, own bool) {
+	replicas.Allocated = make(map[uint64]limits.Quantity)
+
+	for _, container := range registry.FindAll(definition.GetPrefix()) {
+		if !own && container.GetGroup() == definition.Meta.Group && container.GetName() == definition.Meta.Name {
+			continue
+		}
+
+		if container.GetNode() == nil || container.GetGlobalDefinition() == nil || container.GetGlobalDefinition().Spec == nil {
+			continue
+		}
+
+		requested, err := limits.FromRequests(container.GetGlobalDefinition().Spec.Requests)
 
-	replicas := make([]uint64, replicasWanted)
-	for i := uint64(0); i < replicasWanted; i++ {
-		replicas[i] = i + uint64(1)
+		if err != nil {
+			continue
+		}
+
+		replicas.Allocated[container.GetNode().NodeID] = replicas.Allocated[container.GetNode().NodeID].Add(requested)
 	}
+}
+
+// Distribute splits replicas over the nodes in order, node gets the next replica only while the request fits next to
+// the replicas allocated there and the ones placed earlier in this pass, replicas that fit nowhere aren't placed
+func (replicas *Replicas) Distribute(replicasWanted uint64, nodes []uint64, requested limits.Quantity) [][]uint64 {
+	allocated := make([]limits.Quantity, len(nodes))
+	counts := make([]uint64, len(nodes))
 
-	create = ChunkSlice(replicas, len(cluster))
+	for i, n := range nodes {
+		allocated[i] = replicas.Allocated[n]
+	}
 
-	if len(create[member-1]) <= len(existingIndexes) {
+	for placed := uint64(0); placed < replicasWanted; {
+		progress := false
+
+		for i, n := range nodes {
+			if placed == replicasWanted {
+				break
+			}
+
+			if !requested.FitsWith(allocated[i], replicas.Capacity[n]) {
+				continue
+			}
+
+			allocated[i] = allocated[i].Add(requested)
+			counts[i]++
+			placed++
+			progress = true
+		}
+
+		if !progress {
+			break
+		}
+	}
+
+	chunks := make([][]uint64, len(nodes))
+	index := uint64(1)
+
+	for i, count := range counts {
+		chunks[i] = make([]uint64, 0, count)
+
+		for c := uint64(0); c < count; c++ {
+			chunks[i] = append(chunks[i], index)
+			index++
+		}
+	}
+
+	return chunks
+}
+
+func Uniform(create [][]uint64, existingIndexes []uint64, cluster []uint64, member uint64) ([]uint64, []uint64) {
+	var destroy = make([]uint64, 0)
+
+	position := slices.Index(cluster, member)
+
+	if position == -1 {
+		return []uint64{}, existingIndexes
+	}
+
+	if len(create[position]) <= len(existingIndexes) {
 		for i, existing := range existingIndexes {
 			preserve := false
 
-			for _, creating := range create[member-1] {
+			for _, creating := range create[position] {
 				if creating == existing {
 					preserve = true
 				}
@@ -150,10 +343,9 @@ func Uniform(replicasWanted uint64, existingIndexes []uint64, cluster []uint64,
 		}
 	}
 
-	return create[member-1], destroy
+	return create[position], destroy
 }
-func Specific(replicasWanted uint64, existingIndexes []uint64, nodes []uint64, member uint64) ([]uint64, []uint64) {
-	var create = make([][]uint64, 0)
+func Specific(create [][]uint64, existingIndexes []uint64, nodes []uint64, member uint64) ([]uint64, []uint64) {
 	var destroy = make([]uint64, 0)
 
 	if slices.Contains(nodes, member) {
@@ -173,13 +365,6 @@ func Specific(replicasWanted uint64, existingIndexes []uint64, nodes []uint64, m
 			}
 		}
 
-		replicas := make([]uint64, replicasWanted)
-		for i := uint64(0); i < replicasWanted; i++ {
-			replicas[i] = i + uint64(1)
-		}
-
-		create = ChunkSlice(replicas, len(nodes))
-
 		if len(create[normalizedMember]) <= len(existingIndexes) {
 			for i, existing := range existingIndexes {
 				preserve := false
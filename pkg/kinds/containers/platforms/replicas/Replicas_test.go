@@ -0,0 +1,90 @@
+package replicas
+
+import (
+	v1 "github.com/simplecontainer/smr/pkg/definitions/v1"
+	"github.com/simplecontainer/smr/pkg/kinds/containers/platforms/limits"
+	"github.com/stretchr/testify/assert"
+	"testing"
+)
+
+func TestGetReplicaNumbers_UnreachableNode(t *testing.T) {
+	d := definition(&v1.ContainersInternal{})
+	placement, err := NewPlacement(d)
+	assert.NoError(t, err)
+
+	nodes := cluster()
+	nodes[2].State.Health.Unreachable = true
+
+	expected := map[uint64][]uint64{1: {1, 2}, 2: {3}, 3: {}}
+
+	for nodeID, indexes := range expected {
+		r := New(nodeID, nodes)
+		create, _ := r.GetReplicaNumbers(d.Spec.Spread, 3, []uint64{}, limits.Quantity{}, placement)
+
+		assert.ElementsMatch(t, indexes, create, "node %d", nodeID)
+	}
+
+	// Once node is reachable again replicas are rebalanced back to it
+	nodes[2].State.Health.Unreachable = false
+
+	r := New(2, nodes)
+	create, destroy := r.GetReplicaNumbers(d.Spec.Spread, 3, []uint64{3}, limits.Quantity{}, placement)
+
+	assert.Equal(t, []uint64{2}, create)
+	assert.Equal(t, []uint64{3}, destroy)
+
+	r = New(3, nodes)
+	create, _ = r.GetReplicaNumbers(d.Spec.Spread, 3, []uint64{}, limits.Quantity{}, placement)
+
+	assert.Equal(t, []uint64{3}, create)
+}
+
+func TestGetReplicaNumbers_Allocated(t *testing.T) {
+	d := definition(&v1.ContainersInternal{})
+	placement, err := NewPlacement(d)
+	assert.NoError(t, err)
+
+	requested := limits.Quantity{CPU: 400, Memory: 256 << 20}
+
+	tests := []struct {
+		name      string
+		allocated map[uint64]limits.Quantity
+		replicas  uint64
+		expected  map[uint64][]uint64
+	}{
+		{
+			name:     "Replicas placed earlier take the capacity",
+			replicas: 7,
+			expected: map[uint64][]uint64{1: {1, 2}, 2: {3, 4}, 3: {5, 6}},
+		},
+		{
+			name:      "Requests of other replicas on the node are subtracted",
+			allocated: map[uint64]limits.Quantity{1: {CPU: 600}, 3: {Memory: 1 << 30}},
+			replicas:  3,
+			expected:  map[uint64][]uint64{1: {1}, 2: {2, 3}, 3: {}},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			nodes := cluster()
+
+			for _, n := range nodes {
+				n.Resources.CPU = 1000
+				n.Resources.Memory = 1 << 30
+			}
+
+			for nodeID, indexes := range tt.expected {
+				r := New(nodeID, nodes)
+
+				if tt.allocated != nil {
+					r.Allocated = tt.allocated
+				}
+
+				create, _ := r.GetReplicaNumbers(d.Spec.Spread, tt.replicas, []uint64{}, requested, placement)
+
+				assert.ElementsMatch(t, indexes, create, "node %d", nodeID)
+			}
+		})
+	}
+}
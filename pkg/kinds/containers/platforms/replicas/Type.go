@@ -1,10 +1,25 @@
 package replicas
 
+import (
+	"github.com/simplecontainer/smr/pkg/kinds/containers/platforms/limits"
+	"github.com/simplecontainer/smr/pkg/selector"
+)
+
 type Replicas struct {
-	NodeID  uint64
-	Create  []uint64
-	Destroy []uint64
-	Cluster []uint64
+	NodeID      uint64
+	Create      []uint64
+	Destroy     []uint64
+	Cluster     []uint64
+	Capacity    map[uint64]limits.Quantity
+	Allocated   map[uint64]limits.Quantity
+	Labels      map[uint64]map[string]string
+	Unreachable []uint64
+}
+
+type Placement struct {
+	Selector     *selector.Selector
+	AntiSelector *selector.Selector
+	OnePerNode   bool
 }
 
 type Distributed struct {
@@ -0,0 +1,225 @@
+package rollout
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	v1 "github.com/simplecontainer/smr/pkg/definitions/v1"
+	"github.com/simplecontainer/smr/pkg/kinds/containers/status"
+	"time"
+)
+
+var (
+	ERROR_INVALID_TYPE       = errors.New("update strategy type must be rolling or recreate")
+	ERROR_INVALID_ON_FAILURE = errors.New("update strategy onFailure must be pause or rollback")
+	ERROR_REPLICA_FAILED     = errors.New("updated replica failed to become ready")
+	ERROR_TIMEOUT            = errors.New("rollout timed out waiting for replicas")
+)
+
+func New(definition *v1.ContainersDefinition) (*Rollout, error) {
+	r := &Rollout{
+		Group:     definition.Meta.Group,
+		Name:      definition.Meta.Name,
+		Type:      TYPE_RECREATE,
+		Replicas:  definition.Spec.Replicas,
+		Timeout:   DEFAULT_TIMEOUT,
+		OnFailure: ON_FAILURE_PAUSE,
+		SpecHash:  SpecHash(definition),
+	}
+
+	strategy := definition.Spec.UpdateStrategy
+
+	if strategy == nil {
+		return r, nil
+	}
+
+	if strategy.Type != "" {
+		r.Type = strategy.Type
+	}
+
+	if strategy.OnFailure != "" {
+		r.OnFailure = strategy.OnFailure
+	}
+
+	switch r.Type {
+	case TYPE_ROLLING, TYPE_RECREATE:
+		break
+	default:
+		return nil, ERROR_INVALID_TYPE
+	}
+
+	switch r.OnFailure {
+	case ON_FAILURE_PAUSE, ON_FAILURE_ROLLBACK:
+		break
+	default:
+		return nil, ERROR_INVALID_ON_FAILURE
+	}
+
+	if strategy.Timeout != "" {
+		timeout, err := time.ParseDuration(strategy.Timeout)
+
+		if err != nil {
+			return nil, err
+		}
+
+		r.Timeout = timeout
+	}
+
+	r.MaxUnavailable = strategy.MaxUnavailable
+	r.MaxSurge = strategy.MaxSurge
+
+	if r.MaxUnavailable == 0 && r.MaxSurge == 0 {
+		r.MaxUnavailable = 1
+	}
+
+	return r, nil
+}
+
+// SpecHash identifies container template so replicas from different applies can be told apart
+func SpecHash(definition *v1.ContainersDefinition) string {
+	template := struct {
+		Labels        map[string]string
+		Spec          v1.ContainersInternal
+		InitContainer *v1.ContainersInternal
+	}{
+		Labels:        definition.Meta.Labels,
+		InitContainer: definition.InitContainer,
+	}
+
+	if definition.Spec != nil {
+		template.Spec = *definition.Spec
+		template.Spec.Replicas = 0
+		template.Spec.UpdateStrategy = nil
+		template.Spec.Autoscale = nil
+
+		// Engine defaults empty tag to latest on the definition itself
+		if template.Spec.Tag == "" {
+			template.Spec.Tag = "latest"
+		}
+	}
+
+	bytes, err := json.Marshal(template)
+
+	if err != nil {
+		return ""
+	}
+
+	sum := sha256.Sum256(bytes)
+	return hex.EncodeToString(sum[:])[:16]
+}
+
+func (r *Rollout) IsRolling() bool {
+	return r.Type == TYPE_ROLLING
+}
+
+func (r *Rollout) BatchSize() uint64 {
+	return r.MaxUnavailable + r.MaxSurge
+}
+
+func (r *Rollout) IsSurge(index uint64) bool {
+	return index > r.Replicas
+}
+
+// Gate decides if replica with the index can be replaced now - batches are ordered by replica index so every node
+// reaches the same decision without coordinating with others
+func (r *Rollout) Gate(replicas []Replica, index uint64) (int8, error) {
+	for _, replica := range replicas {
+		if replica.SpecHash == r.SpecHash && replica.IsFailed() {
+			return ABORT, fmt.Errorf("%w: replica %d is %s", ERROR_REPLICA_FAILED, replica.Index, replica.State)
+		}
+	}
+
+	batch := (index - 1) / r.BatchSize()
+
+	for _, replica := range replicas {
+		if !r.IsSurge(replica.Index) && (replica.Index-1)/r.BatchSize() < batch {
+			if !r.IsUpdated(replica) {
+				return WAIT, nil
+			}
+		}
+	}
+
+	if r.MaxSurge > 0 && r.countSurge(replicas) < r.MaxSurge {
+		return WAIT, nil
+	}
+
+	return PROCEED, nil
+}
+
+// Completed returns true once every desired replica runs the new template
+func (r *Rollout) Completed(replicas []Replica) (bool, error) {
+	for _, replica := range replicas {
+		if replica.SpecHash == r.SpecHash && replica.IsFailed() {
+			return false, fmt.Errorf("%w: replica %d is %s", ERROR_REPLICA_FAILED, replica.Index, replica.State)
+		}
+
+		if !r.IsSurge(replica.Index) && !r.IsUpdated(replica) {
+			return false, nil
+		}
+	}
+
+	return true, nil
+}
+
+func (r *Rollout) IsUpdated(replica Replica) bool {
+	return replica.SpecHash == r.SpecHash && replica.IsReady()
+}
+
+// Wait polls the condition until it is satisfied, fails or the rollout timeout expires
+func (r *Rollout) Wait(condition func() (bool, error)) error {
+	ctx, cancel := context.WithTimeout(r.Ctx, r.Timeout)
+	defer cancel()
+
+	ticker := time.NewTicker(POLL_INTERVAL)
+	defer ticker.Stop()
+
+	for {
+		done, err := condition()
+
+		if err != nil {
+			return err
+		}
+
+		if done {
+			return nil
+		}
+
+		select {
+		case <-ctx.Done():
+			if r.Ctx.Err() != nil {
+				return r.Ctx.Err()
+			}
+
+			return ERROR_TIMEOUT
+		case <-ticker.C:
+		}
+	}
+}
+
+func (r *Rollout) countSurge(replicas []Replica) uint64 {
+	count := uint64(0)
+
+	for _, replica := range replicas {
+		if r.IsSurge(replica.Index) && r.IsUpdated(replica) {
+			count++
+		}
+	}
+
+	return count
+}
+
+func (replica Replica) IsReady() bool {
+	return replica.State == status.RUNNING || replica.State == status.READY
+}
+
+func (replica Replica) IsFailed() bool {
+	switch replica.State {
+	case status.READINESS_FAILED, status.BACKOFF, status.DAEMON_FAILURE, status.INIT_FAILED:
+		return true
+	default:
+		return replica.ReadinessFailed
+	}
+}
@@ -0,0 +1,211 @@
+package rollout
+
+import (
+	"context"
+	"github.com/simplecontainer/smr/pkg/definitions/commonv1"
+	v1 "github.com/simplecontainer/smr/pkg/definitions/v1"
+	"github.com/simplecontainer/smr/pkg/kinds/containers/status"
+	"github.com/stretchr/testify/assert"
+	"testing"
+	"time"
+)
+
+func definition(replicas uint64, strategy *v1.ContainersUpdateStrategy) *v1.ContainersDefinition {
+	return &v1.ContainersDefinition{
+		Meta: &commonv1.Meta{Group: "example", Name: "web"},
+		Spec: &v1.ContainersInternal{
+			Image:          "nginx",
+			Tag:            "1.27",
+			Replicas:       replicas,
+			UpdateStrategy: strategy,
+		},
+	}
+}
+
+func TestNew(t *testing.T) {
+	tests := []struct {
+		name           string
+		strategy       *v1.ContainersUpdateStrategy
+		expectedType   string
+		maxUnavailable uint64
+		expectError    bool
+	}{
+		{name: "No strategy", strategy: nil, expectedType: TYPE_RECREATE},
+		{name: "Rolling defaults", strategy: &v1.ContainersUpdateStrategy{Type: TYPE_ROLLING}, expectedType: TYPE_ROLLING, maxUnavailable: 1},
+		{name: "Rolling with surge only", strategy: &v1.ContainersUpdateStrategy{Type: TYPE_ROLLING, MaxSurge: 1}, expectedType: TYPE_ROLLING, maxUnavailable: 0},
+		{name: "Invalid type", strategy: &v1.ContainersUpdateStrategy{Type: "bluegreen"}, expectError: true},
+		{name: "Invalid on failure", strategy: &v1.ContainersUpdateStrategy{Type: TYPE_ROLLING, OnFailure: "ignore"}, expectError: true},
+		{name: "Invalid timeout", strategy: &v1.ContainersUpdateStrategy{Type: TYPE_ROLLING, Timeout: "soon"}, expectError: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r, err := New(definition(3, tt.strategy))
+
+			if tt.expectError {
+				assert.Error(t, err)
+				return
+			}
+
+			assert.NoError(t, err)
+			assert.Equal(t, tt.expectedType, r.Type)
+			assert.Equal(t, tt.maxUnavailable, r.MaxUnavailable)
+		})
+	}
+}
+
+func TestSpecHash(t *testing.T) {
+	base := definition(3, nil)
+
+	scaled := definition(5, &v1.ContainersUpdateStrategy{Type: TYPE_ROLLING})
+	assert.Equal(t, SpecHash(base), SpecHash(scaled), "replicas and strategy are not part of the template")
+
+	changed := definition(3, nil)
+	changed.Spec.Tag = "1.28"
+	assert.NotEqual(t, SpecHash(base), SpecHash(changed))
+
+	latest := definition(3, nil)
+	latest.Spec.Tag = ""
+	tagged := definition(3, nil)
+	tagged.Spec.Tag = "latest"
+	assert.Equal(t, SpecHash(latest), SpecHash(tagged))
+}
+
+func TestGate(t *testing.T) {
+	const next = "new"
+	const prev = "old"
+
+	tests := []struct {
+		name           string
+		maxUnavailable uint64
+		maxSurge       uint64
+		replicas       []Replica
+		index          uint64
+		expected       int8
+	}{
+		{
+			name:           "First batch proceeds",
+			maxUnavailable: 1,
+			replicas: []Replica{
+				{Index: 1, SpecHash: prev, State: status.RUNNING},
+				{Index: 2, SpecHash: prev, State: status.RUNNING},
+			},
+			index:    1,
+			expected: PROCEED,
+		},
+		{
+			name:           "Second batch waits for first",
+			maxUnavailable: 1,
+			replicas: []Replica{
+				{Index: 1, SpecHash: next, State: status.READINESS_CHECKING},
+				{Index: 2, SpecHash: prev, State: status.RUNNING},
+			},
+			index:    2,
+			expected: WAIT,
+		},
+		{
+			name:           "Second batch proceeds once first is ready",
+			maxUnavailable: 1,
+			replicas: []Replica{
+				{Index: 1, SpecHash: next, State: status.RUNNING},
+				{Index: 2, SpecHash: prev, State: status.RUNNING},
+			},
+			index:    2,
+			expected: PROCEED,
+		},
+		{
+			name:           "Replicas in same batch proceed together",
+			maxUnavailable: 2,
+			replicas: []Replica{
+				{Index: 1, SpecHash: next, State: status.PREPARE},
+				{Index: 2, SpecHash: prev, State: status.RUNNING},
+				{Index: 3, SpecHash: prev, State: status.RUNNING},
+			},
+			index:    2,
+			expected: PROCEED,
+		},
+		{
+			name:     "Surge must be ready before first batch",
+			maxSurge: 1,
+			replicas: []Replica{
+				{Index: 1, SpecHash: prev, State: status.RUNNING},
+				{Index: 2, SpecHash: next, State: status.READINESS_CHECKING},
+			},
+			index:    1,
+			expected: WAIT,
+		},
+		{
+			name:     "Surge ready unlocks first batch",
+			maxSurge: 1,
+			replicas: []Replica{
+				{Index: 1, SpecHash: prev, State: status.RUNNING},
+				{Index: 2, SpecHash: next, State: status.RUNNING},
+			},
+			index:    1,
+			expected: PROCEED,
+		},
+		{
+			name:           "Failed updated replica aborts",
+			maxUnavailable: 1,
+			replicas: []Replica{
+				{Index: 1, SpecHash: next, State: status.KILL, ReadinessFailed: true},
+				{Index: 2, SpecHash: prev, State: status.RUNNING},
+			},
+			index:    2,
+			expected: ABORT,
+		},
+		{
+			name:           "Failed old replica is ignored",
+			maxUnavailable: 1,
+			replicas: []Replica{
+				{Index: 1, SpecHash: prev, State: status.BACKOFF},
+				{Index: 2, SpecHash: prev, State: status.RUNNING},
+			},
+			index:    1,
+			expected: PROCEED,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r := &Rollout{
+				Type:           TYPE_ROLLING,
+				Replicas:       uint64(len(tt.replicas)) - tt.maxSurge,
+				MaxUnavailable: tt.maxUnavailable,
+				MaxSurge:       tt.maxSurge,
+				SpecHash:       next,
+			}
+
+			decision, err := r.Gate(tt.replicas, tt.index)
+
+			assert.Equal(t, tt.expected, decision)
+
+			if tt.expected == ABORT {
+				assert.ErrorIs(t, err, ERROR_REPLICA_FAILED)
+			} else {
+				assert.NoError(t, err)
+			}
+		})
+	}
+}
+
+func TestWait_Timeout(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	r := &Rollout{Timeout: 50 * time.Millisecond, Ctx: ctx, Cancel: cancel}
+
+	err := r.Wait(func() (bool, error) {
+		return false, nil
+	})
+
+	assert.ErrorIs(t, err, ERROR_TIMEOUT)
+
+	cancel()
+
+	err = r.Wait(func() (bool, error) {
+		return false, nil
+	})
+
+	assert.ErrorIs(t, err, context.Canceled)
+}
@@ -0,0 +1,52 @@
+package rollout
+
+import (
+	"context"
+	"time"
+)
+
+type Rollout struct {
+	Group          string
+	Name           string
+	Type           string
+	Replicas       uint64
+	MaxUnavailable uint64
+	MaxSurge       uint64
+	Timeout        time.Duration
+	OnFailure      string
+	SpecHash       string
+	Ctx            context.Context    `json:"-"`
+	Cancel         context.CancelFunc `json:"-"`
+}
+
+// Status of the paused rollout is replicated so every node holds back template changes until it is resumed
+type Status struct {
+	Paused    bool      `json:"paused"`
+	SpecHash  string    `json:"specHash"`
+	Error     string    `json:"error"`
+	Timestamp time.Time `json:"timestamp"`
+}
+
+// Replica is cluster wide view of single replica used for gating the rollout
+type Replica struct {
+	Index           uint64
+	SpecHash        string
+	State           string
+	ReadinessFailed bool
+}
+
+const TYPE_ROLLING = "rolling"
+const TYPE_RECREATE = "recreate"
+
+const ON_FAILURE_PAUSE = "pause"
+const ON_FAILURE_ROLLBACK = "rollback"
+
+const PROCEED = 1
+const WAIT = 2
+const ABORT = 3
+
+const DEFAULT_TIMEOUT = 5 * time.Minute
+const POLL_INTERVAL = 1 * time.Second
+
+// STATE_ROLLOUT is kind segment of the key holding rollout status in the state category
+const STATE_ROLLOUT = "rollout"
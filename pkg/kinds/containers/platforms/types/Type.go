@@ -4,6 +4,7 @@ import (
 	"github.com/simplecontainer/smr/pkg/f"
 	"github.com/simplecontainer/smr/pkg/node"
 	"github.com/simplecontainer/smr/pkg/smaps"
+	"time"
 )
 
 const EVENT_NETWORK_CONNECT = "conn"
@@ -21,6 +22,20 @@ type Runtime struct {
 	ObjectDependencies []f.Format
 	Node               *node.Node
 	NodeName           string
+	Usage              *Usage
+}
+
+// Usage is sampled from the engine, CPU and Memory are utilisation in percents
+type Usage struct {
+	CPU         float64
+	Memory      float64
+	MemoryUsage uint64
+	MemoryLimit uint64
+	NetworkRx   uint64
+	NetworkTx   uint64
+	BlockRead   uint64
+	BlockWrite  uint64
+	SampledAt   time.Time
 }
 
 type ExecResult struct {
@@ -0,0 +1,55 @@
+package reconcile
+
+import (
+	"context"
+	"encoding/json"
+	"github.com/simplecontainer/smr/pkg/events/events"
+	"github.com/simplecontainer/smr/pkg/kinds/containers/platforms/liveness/solver"
+	"github.com/simplecontainer/smr/pkg/kinds/containers/shared"
+	"github.com/simplecontainer/smr/pkg/kinds/containers/status"
+	"github.com/simplecontainer/smr/pkg/kinds/containers/watcher"
+	"go.uber.org/zap"
+	"time"
+)
+
+// handleLiveness keeps probing running container and restarts it through the reconciler once probes fail
+func handleLiveness(shared *shared.Shared, cw *watcher.Container, ctx context.Context) {
+	result := solver.Alive(ctx, shared.Client, cw.Container, cw.User, cw.Logger)
+
+	if result == nil || ctx.Err() != nil || cw.IsDone() {
+		return
+	}
+
+	if !cw.Container.GetStatus().IfStateIs(status.RUNNING) {
+		return
+	}
+
+	next := status.RESTART
+
+	err := shared.Registry.MarkContainerStopped(cw.Container.GetGroup(), cw.Container.GetGeneratedName())
+	if err != nil {
+		cw.Logger.Info("container liveness keeps failing - backoff", zap.Error(err))
+		next = status.BACKOFF
+	}
+
+	cw.Logger.Info("container liveness failed", zap.String("probe", result.Probe), zap.Error(result.Error))
+
+	data, _ := json.Marshal(map[string]string{
+		"probe": result.Probe,
+		"error": result.Error.Error(),
+		"state": next,
+	})
+
+	events.Dispatch(
+		events.NewKindEvent(events.EVENT_LIVENESS_FAILED, cw.Container.GetDefinition(), data).SetName(cw.Container.GetGeneratedName()),
+		shared, cw.Container.GetRuntime().Node.NodeID,
+	)
+
+	err = cw.Container.GetStatus().QueueState(next, time.Now())
+	if err != nil {
+		cw.Logger.Error("failed to queue state", zap.String("state", next), zap.Error(err))
+		return
+	}
+
+	cw.SendToQueue(cw.Container, 5*time.Second)
+}
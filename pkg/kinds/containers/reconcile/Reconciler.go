@@ -33,9 +33,14 @@ func Containers(shared *shared.Shared, containerWatcher *watcher.Container) {
 	// Remember my QueuedAt locally, because containerObj.GetStatus().State can be changed to another state
 	QueuedAt := containerObj.GetStatus().State.QueuedAt
 
-	err := shared.Registry.Sync(containerObj.GetGroup(), containerObj.GetGeneratedName())
-	if err != nil {
-		containerWatcher.Logger.Error(err.Error())
+	var err error
+
+	// State in the registry still belongs to the node running the container
+	if containerObj.GetStatus().State.State != status.TRANSFERING {
+		err = shared.Registry.Sync(containerObj.GetGroup(), containerObj.GetGeneratedName())
+		if err != nil {
+			containerWatcher.Logger.Error(err.Error())
+		}
 	}
 
 	existing := shared.Registry.Find(containerObj.GetDefinition().GetPrefix(), containerObj.GetGroup(), containerObj.GetGeneratedName())
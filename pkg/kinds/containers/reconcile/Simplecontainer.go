@@ -54,10 +54,18 @@ func Reconcile(shared *shared.Shared, cw *watcher.Container, existing platforms.
 }
 
 func handleTransferring(shared *shared.Shared, cw *watcher.Container, existing platforms.IContainer) (string, bool) {
-	if existing != nil && existing.IsGhost() {
-		cw.Logger.Info("container is not dead on another node - wait")
-		return status.TRANSFERING, false
+	remote := shared.Registry.FindRemote(cw.Container.GetDefinition().GetPrefix(), cw.Container.GetGroup(), cw.Container.GetGeneratedName())
+
+	if remote != nil && remote.GetNode() != nil && remote.GetNode().NodeID != shared.Manager.Cluster.Node.NodeID {
+		owner := shared.Manager.Cluster.Cluster.FindById(remote.GetNode().NodeID)
+
+		if owner != nil && owner.Reachable() {
+			cw.Logger.Info("container is not dead on another node - wait")
+			cw.SafeResetTicker(5 * time.Second)
+			return status.TRANSFERING, false
+		}
 	}
+
 	cw.Logger.Info("container transferred on this node")
 	return status.CREATED, true
 }
@@ -74,6 +82,7 @@ func handleCreated(shared *shared.Shared, cw *watcher.Container, existing platfo
 
 func handleClean(shared *shared.Shared, cw *watcher.Container, existing platforms.IContainer) (string, bool) {
 	cw.Logger.Info("container is cleaning old container")
+	cw.LivenessCancel()
 
 	cw.SetAllowPlatformEvents(false)
 	defer func() {
@@ -288,10 +297,20 @@ func handleRunning(shared *shared.Shared, cw *watcher.Container, existing platfo
 	shared.Registry.MarkContainerStarted(cw.Container.GetGroup(), cw.Container.GetGeneratedName())
 	cw.Logger.Info("container is running")
 	cw.SetAllowPlatformEvents(true)
+
+	if len(cw.Container.GetLiveness()) > 0 {
+		cw.LivenessCancel()
+		cw.LivenessCtx, cw.LivenessCancel = context.WithCancel(cw.Ctx)
+
+		go handleLiveness(shared, cw, cw.LivenessCtx)
+	}
+
 	return status.RUNNING, false
 }
 
 func handleKill(shared *shared.Shared, cw *watcher.Container, existing platforms.IContainer) (string, bool) {
+	cw.LivenessCancel()
+
 	if err := cw.Container.Stop(static.SIGTERM); err != nil {
 		if err = cw.Container.Stop(static.SIGKILL); err != nil {
 			cw.Logger.Error(err.Error())
@@ -301,6 +320,8 @@ func handleKill(shared *shared.Shared, cw *watcher.Container, existing platforms
 }
 
 func handleDead(shared *shared.Shared, cw *watcher.Container, existing platforms.IContainer) (string, bool) {
+	cw.LivenessCancel()
+
 	err := shared.Registry.MarkContainerStopped(cw.Container.GetGroup(), cw.Container.GetGeneratedName())
 	if err != nil {
 		return status.BACKOFF, true
@@ -317,6 +338,7 @@ func handleDead(shared *shared.Shared, cw *watcher.Container, existing platforms
 }
 
 func handleDelete(shared *shared.Shared, cw *watcher.Container, existing platforms.IContainer) (string, bool) {
+	cw.LivenessCancel()
 	cw.Done = true
 	cw.Cancel()
 	return "", false
@@ -5,6 +5,7 @@ import (
 	"fmt"
 	"github.com/simplecontainer/smr/pkg/authentication"
 	"github.com/simplecontainer/smr/pkg/clients"
+	"github.com/simplecontainer/smr/pkg/contracts/iformat"
 	"github.com/simplecontainer/smr/pkg/f"
 	"github.com/simplecontainer/smr/pkg/kinds/common"
 	"github.com/simplecontainer/smr/pkg/kinds/containers/platforms"
@@ -73,9 +74,6 @@ func (registry *Registry) FindLocal(group string, name string) platforms.IContai
 }
 
 func (registry *Registry) Find(prefix string, group string, name string) platforms.IContainer {
-	format := f.New(prefix, static.CATEGORY_STATE, static.KIND_CONTAINERS, group, registry.extractName(name), name)
-	obj := objects.New(registry.Client.Clients[registry.User.Username], registry.User)
-
 	registry.ContainersLock.RLock()
 	value, ok := registry.Containers[common.GroupIdentifier(group, name)]
 	registry.ContainersLock.RUnlock()
@@ -83,25 +81,41 @@ func (registry *Registry) Find(prefix string, group string, name string) platfor
 	if ok {
 		return value
 	} else {
-		obj.Find(format)
+		return registry.FindRemote(prefix, group, name)
+	}
+}
 
-		if obj.Exists() {
-			instance, err := containers.NewGhost(obj.GetDefinition())
+// FindRemote returns ghost of the container from the replicated state ignoring local registry
+func (registry *Registry) FindRemote(prefix string, group string, name string) platforms.IContainer {
+	format := f.New(prefix, static.CATEGORY_STATE, static.KIND_CONTAINERS, group, registry.extractName(name), name)
+	obj := objects.New(registry.Client.Clients[registry.User.Username], registry.User)
 
-			if err != nil {
-				logger.Log.Error(err.Error())
-				return nil
-			}
+	obj.Find(format)
 
-			return instance
-		} else {
+	if obj.Exists() {
+		instance, err := containers.NewGhost(obj.GetDefinition())
+
+		if err != nil {
+			logger.Log.Error(err.Error())
 			return nil
 		}
+
+		return instance
+	} else {
+		return nil
 	}
 }
 
 func (registry *Registry) FindGroup(prefix string, group string) []platforms.IContainer {
-	format := f.New(prefix, static.CATEGORY_STATE, static.KIND_CONTAINERS, group)
+	return registry.findMany(f.New(prefix, static.CATEGORY_STATE, static.KIND_CONTAINERS, group))
+}
+
+// FindAll returns ghosts of containers of every group from the replicated state
+func (registry *Registry) FindAll(prefix string) []platforms.IContainer {
+	return registry.findMany(f.New(prefix, static.CATEGORY_STATE, static.KIND_CONTAINERS))
+}
+
+func (registry *Registry) findMany(format iformat.Format) []platforms.IContainer {
 	obj := objects.New(registry.Client.Clients[registry.User.Username], registry.User)
 
 	var result []platforms.IContainer
@@ -56,6 +56,7 @@ func (status *Status) CreateGraph() {
 	status.StateMachine.AddEdge(change, created)
 
 	status.StateMachine.AddEdge(created, change)
+	status.StateMachine.AddEdge(created, transfering)
 	status.StateMachine.AddEdge(created, clean)
 	status.StateMachine.AddEdge(created, prepare)
 	status.StateMachine.AddEdge(created, kill)
@@ -451,3 +452,19 @@ func (status *Status) GetQueueSnapshot() []*State {
 
 	return snapshot
 }
+
+// Update runs fn under the status lock, probes write results concurrently with the reconciler and registry sync
+func (status *Status) Update(fn func()) {
+	status.mu.Lock()
+	defer status.mu.Unlock()
+
+	fn()
+}
+
+// View runs fn under the read lock so results written by probes are read consistently
+func (status *Status) View(fn func()) {
+	status.mu.RLock()
+	defer status.mu.RUnlock()
+
+	fn()
+}
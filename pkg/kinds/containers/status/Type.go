@@ -14,9 +14,13 @@ type Status struct {
 	LastReadiness               bool
 	LastReadinessTimestamp      time.Time
 	LastReadinessStarted        time.Time
+	LastLiveness                bool
+	LastLivenessTimestamp       time.Time
 	LastDependsSolved           bool
 	LastDependsSolvedTimestamp  time.Time
 	LastDependsStartedTimestamp time.Time
+	LastExitCode                int
+	LastExitTimestamp           time.Time
 	StateMachine                gograph.Graph[*State] `json:"-"`
 	LastUpdate                  time.Time
 	mu                          sync.RWMutex `json:"-"` // Mutex for thread safety
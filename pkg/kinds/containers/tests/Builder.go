@@ -16,6 +16,7 @@ import (
 	"github.com/simplecontainer/smr/pkg/dns"
 	"github.com/simplecontainer/smr/pkg/kinds/containers/platforms"
 	"github.com/simplecontainer/smr/pkg/kinds/containers/platforms/image"
+	"github.com/simplecontainer/smr/pkg/kinds/containers/platforms/liveness"
 	"github.com/simplecontainer/smr/pkg/kinds/containers/platforms/readiness"
 	"github.com/simplecontainer/smr/pkg/kinds/containers/platforms/state"
 	"github.com/simplecontainer/smr/pkg/kinds/containers/platforms/types"
@@ -30,6 +31,8 @@ import (
 type MockContainer struct {
 	// Pre-configured fields that bypass mock calls
 	readiness     []*readiness.Readiness
+	liveness      []*liveness.Liveness
+	networks      map[string]net.IP
 	status        *status.Status
 	state         *state.State
 	stateError    error
@@ -46,6 +49,10 @@ func (m *MockContainer) GetReadiness() []*readiness.Readiness {
 	return m.readiness
 }
 
+func (m *MockContainer) GetLiveness() []*liveness.Liveness {
+	return m.liveness
+}
+
 func (m *MockContainer) GetStatus() *status.Status {
 	return m.status
 }
@@ -83,38 +90,42 @@ func (m *MockContainer) PostRun(*configuration.Configuration, *dns.Records) erro
 func (m *MockContainer) InitContainer(*v1.ContainersInternal, *configuration.Configuration, *clients.Http, *authentication.User) error {
 	return nil
 }
-func (m *MockContainer) MountResources() error                         { return nil }
-func (m *MockContainer) UpdateDns(*dns.Records) error                  { return nil }
-func (m *MockContainer) RemoveDns(*dns.Records, string) error          { return nil }
-func (m *MockContainer) SyncNetwork() error                            { return nil }
-func (m *MockContainer) HasDependencyOn(string, string, string) bool   { return false }
-func (m *MockContainer) HasOwner() bool                                { return false }
-func (m *MockContainer) GetEngineState() string                        { return "" }
-func (m *MockContainer) GetNodeName() string                           { return "" }
-func (m *MockContainer) GetId() string                                 { return "" }
-func (m *MockContainer) GetGlobalDefinition() *v1.ContainersDefinition { return nil }
-func (m *MockContainer) GetDefinition() idefinitions.IDefinition       { return m.definition }
-func (m *MockContainer) GetLabels() map[string]string                  { return nil }
-func (m *MockContainer) GetGroupIdentifier() string                    { return "" }
-func (m *MockContainer) GetIndex() (uint64, error)                     { return 0, nil }
-func (m *MockContainer) GetImageState() *image.ImageState              { return nil }
-func (m *MockContainer) GetImageWithTag() string                       { return "" }
-func (m *MockContainer) GetNetwork() map[string]net.IP                 { return nil }
-func (m *MockContainer) GetDomain(string) string                       { return "" }
-func (m *MockContainer) GetHeadlessDomain(string) string               { return "" }
-func (m *MockContainer) GetInit() platforms.IPlatform                  { return nil }
-func (m *MockContainer) GetInitDefinition() *v1.ContainersInternal     { return nil }
-func (m *MockContainer) IsGhost() bool                                 { return false }
-func (m *MockContainer) SetGhost(bool)                                 {}
-func (m *MockContainer) CreateVolume(*v1.VolumeDefinition) error       { return nil }
-func (m *MockContainer) DeleteVolume(string, bool) error               { return nil }
-func (m *MockContainer) Start() error                                  { return nil }
-func (m *MockContainer) Stop(string) error                             { return nil }
-func (m *MockContainer) Kill(string) error                             { return nil }
-func (m *MockContainer) Restart() error                                { return nil }
-func (m *MockContainer) Delete() error                                 { return nil }
-func (m *MockContainer) Wait(string) error                             { return nil }
-func (m *MockContainer) Rename(string) error                           { return nil }
+func (m *MockContainer) MountResources() error                                    { return nil }
+func (m *MockContainer) RefreshSecrets(*clients.Http, *authentication.User) error { return nil }
+func (m *MockContainer) UpdateDns(*dns.Records) error                             { return nil }
+func (m *MockContainer) RemoveDns(*dns.Records, string) error                     { return nil }
+func (m *MockContainer) SyncNetwork() error                                       { return nil }
+func (m *MockContainer) HasDependencyOn(string, string, string) bool              { return false }
+func (m *MockContainer) HasSecret(string, string) bool                            { return false }
+func (m *MockContainer) HasOwner() bool                                           { return false }
+func (m *MockContainer) GetEngineState() string                                   { return "" }
+func (m *MockContainer) GetNodeName() string                                      { return "" }
+func (m *MockContainer) GetId() string                                            { return "" }
+func (m *MockContainer) GetGlobalDefinition() *v1.ContainersDefinition            { return nil }
+func (m *MockContainer) GetDefinition() idefinitions.IDefinition                  { return m.definition }
+func (m *MockContainer) GetSpecHash() string                                      { return "" }
+func (m *MockContainer) GetLabels() map[string]string                             { return nil }
+func (m *MockContainer) GetGroupIdentifier() string                               { return "" }
+func (m *MockContainer) GetIndex() (uint64, error)                                { return 0, nil }
+func (m *MockContainer) GetImageState() *image.ImageState                         { return nil }
+func (m *MockContainer) GetImageWithTag() string                                  { return "" }
+func (m *MockContainer) GetNetwork() map[string]net.IP                            { return m.networks }
+func (m *MockContainer) GetDomain(string) string                                  { return "" }
+func (m *MockContainer) GetHeadlessDomain(string) string                          { return "" }
+func (m *MockContainer) GetInit() platforms.IPlatform                             { return nil }
+func (m *MockContainer) GetInitDefinition() *v1.ContainersInternal                { return nil }
+func (m *MockContainer) GetUsage() (*types.Usage, error)                          { return nil, nil }
+func (m *MockContainer) IsGhost() bool                                            { return false }
+func (m *MockContainer) SetGhost(bool)                                            {}
+func (m *MockContainer) CreateVolume(*v1.VolumeDefinition) error                  { return nil }
+func (m *MockContainer) DeleteVolume(string, bool) error                          { return nil }
+func (m *MockContainer) Start() error                                             { return nil }
+func (m *MockContainer) Stop(string) error                                        { return nil }
+func (m *MockContainer) Kill(string) error                                        { return nil }
+func (m *MockContainer) Restart() error                                           { return nil }
+func (m *MockContainer) Delete() error                                            { return nil }
+func (m *MockContainer) Wait(string) error                                        { return nil }
+func (m *MockContainer) Rename(string) error                                      { return nil }
 func (m *MockContainer) Exec(context.Context, []string, bool, string, string) (string, *bufio.Reader, net.Conn, error) {
 	return "", nil, nil, nil
 }
@@ -190,6 +201,20 @@ func (b *ContainerBuilder) AddReadinessProbe(probe *readiness.Readiness) *Contai
 	return b
 }
 
+func (b *ContainerBuilder) WithLiveness(probes ...*liveness.Liveness) *ContainerBuilder {
+	b.container.liveness = probes
+	return b
+}
+
+func (b *ContainerBuilder) WithNetwork(name string, ip net.IP) *ContainerBuilder {
+	if b.container.networks == nil {
+		b.container.networks = make(map[string]net.IP)
+	}
+
+	b.container.networks[name] = ip
+	return b
+}
+
 func (b *ContainerBuilder) WithRuntime(runtime *types.Runtime) *ContainerBuilder {
 	b.container.runtime = runtime
 	return b
@@ -253,6 +278,21 @@ func (b *ReadinessBuilder) WithCommand(command ...string) *ReadinessBuilder {
 	return b
 }
 
+func (b *ReadinessBuilder) WithTCP(network, port string) *ReadinessBuilder {
+	b.readiness.Network = network
+	b.readiness.Port = port
+	b.readiness.Type = readiness.TYPE_TCP
+	return b
+}
+
+func (b *ReadinessBuilder) WithGRPC(network, port, service string) *ReadinessBuilder {
+	b.readiness.Network = network
+	b.readiness.Port = port
+	b.readiness.Service = service
+	b.readiness.Type = readiness.TYPE_GRPC
+	return b
+}
+
 func (b *ReadinessBuilder) WithTimeout(timeout string) *ReadinessBuilder {
 	b.readiness.Timeout = timeout
 
@@ -30,6 +30,14 @@ func (m *MockRegistry) Find(prefix string, group string, name string) platforms.
 	return args.Get(0).(platforms.IContainer)
 }
 
+func (m *MockRegistry) FindRemote(prefix string, group string, name string) platforms.IContainer {
+	args := m.Called(prefix, group, name)
+	if args.Get(0) == nil {
+		return nil
+	}
+	return args.Get(0).(platforms.IContainer)
+}
+
 func (m *MockRegistry) FindGroup(prefix string, group string) []platforms.IContainer {
 	args := m.Called(prefix, group)
 	if args.Get(0) == nil {
@@ -38,6 +46,14 @@ func (m *MockRegistry) FindGroup(prefix string, group string) []platforms.IConta
 	return args.Get(0).([]platforms.IContainer)
 }
 
+func (m *MockRegistry) FindAll(prefix string) []platforms.IContainer {
+	args := m.Called(prefix)
+	if args.Get(0) == nil {
+		return []platforms.IContainer{}
+	}
+	return args.Get(0).([]platforms.IContainer)
+}
+
 func (m *MockRegistry) FindLocal(group string, name string) platforms.IContainer {
 	args := m.Called(group, name)
 	if args.Get(0) == nil {
@@ -31,6 +31,8 @@ type Container struct {
 	ReconcileCancel     context.CancelFunc             `json:"-"`
 	ChecksCtx           context.Context                `json:"-"`
 	ChecksCancel        context.CancelFunc             `json:"-"`
+	LivenessCtx         context.Context                `json:"-"`
+	LivenessCancel      context.CancelFunc             `json:"-"`
 	Ticker              *time.Ticker                   `json:"-"`
 	Retry               int                            `json:"-"`
 	Logger              *zap.Logger
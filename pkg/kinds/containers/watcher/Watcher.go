@@ -21,6 +21,7 @@ func New(containerObj platforms.IContainer, startState string, user *authenticat
 	ctx, fn := context.WithCancel(context.Background())
 	rctx, rfn := context.WithCancel(context.Background())
 	cctx, cfn := context.WithCancel(context.Background())
+	lctx, lfn := context.WithCancel(ctx)
 
 	format := f.New(containerObj.GetDefinition().GetPrefix(), "kind", static.KIND_CONTAINERS, containerObj.GetGroup(), containerObj.GetGeneratedName())
 	path := fmt.Sprintf("/tmp/%s", strings.Replace(format.ToString(), "/", "-", -1))
@@ -45,6 +46,8 @@ func New(containerObj platforms.IContainer, startState string, user *authenticat
 		ReconcileCancel:     rfn,
 		ChecksCtx:           cctx,
 		ChecksCancel:        cfn,
+		LivenessCtx:         lctx,
+		LivenessCancel:      lfn,
 		Ticker:              time.NewTicker(interval),
 		Retry:               0,
 		Logger:              loggerObj,
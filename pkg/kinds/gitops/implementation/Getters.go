@@ -10,6 +10,7 @@ import (
 	"os"
 	"path/filepath"
 	"strings"
+	"time"
 )
 
 func (gitops *Gitops) GetDefinition() idefinitions.IDefinition {
@@ -26,6 +27,14 @@ func (gitops *Gitops) GetDirectory() string { return gitops.Gitops.DirectoryPath
 
 func (gitops *Gitops) GetForceSync() bool { return gitops.Gitops.ForceSync }
 
+func (gitops *Gitops) GetPrune() bool { return gitops.Gitops.Prune }
+
+func (gitops *Gitops) GetOrphans() []*Orphan { return gitops.Gitops.Orphans }
+
+func (gitops *Gitops) GetWaves() *Waves { return gitops.Gitops.Waves }
+
+func (gitops *Gitops) GetWaveTimeout() time.Duration { return gitops.Gitops.WaveTimeout }
+
 func (gitops *Gitops) GetForceClone() bool { return gitops.Gitops.ForceClone }
 
 func (gitops *Gitops) GetName() string {
@@ -37,15 +37,55 @@ func New(definition *v1.GitopsDefinition, config *configuration.Configuration) (
 		duration = time.Second * 360
 	}
 
+	timeout, err := time.ParseDuration(definition.Spec.WaveTimeout)
+
+	if err != nil || timeout <= 0 {
+		timeout = DEFAULT_WAVE_TIMEOUT
+	}
+
 	var git *internal.Git
 	git, err = internal.NewGit(definition, logpath)
 	if err != nil {
 		return nil, err
 	}
 
+	overlays := make([]*Overlay, 0, len(definition.Spec.Overlays))
+
+	for _, overlay := range definition.Spec.Overlays {
+		clone := git
+		base := overlay.RepoURL == "" || overlay.RepoURL == definition.Spec.RepoURL
+
+		if !base || (overlay.Revision != "" && overlay.Revision != definition.Spec.Revision) {
+			source := *overlay
+
+			if source.RepoURL == "" {
+				source.RepoURL = definition.Spec.RepoURL
+			}
+
+			// Credentials of the base are never sent to other repository
+			auth := internal.NewAuth()
+
+			if base && overlay.CertKeyRef == nil && overlay.HttpAuthRef == nil {
+				auth = git.Auth
+			}
+
+			clone, err = internal.NewOverlay(definition, &source, logpath, auth)
+			if err != nil {
+				return nil, err
+			}
+		}
+
+		overlays = append(overlays, &Overlay{
+			Git:           clone,
+			Definition:    overlay,
+			DirectoryPath: helpers.GetSanitizedDirectoryPath(overlay.DirectoryPath),
+		})
+	}
+
 	gitops := &Gitops{
 		Gitops: &GitopsInternal{
 			Git:             git,
+			Overlays:        overlays,
 			LogPath:         logpath,
 			Group:           definition.Meta.Group,
 			Name:            definition.Meta.Name,
@@ -53,6 +93,10 @@ func New(definition *v1.GitopsDefinition, config *configuration.Configuration) (
 			DirectoryPath:   helpers.GetSanitizedDirectoryPath(definition.Spec.DirectoryPath),
 			PoolingInterval: duration,
 			AutomaticSync:   definition.Spec.AutomaticSync,
+			Prune:           definition.Spec.Prune,
+			Orphans:         make([]*Orphan, 0),
+			Waves:           &Waves{Waves: make([]int, 0)},
+			WaveTimeout:     timeout,
 			Pack:            packer.New(),
 			Node:            node.NewNodeDefinition(config.KVStore.Cluster, config.KVStore.Node.NodeID),
 			Commit: &object.Commit{
@@ -86,6 +130,11 @@ func (gitops *Gitops) Commit(logger *zap.Logger, client *clients.Http, user *aut
 
 	for _, def := range gitops.Gitops.Pack.Definitions {
 		if def.Definition.Definition.IsOf(commit.Clone.Definition) {
+			// Writing it back to the base would bake the overlay into every environment
+			if def.Layer > 0 {
+				return errors.New("definition is changed by the overlay, commit to the overlay instead")
+			}
+
 			var bytes []byte
 			bytes, err = commit.ApplyPatch(def.Definition.Definition)
 
@@ -122,13 +171,20 @@ func (gitops *Gitops) Commit(logger *zap.Logger, client *clients.Http, user *aut
 	return errors.New("definition not found")
 }
 
-func (gitops *Gitops) Sync(logger *zap.Logger, client *clients.Http, user *authentication.User) ([]*common.Request, []error) {
+// Sync proposes definitions of the pack which belong to the wave, rest of the definitions are left for the next waves
+func (gitops *Gitops) Sync(logger *zap.Logger, client *clients.Http, user *authentication.User, wave int) ([]*common.Request, []error) {
 	var requests = make([]*common.Request, 0)
 	var errs = make([]error, 0)
 
 	index := 0
 
 	for k, request := range gitops.Gitops.Pack.Definitions {
+		if current, _ := Wave(request.Definition.Definition); current != wave {
+			gitops.Gitops.Pack.Definitions[index] = request
+			index++
+			continue
+		}
+
 		logger.Info("syncing object", zap.String("object", request.Definition.Definition.GetMeta().Name))
 
 		request.Definition.Definition.GetRuntime().SetOwner(static.KIND_GITOPS, gitops.GetDefinition().GetMeta().Group, gitops.GetDefinition().GetMeta().Name)
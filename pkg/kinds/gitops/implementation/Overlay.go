@@ -0,0 +1,50 @@
+package implementation
+
+import (
+	"fmt"
+)
+
+// GetLayers returns directory of the base followed by the overlays in the order they are applied
+func (gitops *Gitops) GetLayers() []Layer {
+	layers := []Layer{{
+		Repository: gitops.Gitops.Git.Repository,
+		Directory:  fmt.Sprintf("%s/%s", gitops.Gitops.Git.Directory, gitops.Gitops.DirectoryPath),
+	}}
+
+	for _, overlay := range gitops.Gitops.Overlays {
+		layers = append(layers, Layer{
+			Repository: overlay.Git.Repository,
+			Directory:  fmt.Sprintf("%s/%s", overlay.Git.Directory, overlay.DirectoryPath),
+		})
+	}
+
+	return layers
+}
+
+// GetDirectories returns directories of the layers as expected by the packer
+func (gitops *Gitops) GetDirectories() []string {
+	directories := make([]string, 0)
+
+	for _, layer := range gitops.GetLayers() {
+		directories = append(directories, layer.Directory)
+	}
+
+	return directories
+}
+
+// FetchOverlays pulls overlays cloned separately from the base, changes are picked up when the pack is read again
+func (gitops *Gitops) FetchOverlays() error {
+	for _, overlay := range gitops.Gitops.Overlays {
+		if overlay.Git == gitops.Gitops.Git {
+			continue
+		}
+
+		_, err := overlay.Git.Fetch()
+
+		if err != nil {
+			return fmt.Errorf("failed to fetch overlay %s: %w", overlay.Git.Repository, err)
+		}
+	}
+
+	return nil
+}
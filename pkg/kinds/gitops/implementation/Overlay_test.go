@@ -0,0 +1,120 @@
+package implementation
+
+import (
+	"fmt"
+	"github.com/go-git/go-git/v5"
+	"github.com/go-git/go-git/v5/config"
+	"github.com/go-git/go-git/v5/plumbing/object"
+	"github.com/simplecontainer/smr/pkg/configuration"
+	"github.com/simplecontainer/smr/pkg/definitions/commonv1"
+	v1 "github.com/simplecontainer/smr/pkg/definitions/v1"
+	"github.com/simplecontainer/smr/pkg/node"
+	"github.com/simplecontainer/smr/pkg/packer"
+	"github.com/simplecontainer/smr/pkg/relations"
+	"github.com/stretchr/testify/assert"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+// repository pushes files to the new local bare repository and returns its path
+func repository(t *testing.T, files map[string]string) string {
+	bare := t.TempDir()
+	work := t.TempDir()
+
+	_, err := git.PlainInit(bare, true)
+	assert.NoError(t, err)
+
+	repo, err := git.PlainInit(work, false)
+	assert.NoError(t, err)
+
+	_, err = repo.CreateRemote(&config.RemoteConfig{Name: "origin", URLs: []string{bare}})
+	assert.NoError(t, err)
+
+	for name, content := range files {
+		path := filepath.Join(work, name)
+
+		assert.NoError(t, os.MkdirAll(filepath.Dir(path), 0755))
+		assert.NoError(t, os.WriteFile(path, []byte(content), 0644))
+	}
+
+	worktree, err := repo.Worktree()
+	assert.NoError(t, err)
+
+	_, err = worktree.Add(".")
+	assert.NoError(t, err)
+
+	_, err = worktree.Commit("initial", &git.CommitOptions{Author: &object.Signature{Name: "test", Email: "test@example.com", When: time.Now()}})
+	assert.NoError(t, err)
+
+	assert.NoError(t, repo.Push(&git.PushOptions{RemoteName: "origin"}))
+
+	return bare
+}
+
+func TestOverlays(t *testing.T) {
+	app := repository(t, map[string]string{
+		"base/Pack.yaml":                               "name: app\nversion: 0.0.1\n",
+		"base/definitions/variables.yaml":              "environment: dev\nreplicas: 1\n",
+		"base/definitions/app.yaml":                    "kind: configuration\nprefix: simplecontainer.io/v1\nmeta:\n  group: app\n  name: settings\nspec:\n  data:\n    environment: \"{{ .environment }}\"\n    replicas: \"{{ .replicas }}\"\n",
+		"environments/prod/definitions/variables.yaml": "environment: prod\n",
+	})
+
+	shared := repository(t, map[string]string{
+		"patches/settings.yaml": "target:\n  kind: configuration\n  name: settings\nmerge:\n  spec:\n    data:\n      replicas: \"3\"\n",
+	})
+
+	definition := &v1.GitopsDefinition{
+		Kind:   "gitops",
+		Prefix: "simplecontainer.io/v1",
+		Meta:   &commonv1.Meta{Group: "test", Name: fmt.Sprintf("overlays-%d", time.Now().UnixNano()), Runtime: &commonv1.Runtime{}},
+		Spec: &v1.GitopsSpec{
+			RepoURL:       app,
+			Revision:      "master",
+			DirectoryPath: "base",
+			Overlays: []*v1.GitopsOverlay{
+				{DirectoryPath: "environments/prod"},
+				{RepoURL: shared},
+			},
+		},
+	}
+
+	t.Cleanup(func() {
+		os.RemoveAll(filepath.Join("/tmp/gitops", definition.Meta.Group, definition.Meta.Name))
+	})
+
+	gitops, err := New(definition, &configuration.Configuration{KVStore: &configuration.KVStore{Node: &node.Node{}}})
+	assert.NoError(t, err)
+
+	assert.NoError(t, os.WriteFile(gitops.Gitops.LogPath, nil, 0644))
+	t.Cleanup(func() {
+		os.Remove(gitops.Gitops.LogPath)
+	})
+
+	// Overlay of the same repository and revision reuses the base clone
+	assert.Same(t, gitops.GetGit(), gitops.Gitops.Overlays[0].Git)
+	assert.NotSame(t, gitops.GetGit(), gitops.Gitops.Overlays[1].Git)
+
+	// Overlay of other repository doesn't get credentials of the base and its clone doesn't depend on the position
+	assert.NotSame(t, gitops.GetGit().Auth, gitops.Gitops.Overlays[1].Git.Auth)
+
+	definition.Spec.Overlays = []*v1.GitopsOverlay{{RepoURL: shared}, {DirectoryPath: "environments/prod"}}
+
+	reordered, err := New(definition, &configuration.Configuration{KVStore: &configuration.KVStore{Node: &node.Node{}}})
+	assert.NoError(t, err)
+	assert.Equal(t, gitops.Gitops.Overlays[1].Git.Directory, reordered.Gitops.Overlays[0].Git.Directory)
+
+	assert.NoError(t, gitops.GetGit().Clone())
+	assert.NoError(t, gitops.FetchOverlays())
+
+	pack, err := packer.ReadLayers(gitops.GetDirectories(), nil, relations.NewDefinitionRelationRegistry())
+	assert.NoError(t, err)
+	assert.Len(t, pack.Definitions, 1)
+	assert.Equal(t, 2, pack.Definitions[0].Layer)
+
+	bytes, err := pack.Definitions[0].Definition.Definition.ToJSON()
+	assert.NoError(t, err)
+	assert.Contains(t, string(bytes), `"environment":"prod"`)
+	assert.Contains(t, string(bytes), `"replicas":"3"`)
+}
@@ -4,7 +4,9 @@ import (
 	"errors"
 	"github.com/simplecontainer/smr/pkg/authentication"
 	"github.com/simplecontainer/smr/pkg/clients"
+	"github.com/simplecontainer/smr/pkg/contracts/idefinitions"
 	"github.com/simplecontainer/smr/pkg/definitions/v1"
+	"github.com/simplecontainer/smr/pkg/kinds/gitops/implementation/internal"
 	"github.com/simplecontainer/smr/pkg/objects"
 	"github.com/simplecontainer/smr/pkg/static"
 )
@@ -17,12 +19,33 @@ func (gitops *Gitops) Prepare(client *clients.Http, user *authentication.User) e
 		return err
 	}
 
+	// Overlays sharing the clone or the credentials of the base are authenticated with it
+	for _, overlay := range gitops.Gitops.Overlays {
+		if overlay.Git.Auth == gitops.Gitops.Git.Auth || overlay.Definition == nil {
+			continue
+		}
+
+		overlayReferences, err := overlay.Definition.ResolveReferences(obj)
+
+		if err != nil {
+			return err
+		}
+
+		if err = authenticate(overlay.Git.Auth, overlayReferences); err != nil {
+			return err
+		}
+	}
+
+	return authenticate(gitops.Gitops.Git.Auth, references)
+}
+
+func authenticate(auth *internal.Auth, references []idefinitions.IDefinition) error {
 	for _, reference := range references {
 		switch reference.GetKind() {
 		case static.KIND_HTTPAUTH:
-			return gitops.Gitops.Git.Auth.Http(reference.(*v1.HttpAuthDefinition))
+			return auth.Http(reference.(*v1.HttpAuthDefinition))
 		case static.KIND_CERTKEY:
-			return gitops.Gitops.Git.Auth.Ssh(reference.(*v1.CertKeyDefinition))
+			return auth.Ssh(reference.(*v1.CertKeyDefinition))
 		default:
 			return errors.New("reference kind is not implemented for this type of object")
 		}
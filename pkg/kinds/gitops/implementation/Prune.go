@@ -0,0 +1,150 @@
+package implementation
+
+import (
+	"fmt"
+	"github.com/simplecontainer/smr/pkg/authentication"
+	"github.com/simplecontainer/smr/pkg/clients"
+	"github.com/simplecontainer/smr/pkg/contracts/idefinitions"
+	"github.com/simplecontainer/smr/pkg/definitions/commonv1"
+	"github.com/simplecontainer/smr/pkg/f"
+	"github.com/simplecontainer/smr/pkg/kinds/common"
+	"github.com/simplecontainer/smr/pkg/objects"
+	"github.com/simplecontainer/smr/pkg/relations"
+	"github.com/simplecontainer/smr/pkg/static"
+	"go.uber.org/zap"
+	"slices"
+	"sort"
+)
+
+// FindOrphans lists objects of the kinds owned by the gitops that are not part of the pack at the current commit,
+// dependents come before their dependencies so they can be removed in order
+func (gitops *Gitops) FindOrphans(client *clients.Http, user *authentication.User, kinds []string, relations *relations.RelationRegistry) ([]*common.Request, error) {
+	orphans := make([]*common.Request, 0)
+
+	// Pack that wasn't read would make every owned object look orphaned
+	if gitops.Gitops.Pack == nil || len(gitops.Gitops.Pack.Definitions) == 0 {
+		return orphans, nil
+	}
+
+	owner := &commonv1.Owner{Kind: static.KIND_GITOPS, Group: gitops.GetGroup(), Name: gitops.GetName()}
+	obj := objects.New(client.Clients[user.Username], user)
+
+	for _, kind := range kinds {
+		objs, err := obj.FindMany(f.New(static.SMR_PREFIX, static.CATEGORY_KIND, kind))
+
+		if err != nil {
+			return nil, err
+		}
+
+		for _, o := range objs {
+			request, err := common.NewRequestFromJson(kind, o.GetDefinitionByte())
+
+			if err != nil {
+				continue
+			}
+
+			definition := request.Definition
+
+			if definition.GetRuntime() == nil || definition.GetRuntime().GetOwner() == nil || !definition.GetRuntime().GetOwner().IsEqual(owner) {
+				continue
+			}
+
+			if !gitops.defines(definition) {
+				orphans = append(orphans, request)
+			}
+		}
+	}
+
+	sort.Slice(orphans, func(i, j int) bool {
+		left, right := depth(orphans[i].Definition.GetKind(), relations, nil), depth(orphans[j].Definition.GetKind(), relations, nil)
+
+		if left != right {
+			return left > right
+		}
+
+		return identifier(orphans[i].Definition) < identifier(orphans[j].Definition)
+	})
+
+	return orphans, nil
+}
+
+// Inspect remembers orphans so they are shown by smrctl gitops definitions even when prune is disabled
+func (gitops *Gitops) Inspect(client *clients.Http, user *authentication.User, kinds []string, relations *relations.RelationRegistry) error {
+	orphans, err := gitops.FindOrphans(client, user, kinds, relations)
+
+	if err != nil {
+		return err
+	}
+
+	gitops.Gitops.Orphans = make([]*Orphan, 0, len(orphans))
+
+	for _, request := range orphans {
+		gitops.Gitops.Orphans = append(gitops.Gitops.Orphans, &Orphan{
+			Kind:  request.Definition.GetKind(),
+			Group: request.Definition.GetMeta().Group,
+			Name:  request.Definition.GetMeta().Name,
+		})
+	}
+
+	return nil
+}
+
+// Prune removes orphans, they are looked up again so objects taken over or re-added since inspection are kept
+func (gitops *Gitops) Prune(logger *zap.Logger, client *clients.Http, user *authentication.User, kinds []string, relations *relations.RelationRegistry) []error {
+	errs := make([]error, 0)
+
+	if !gitops.GetPrune() {
+		return errs
+	}
+
+	orphans, err := gitops.FindOrphans(client, user, kinds, relations)
+
+	if err != nil {
+		return append(errs, err)
+	}
+
+	for _, request := range orphans {
+		err = request.ProposeRemove(client.Clients[user.Username].Http, client.Clients[user.Username].API)
+
+		if err != nil {
+			errs = append(errs, fmt.Errorf("failed to prune %s: %w", identifier(request.Definition), err))
+			continue
+		}
+
+		logger.Info("object pruned", zap.String("object", identifier(request.Definition)))
+	}
+
+	gitops.Gitops.Orphans = make([]*Orphan, 0)
+
+	return errs
+}
+
+func (gitops *Gitops) defines(definition idefinitions.IDefinition) bool {
+	for _, request := range gitops.Gitops.Pack.Definitions {
+		if request.Definition.Definition.IsOf(definition) {
+			return true
+		}
+	}
+
+	return false
+}
+
+// depth is the length of the longest dependency chain of the kind, dependent kind is always deeper than the kinds
+// it depends on
+func depth(kind string, relations *relations.RelationRegistry, visited []string) int {
+	if relations == nil || slices.Contains(visited, kind) {
+		return 0
+	}
+
+	deepest := 0
+
+	for _, dependency := range relations.GetDependencies(kind) {
+		deepest = max(deepest, depth(dependency, relations, append(visited, kind))+1)
+	}
+
+	return deepest
+}
+
+func identifier(definition idefinitions.IDefinition) string {
+	return fmt.Sprintf("%s/%s/%s", definition.GetKind(), definition.GetMeta().Group, definition.GetMeta().Name)
+}
@@ -0,0 +1,80 @@
+package implementation
+
+import (
+	"encoding/json"
+	"fmt"
+	"github.com/simplecontainer/smr/pkg/authentication"
+	"github.com/simplecontainer/smr/pkg/clients"
+	"github.com/simplecontainer/smr/pkg/kinds/common"
+	"github.com/simplecontainer/smr/pkg/logger"
+	"github.com/simplecontainer/smr/pkg/packer"
+	"github.com/simplecontainer/smr/pkg/relations"
+	"github.com/simplecontainer/smr/pkg/static"
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/zap"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+func owned(kind string, name string, owner string) []byte {
+	return []byte(fmt.Sprintf(`{"kind":"%s","prefix":"simplecontainer.io","meta":{"group":"app","name":"%s","runtime":{"owner":{"kind":"gitops","group":"infra","name":"%s"}}},"spec":{}}`, kind, name, owner))
+}
+
+func TestFindOrphans(t *testing.T) {
+	logger.Log = zap.NewNop()
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		objects := make([]json.RawMessage, 0)
+
+		switch {
+		case strings.HasSuffix(r.URL.Path, "/"+static.KIND_CONFIGURATION):
+			objects = append(objects, owned(static.KIND_CONFIGURATION, "kept", "repo"), owned(static.KIND_CONFIGURATION, "removed", "repo"), owned(static.KIND_CONFIGURATION, "foreign", "other"))
+		case strings.HasSuffix(r.URL.Path, "/"+static.KIND_SECRET):
+			objects = append(objects, owned(static.KIND_SECRET, "password", "repo"))
+		case strings.HasSuffix(r.URL.Path, "/"+static.KIND_ROLE):
+			objects = append(objects, owned(static.KIND_ROLE, "viewer", "repo"))
+		case strings.HasSuffix(r.URL.Path, "/"+static.KIND_ROLEBINDING):
+			objects = append(objects, owned(static.KIND_ROLEBINDING, "viewer", "repo"))
+		}
+
+		data, _ := json.Marshal(objects)
+		json.NewEncoder(w).Encode(common.Response(http.StatusOK, "", nil, data))
+	}))
+	defer server.Close()
+
+	user := &authentication.User{Username: "root"}
+	client := &clients.Http{Clients: map[string]*clients.Client{"root": {Http: server.Client(), API: server.URL}}}
+
+	kept, err := common.NewRequestFromJson(static.KIND_CONFIGURATION, owned(static.KIND_CONFIGURATION, "kept", "repo"))
+	assert.NoError(t, err)
+
+	registry := relations.NewDefinitionRelationRegistry()
+	registry.InTree()
+
+	tests := []struct {
+		name     string
+		pack     *packer.Pack
+		expected []string
+	}{
+		{"Owned object missing from the pack", &packer.Pack{Definitions: []*packer.Definition{{Definition: kept}}}, []string{"configuration/app/removed", "rolebinding/app/viewer", "role/app/viewer", "secret/app/password"}},
+		{"Pack that wasn't read", packer.New(), []string{}},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			gitops := &Gitops{Gitops: &GitopsInternal{Group: "infra", Name: "repo", Pack: tc.pack}}
+
+			orphans, err := gitops.FindOrphans(client, user, []string{static.KIND_CONFIGURATION, static.KIND_ROLE, static.KIND_ROLEBINDING, static.KIND_SECRET}, registry)
+			assert.NoError(t, err)
+
+			names := make([]string, 0)
+			for _, orphan := range orphans {
+				names = append(names, identifier(orphan.Definition))
+			}
+
+			assert.Equal(t, tc.expected, names)
+		})
+	}
+}
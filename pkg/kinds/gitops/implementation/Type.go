@@ -1,6 +1,7 @@
 package implementation
 
 import (
+	"github.com/go-git/go-git/v5/plumbing"
 	"github.com/go-git/go-git/v5/plumbing/object"
 	"github.com/simplecontainer/smr/pkg/contracts/iformat"
 	"github.com/simplecontainer/smr/pkg/definitions"
@@ -12,6 +13,9 @@ import (
 	"time"
 )
 
+const LABEL_WAVE = "sync-wave"
+const DEFAULT_WAVE_TIMEOUT = 10 * time.Minute
+
 type Gitops struct {
 	Gitops     *GitopsInternal
 	Definition *v1.GitopsDefinition
@@ -19,6 +23,7 @@ type Gitops struct {
 
 type GitopsInternal struct {
 	Git             *internal.Git
+	Overlays        []*Overlay
 	Node            *node.Node
 	Group           string
 	Name            string
@@ -29,6 +34,10 @@ type GitopsInternal struct {
 	LastPoll        time.Time
 	ForceClone      bool
 	AutomaticSync   bool
+	Prune           bool
+	Orphans         []*Orphan
+	Waves           *Waves
+	WaveTimeout     time.Duration
 	ForceSync       bool
 	Commit          *object.Commit
 	Status          *status.Status
@@ -38,6 +47,35 @@ type GitopsInternal struct {
 	Ghost           bool
 }
 
+// Orphan is object owned by the gitops which is not defined at the current commit anymore
+type Orphan struct {
+	Kind  string
+	Group string
+	Name  string
+}
+
+// Waves track progress of the sync split by the sync-wave label of the definitions
+type Waves struct {
+	Waves   []int
+	Current int
+	Active  bool
+	Commit  plumbing.Hash
+	Started time.Time
+}
+
+// Overlay is directory layered over the base, overlays from the base repository and revision share its clone
+type Overlay struct {
+	Git           *internal.Git
+	Definition    *v1.GitopsOverlay
+	DirectoryPath string
+}
+
+// Layer is directory of the base or overlay together with the repository it comes from
+type Layer struct {
+	Repository string
+	Directory  string
+}
+
 type Auth struct {
 	CertKeyRef  *v1.GitopsCertKeyRef
 	HttpAuthRef *v1.GitopsHttpauthRef
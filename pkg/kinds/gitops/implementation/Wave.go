@@ -0,0 +1,200 @@
+package implementation
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"github.com/simplecontainer/smr/pkg/authentication"
+	"github.com/simplecontainer/smr/pkg/clients"
+	"github.com/simplecontainer/smr/pkg/contracts/idefinitions"
+	v1 "github.com/simplecontainer/smr/pkg/definitions/v1"
+	"github.com/simplecontainer/smr/pkg/f"
+	"github.com/simplecontainer/smr/pkg/kinds/containers/platforms/containers"
+	"github.com/simplecontainer/smr/pkg/kinds/containers/platforms/rollout"
+	"github.com/simplecontainer/smr/pkg/kinds/containers/status"
+	"github.com/simplecontainer/smr/pkg/objects"
+	"github.com/simplecontainer/smr/pkg/static"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+)
+
+var ERROR_INVALID_WAVE = errors.New("invalid sync wave")
+var ERROR_WAVE_FAILED = errors.New("sync wave failed")
+var ERROR_WAVE_TIMEOUT = errors.New("sync wave timed out")
+
+// Wave of the definition is read from the sync-wave label, definitions without the label belong to the wave 0
+func Wave(definition idefinitions.IDefinition) (int, error) {
+	value, ok := definition.GetMeta().GetLabel(LABEL_WAVE)
+
+	if !ok {
+		return 0, nil
+	}
+
+	wave, err := strconv.Atoi(strings.TrimSpace(value))
+
+	if err != nil {
+		return 0, fmt.Errorf("%w: %s has %s=%s", ERROR_INVALID_WAVE, identifier(definition), LABEL_WAVE, value)
+	}
+
+	return wave, nil
+}
+
+// Plan orders distinct waves of the pack from the lowest so sync of the commit starts from the first one
+func (gitops *Gitops) Plan() error {
+	waves := make([]int, 0)
+	seen := make(map[int]bool)
+
+	for _, request := range gitops.Gitops.Pack.Definitions {
+		wave, err := Wave(request.Definition.Definition)
+
+		if err != nil {
+			return err
+		}
+
+		if !seen[wave] {
+			seen[wave] = true
+			waves = append(waves, wave)
+		}
+	}
+
+	sort.Ints(waves)
+
+	gitops.Gitops.Waves = &Waves{
+		Waves:   waves,
+		Current: 0,
+		Active:  true,
+		Commit:  gitops.Gitops.Commit.Hash,
+		Started: time.Now(),
+	}
+
+	return nil
+}
+
+// Planned is true while waves of the current commit are being synced
+func (gitops *Gitops) Planned() bool {
+	return gitops.Gitops.Waves != nil && gitops.Gitops.Waves.Active && gitops.Gitops.Waves.Commit == gitops.Gitops.Commit.Hash
+}
+
+// Ready returns containers of the current wave that are not ready yet, replicas left from the previous spec are
+// ignored and containers that exited with success since the wave was proposed count as completed jobs, exit with
+// error fails the wave
+func (gitops *Gitops) Ready(client *clients.Http, user *authentication.User) ([]string, error) {
+	waiting := make([]string, 0)
+	obj := objects.New(client.Clients[user.Username], user)
+
+	for _, request := range gitops.Gitops.Pack.Definitions {
+		definition := request.Definition.Definition
+
+		if definition.GetKind() != static.KIND_CONTAINERS || definition.GetState().GetOpt("action").Value == static.REMOVE_KIND {
+			continue
+		}
+
+		if wave, _ := Wave(definition); wave != gitops.Gitops.Waves.GetWave() {
+			continue
+		}
+
+		group, name := definition.GetMeta().Group, definition.GetMeta().Name
+
+		// Applied definition is used since admission could have patched the one from the repository
+		applied := objects.New(client.Clients[user.Username], user)
+		applied.Find(f.New(definition.GetPrefix(), static.CATEGORY_KIND, static.KIND_CONTAINERS, group, name))
+
+		if !applied.Exists() {
+			waiting = append(waiting, identifier(definition))
+			continue
+		}
+
+		current := &v1.ContainersDefinition{}
+
+		if err := json.Unmarshal(applied.GetDefinitionByte(), current); err != nil {
+			return nil, err
+		}
+
+		hash := rollout.SpecHash(current)
+		replicas := 0
+
+		objs, _ := obj.FindMany(f.New(definition.GetPrefix(), static.CATEGORY_STATE, static.KIND_CONTAINERS, group, name))
+
+		for _, o := range objs {
+			container, err := containers.NewGhost(o.GetDefinition())
+
+			if err != nil || container.GetName() != name || container.GetSpecHash() != hash || container.GetStatus() == nil || container.GetStatus().State == nil {
+				continue
+			}
+
+			replicas++
+
+			if completed(container.GetStatus(), gitops.Gitops.Waves.Started) {
+				continue
+			}
+
+			if failed(container.GetStatus(), gitops.Gitops.Waves.Started) {
+				return nil, fmt.Errorf("%w: %s exited with code %d", ERROR_WAVE_FAILED, container.GetGeneratedName(), container.GetStatus().LastExitCode)
+			}
+
+			switch container.GetStatus().GetState() {
+			case status.READY, status.RUNNING:
+				continue
+			case status.INIT_FAILED, status.DEPENDS_FAILED, status.READINESS_FAILED, status.BACKOFF, status.DAEMON_FAILURE:
+				return nil, fmt.Errorf("%w: %s is %s", ERROR_WAVE_FAILED, container.GetGeneratedName(), container.GetStatus().GetState())
+			default:
+				waiting = append(waiting, container.GetGeneratedName())
+			}
+		}
+
+		if replicas == 0 {
+			waiting = append(waiting, identifier(definition))
+		}
+	}
+
+	return waiting, nil
+}
+
+func (waves *Waves) GetWave() int {
+	if waves == nil || waves.Current >= len(waves.Waves) {
+		return 0
+	}
+
+	return waves.Waves[waves.Current]
+}
+
+func (waves *Waves) IsLast() bool {
+	return waves == nil || waves.Current >= len(waves.Waves)-1
+}
+
+func (waves *Waves) Next() {
+	waves.Current++
+	waves.Started = time.Now()
+}
+
+// Expired is true if the current wave is waited on longer than the timeout
+func (waves *Waves) Expired(timeout time.Duration, now time.Time) bool {
+	return waves != nil && !waves.Started.IsZero() && now.Sub(waves.Started) > timeout
+}
+
+func (waves *Waves) Done() {
+	waves.Active = false
+}
+
+// Progress is shown as current/total, pack without waves has nothing to show
+func (waves *Waves) Progress() string {
+	if waves == nil || len(waves.Waves) < 2 {
+		return "-"
+	}
+
+	if !waves.Active {
+		return fmt.Sprintf("%d/%d", len(waves.Waves), len(waves.Waves))
+	}
+
+	return fmt.Sprintf("%d/%d", waves.Current+1, len(waves.Waves))
+}
+
+func completed(state *status.Status, since time.Time) bool {
+	return !state.LastExitTimestamp.IsZero() && state.LastExitTimestamp.After(since) && state.LastExitCode == 0
+}
+
+func failed(state *status.Status, since time.Time) bool {
+	return !state.LastExitTimestamp.IsZero() && state.LastExitTimestamp.After(since) && state.LastExitCode != 0
+}
@@ -0,0 +1,162 @@
+package implementation
+
+import (
+	"encoding/json"
+	"fmt"
+	"github.com/go-git/go-git/v5/plumbing/object"
+	"github.com/simplecontainer/smr/pkg/authentication"
+	"github.com/simplecontainer/smr/pkg/clients"
+	v1 "github.com/simplecontainer/smr/pkg/definitions/v1"
+	"github.com/simplecontainer/smr/pkg/kinds/common"
+	"github.com/simplecontainer/smr/pkg/kinds/containers/platforms/rollout"
+	"github.com/simplecontainer/smr/pkg/logger"
+	"github.com/simplecontainer/smr/pkg/packer"
+	"github.com/simplecontainer/smr/pkg/static"
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/zap"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+)
+
+func waved(name string, wave string) []byte {
+	labels := ""
+
+	if wave != "" {
+		labels = fmt.Sprintf(`,"labels":{"sync-wave":"%s"}`, wave)
+	}
+
+	return []byte(fmt.Sprintf(`{"kind":"containers","prefix":"simplecontainer.io/v1","meta":{"group":"app","name":"%s"%s},"spec":{"image":"busybox","tag":"1"}}`, name, labels))
+}
+
+func pack(t *testing.T, definitions ...[]byte) *packer.Pack {
+	p := packer.New()
+
+	for _, definition := range definitions {
+		request, err := common.NewRequestFromJson(static.KIND_CONTAINERS, definition)
+		assert.NoError(t, err)
+
+		p.Definitions = append(p.Definitions, &packer.Definition{Definition: request})
+	}
+
+	return p
+}
+
+func TestPlan(t *testing.T) {
+	tests := []struct {
+		name     string
+		pack     *packer.Pack
+		expected []int
+		wantErr  bool
+	}{
+		{"Definitions without waves", pack(t, waved("db", ""), waved("api", "")), []int{0}, false},
+		{"Waves are ordered", pack(t, waved("web", "2"), waved("migrate", "-1"), waved("api", "2"), waved("db", "")), []int{-1, 0, 2}, false},
+		{"Invalid wave", pack(t, waved("db", "first")), nil, true},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			gitops := &Gitops{Gitops: &GitopsInternal{Pack: tc.pack, Commit: &object.Commit{}}}
+
+			err := gitops.Plan()
+
+			if tc.wantErr {
+				assert.ErrorIs(t, err, ERROR_INVALID_WAVE)
+				return
+			}
+
+			assert.NoError(t, err)
+			assert.Equal(t, tc.expected, gitops.GetWaves().Waves)
+			assert.True(t, gitops.Planned())
+		})
+	}
+}
+
+func TestExpired(t *testing.T) {
+	started := time.Now()
+
+	tests := []struct {
+		name     string
+		waves    *Waves
+		now      time.Time
+		expected bool
+	}{
+		{"Wave within timeout", &Waves{Started: started}, started.Add(time.Minute), false},
+		{"Wave past timeout", &Waves{Started: started}, started.Add(DEFAULT_WAVE_TIMEOUT + time.Second), true},
+		{"Wave not started", &Waves{}, started, false},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			assert.Equal(t, tc.expected, tc.waves.Expired(DEFAULT_WAVE_TIMEOUT, tc.now))
+		})
+	}
+}
+
+func TestReady(t *testing.T) {
+	logger.Log = zap.NewNop()
+
+	definition := &v1.ContainersDefinition{}
+	assert.NoError(t, json.Unmarshal(waved("migrate", "1"), definition))
+
+	hash := rollout.SpecHash(definition)
+	started := time.Now()
+
+	state := func(name string, spec string, state string, exited time.Time, code int) json.RawMessage {
+		return json.RawMessage(fmt.Sprintf(`{"Type":"docker","Platform":{"Name":"%s","Group":"app","GeneratedName":"app-%s-1"},"General":{"SpecHash":"%s","Status":{"state":{"state":"%s"},"LastExitCode":%d,"LastExitTimestamp":"%s"}}}`,
+			name, name, spec, state, code, exited.Format(time.RFC3339Nano)))
+	}
+
+	tests := []struct {
+		name     string
+		states   []json.RawMessage
+		expected []string
+		wantErr  bool
+	}{
+		{"Running container", []json.RawMessage{state("migrate", hash, "running", time.Time{}, 0)}, []string{}, false},
+		{"Completed job", []json.RawMessage{state("migrate", hash, "prepare", started.Add(time.Second), 0)}, []string{}, false},
+		{"Job completed before the wave", []json.RawMessage{state("migrate", hash, "dead", started.Add(-time.Second), 0)}, []string{"app-migrate-1"}, false},
+		{"Job exited with error", []json.RawMessage{state("migrate", hash, "dead", started.Add(time.Second), 1)}, nil, true},
+		{"Replica of the previous spec", []json.RawMessage{state("migrate", "old", "running", time.Time{}, 0)}, []string{"containers/app/migrate"}, false},
+		{"Container of other definition", []json.RawMessage{state("migrate-old", hash, "running", time.Time{}, 0)}, []string{"containers/app/migrate"}, false},
+		{"Failed container", []json.RawMessage{state("migrate", hash, "readiness_failed", time.Time{}, 0)}, nil, true},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				var data []byte
+
+				if strings.HasPrefix(r.URL.Path, "/api/v1/kind/") {
+					data = waved("migrate", "1")
+				} else {
+					data, _ = json.Marshal(tc.states)
+				}
+
+				json.NewEncoder(w).Encode(common.Response(http.StatusOK, "", nil, data))
+			}))
+			defer server.Close()
+
+			user := &authentication.User{Username: "root"}
+			client := &clients.Http{Clients: map[string]*clients.Client{"root": {Http: server.Client(), API: server.URL}}}
+
+			gitops := &Gitops{Gitops: &GitopsInternal{
+				Pack:   pack(t, waved("db", ""), waved("migrate", "1")),
+				Commit: &object.Commit{},
+				Waves:  &Waves{Waves: []int{0, 1}, Current: 1, Active: true, Started: started},
+			}}
+
+			waiting, err := gitops.Ready(client, user)
+
+			if tc.wantErr {
+				assert.ErrorIs(t, err, ERROR_WAVE_FAILED)
+				return
+			}
+
+			assert.NoError(t, err)
+			assert.Equal(t, tc.expected, waiting)
+		})
+	}
+}
@@ -1,6 +1,8 @@
 package internal
 
 import (
+	"crypto/sha256"
+	"encoding/hex"
 	"errors"
 	"fmt"
 	"github.com/go-git/go-git/v5"
@@ -54,6 +56,34 @@ func NewGit(definition *v1.GitopsDefinition, logpath string) (*Git, error) {
 	}, nil
 }
 
+// NewOverlay is clone of the overlay repository placed next to the base one, directory is keyed by the repository
+// and revision so reordering overlays doesn't reuse clone of the other repository
+func NewOverlay(definition *v1.GitopsDefinition, overlay *v1.GitopsOverlay, logpath string, auth *Auth) (*Git, error) {
+	revision := overlay.Revision
+
+	if revision == "" {
+		revision = definition.Spec.Revision
+	}
+
+	key := sha256.Sum256([]byte(fmt.Sprintf("%s\n%s", overlay.RepoURL, revision)))
+
+	directory := fmt.Sprintf("/tmp/gitops/%s/%s/overlays/%s", definition.GetMeta().Group, definition.GetMeta().Name, hex.EncodeToString(key[:8]))
+	absolute := fmt.Sprintf("%s/%s", directory, helpers.GetSanitizedDirectoryPath(path.Base(overlay.RepoURL)))
+
+	err := os.MkdirAll(directory, 0750)
+	if err != nil {
+		return nil, err
+	}
+
+	return &Git{
+		Repository: overlay.RepoURL,
+		Revision:   revision,
+		Directory:  absolute,
+		LogPath:    logpath,
+		Auth:       auth,
+	}, nil
+}
+
 func (g *Git) Fetch() (*object.Commit, error) {
 	if _, err := git.PlainOpen(g.Directory); err != nil {
 		err = g.Clone()
@@ -2,12 +2,17 @@ package reconcile
 
 import (
 	"fmt"
+	"github.com/simplecontainer/smr/pkg/kinds/gitops/implementation"
 	"github.com/simplecontainer/smr/pkg/kinds/gitops/shared"
 	"github.com/simplecontainer/smr/pkg/kinds/gitops/status"
 	"github.com/simplecontainer/smr/pkg/kinds/gitops/watcher"
 	"github.com/simplecontainer/smr/pkg/logger"
 	"github.com/simplecontainer/smr/pkg/packer"
+	"github.com/simplecontainer/smr/pkg/static"
 	"go.uber.org/zap"
+	"sort"
+	"strings"
+	"time"
 )
 
 type StateHandlerFunc func(shared *shared.Shared, gw *watcher.Gitops) (string, bool)
@@ -21,6 +26,7 @@ var stateHandlers = map[string]StateHandlerFunc{
 	status.INVALID_GIT_PUSH:    handleInvalidGitPush,
 	status.INVALID_DEFINITIONS: handleInvalidDefinitions,
 	status.SYNCING:             handleSyncing,
+	status.SYNCING_WAVE:        handleSyncingWave,
 	status.GIT_PUSH_SUCCESS:    handleGitPushSuccess,
 	status.INSPECTING:          handleInspecting,
 	status.SYNCING_STATE:       handleSyncingState,
@@ -71,8 +77,12 @@ func handleCloningGit(shared *shared.Shared, gw *watcher.Gitops) (string, bool)
 			gw.Logger.Error(err.Error())
 			return status.INVALID_GIT, true
 		}
+	}
 
-		return status.CLONED_GIT, true
+	err = gw.Gitops.FetchOverlays()
+	if err != nil {
+		gw.Logger.Error(err.Error())
+		return status.INVALID_GIT, true
 	}
 
 	return status.CLONED_GIT, true
@@ -101,22 +111,39 @@ func handleCommitGit(shared *shared.Shared, gw *watcher.Gitops) (string, bool) {
 func handleClonedGit(shared *shared.Shared, gw *watcher.Gitops) (string, bool) {
 	var err error
 
+	// Definitions are not read at all unless every layer is signed by key trusted for its repository
+	if shared.Manager.Trust != nil {
+		for _, layer := range gw.Gitops.GetLayers() {
+			err = shared.Manager.Trust.Verify(layer.Repository, layer.Directory)
+			if err != nil {
+				gw.Logger.Error("pack verification failed", zap.String("directory", layer.Directory), zap.Error(err))
+				gw.Gitops.GetStatus().Reason = err.Error()
+				return status.INVALID_DEFINITIONS, true
+			}
+		}
+	}
+
 	if len(gw.Gitops.GetPack().Definitions) == 0 {
-		err = gw.Gitops.SetPack(packer.Read(fmt.Sprintf("%s/%s", gw.Gitops.GetGit().Directory, gw.Gitops.GetDirectory()), nil, shared.Manager.Kinds))
+		err = gw.Gitops.SetPack(packer.ReadLayers(gw.Gitops.GetDirectories(), nil, shared.Manager.Kinds))
 		if err != nil {
+			gw.Gitops.GetStatus().Reason = err.Error()
 			return status.INVALID_DEFINITIONS, true
 		}
 	} else {
-		tmp, err := packer.Read(fmt.Sprintf("%s/%s", gw.Gitops.GetGit().Directory, gw.Gitops.GetDirectory()), nil, shared.Manager.Kinds)
+		tmp, err := packer.ReadLayers(gw.Gitops.GetDirectories(), nil, shared.Manager.Kinds)
 		if err != nil {
+			gw.Gitops.GetStatus().Reason = err.Error()
 			return status.INVALID_DEFINITIONS, true
 		}
 
 		err = gw.Gitops.Update(tmp)
 		if err != nil {
+			gw.Gitops.GetStatus().Reason = err.Error()
 			return status.INVALID_DEFINITIONS, true
 		}
 	}
+
+	gw.Gitops.GetStatus().Reason = ""
 	return status.INSPECTING, true
 }
 
@@ -150,8 +177,36 @@ func handleSyncing(shared *shared.Shared, gw *watcher.Gitops) (string, bool) {
 		return status.INVALID_DEFINITIONS, true
 	}
 
+	// Waves are planned once per commit, sync continues from the current wave after it becomes ready
+	if !gw.Gitops.Planned() {
+		err := gw.Gitops.Plan()
+		if err != nil {
+			gw.Logger.Error(err.Error())
+			gw.Gitops.GetStatus().Reason = err.Error()
+			return status.INVALID_DEFINITIONS, true
+		}
+	}
+
+	waves := gw.Gitops.GetWaves()
+
 	errs := []error{}
-	_, errs = gw.Gitops.Sync(gw.Logger, shared.Client, gw.User)
+	_, errs = gw.Gitops.Sync(gw.Logger, shared.Client, gw.User, waves.GetWave())
+	if len(errs) > 0 {
+		for _, e := range errs {
+			gw.Logger.Error(e.Error())
+		}
+		waves.Done()
+		return status.INVALID_DEFINITIONS, true
+	}
+
+	if !waves.IsLast() {
+		gw.Logger.Info(fmt.Sprintf("wave %d proposed, waiting for it to become ready", waves.GetWave()))
+		return status.SYNCING_WAVE, true
+	}
+
+	waves.Done()
+
+	errs = gw.Gitops.Prune(gw.Logger, shared.Client, gw.User, ownable(shared), shared.Manager.Kinds)
 	if len(errs) > 0 {
 		for _, e := range errs {
 			gw.Logger.Error(e.Error())
@@ -160,12 +215,44 @@ func handleSyncing(shared *shared.Shared, gw *watcher.Gitops) (string, bool) {
 	}
 
 	gw.Gitops.GetStatus().LastSyncedCommit = gw.Gitops.GetCommit().ID()
+	gw.Gitops.GetStatus().Reason = ""
 	gw.Gitops.GetStatus().InSync = true
 	gw.Gitops.SetForceSync(false)
 	gw.Logger.Info(fmt.Sprintf("commit %s synced", gw.Gitops.GetStatus().LastSyncedCommit))
 	return status.INSPECTING, true
 }
 
+func handleSyncingWave(shared *shared.Shared, gw *watcher.Gitops) (string, bool) {
+	waves := gw.Gitops.GetWaves()
+
+	waiting, err := gw.Gitops.Ready(shared.Client, gw.User)
+	if err != nil {
+		gw.Logger.Error(fmt.Sprintf("wave %d failed", waves.GetWave()), zap.Error(err))
+		gw.Gitops.GetStatus().Reason = err.Error()
+		waves.Done()
+		return status.INVALID_DEFINITIONS, true
+	}
+
+	// Ticker runs the check again until every container of the wave is ready or completed
+	if len(waiting) > 0 {
+		if waves.Expired(gw.Gitops.GetWaveTimeout(), time.Now()) {
+			err = fmt.Errorf("%w: wave %d is not ready after %s: %s", implementation.ERROR_WAVE_TIMEOUT, waves.GetWave(), gw.Gitops.GetWaveTimeout(), strings.Join(waiting, ", "))
+			gw.Logger.Error(err.Error())
+			gw.Gitops.GetStatus().Reason = err.Error()
+			waves.Done()
+			return status.INVALID_DEFINITIONS, true
+		}
+
+		gw.Gitops.GetStatus().Reason = fmt.Sprintf("waiting for wave %d: %s", waves.GetWave(), strings.Join(waiting, ", "))
+		return status.SYNCING_WAVE, false
+	}
+
+	gw.Logger.Info(fmt.Sprintf("wave %d is ready", waves.GetWave()))
+	gw.Gitops.GetStatus().Reason = ""
+	waves.Next()
+	return status.SYNCING, true
+}
+
 func handleInspecting(shared *shared.Shared, gw *watcher.Gitops) (string, bool) {
 	drifted, errs := gw.Gitops.Drift(shared.Client, gw.User)
 	if len(errs) > 0 {
@@ -174,6 +261,17 @@ func handleInspecting(shared *shared.Shared, gw *watcher.Gitops) (string, bool)
 		}
 		return status.INVALID_DEFINITIONS, true
 	}
+
+	err := gw.Gitops.Inspect(shared.Client, gw.User, ownable(shared), shared.Manager.Kinds)
+	if err != nil {
+		gw.Logger.Error("failed to look up orphaned objects", zap.Error(err))
+	}
+
+	// Orphans only count as drift when they would be pruned by the sync
+	if gw.Gitops.GetPrune() && len(gw.Gitops.GetOrphans()) > 0 {
+		drifted = true
+	}
+
 	if gw.Gitops.GetStatus().InSync {
 		gw.Gitops.GetStatus().InSync = !drifted
 	}
@@ -220,6 +318,21 @@ func handleDrifted(shared *shared.Shared, gw *watcher.Gitops) (string, bool) {
 	return status.DRIFTED, false
 }
 
+// ownable are kinds gitops can own, nodes are never defined in the repository
+func ownable(shared *shared.Shared) []string {
+	kinds := make([]string, 0)
+
+	for kind := range shared.Manager.Kinds.Relations {
+		if kind != static.KIND_NODE {
+			kinds = append(kinds, kind)
+		}
+	}
+
+	sort.Strings(kinds)
+
+	return kinds
+}
+
 func handleDelete(shared *shared.Shared, gw *watcher.Gitops) (string, bool) {
 	gw.Logger.Info("triggering context cancel")
 	err := gw.Gitops.GetStatus().GetPending().Set(status.PENDING_DELETE)
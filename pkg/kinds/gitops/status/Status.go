@@ -25,6 +25,7 @@ func (status *Status) CreateGraph() {
 	created := gograph.NewVertex(&StatusState{CREATED, CATEGORY_PRERUN})
 	syncing := gograph.NewVertex(&StatusState{SYNCING, CATEGORY_WHILERUN})
 	syncingstate := gograph.NewVertex(&StatusState{SYNCING_STATE, CATEGORY_WHILERUN})
+	syncingwave := gograph.NewVertex(&StatusState{SYNCING_WAVE, CATEGORY_WHILERUN})
 	inspecting := gograph.NewVertex(&StatusState{INSPECTING, CATEGORY_WHILERUN})
 	pushingchanges := gograph.NewVertex(&StatusState{COMMIT_GIT, CATEGORY_WHILERUN})
 	cloning := gograph.NewVertex(&StatusState{CLONING_GIT, CATEGORY_WHILERUN})
@@ -66,6 +67,11 @@ func (status *Status) CreateGraph() {
 	status.StateMachine.AddEdge(syncing, backoff)
 	status.StateMachine.AddEdge(syncing, invaliddefinitions)
 	status.StateMachine.AddEdge(syncing, inspecting)
+	status.StateMachine.AddEdge(syncing, syncingwave)
+
+	status.StateMachine.AddEdge(syncingwave, syncing)
+	status.StateMachine.AddEdge(syncingwave, invaliddefinitions)
+	status.StateMachine.AddEdge(syncingwave, cloning)
 
 	status.StateMachine.AddEdge(invalidgit, created)
 
@@ -94,6 +100,7 @@ func (status *Status) CreateGraph() {
 	status.StateMachine.AddEdge(insync, pendingdelete)
 	status.StateMachine.AddEdge(inspecting, pendingdelete)
 	status.StateMachine.AddEdge(syncing, pendingdelete)
+	status.StateMachine.AddEdge(syncingwave, pendingdelete)
 	status.StateMachine.AddEdge(created, pendingdelete)
 	status.StateMachine.AddEdge(cloning, pendingdelete)
 	status.StateMachine.AddEdge(cloned, pendingdelete)
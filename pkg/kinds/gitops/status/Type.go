@@ -16,6 +16,7 @@ type Status struct {
 	PendingDelete    bool
 	InSync           bool
 	LastSyncedCommit plumbing.Hash
+	Reason           string
 	LastUpdate       time.Time
 	mu               sync.RWMutex `json:"-"` // Mutex for thread safety
 }
@@ -40,6 +41,7 @@ const CATEGORY_END = 3
 const CREATED string = "created"
 const SYNCING string = "syncing"
 const SYNCING_STATE string = "syncing_state"
+const SYNCING_WAVE string = "syncing_wave"
 const BACKOFF string = "backoff"
 const CLONING_GIT string = "cloning"
 const COMMIT_GIT string = "pushing_changes"
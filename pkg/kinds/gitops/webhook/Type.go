@@ -0,0 +1,32 @@
+package webhook
+
+const PROVIDER_GITHUB = "github"
+const PROVIDER_GITLAB = "gitlab"
+const PROVIDER_GITEA = "gitea"
+const PROVIDER_GENERIC = "generic"
+
+// Push is the part of the push event needed to find gitops definitions tracking the branch
+type Push struct {
+	Provider     string
+	Repositories []string
+	Branch       string
+}
+
+type repository struct {
+	CloneURL   string `json:"clone_url"`
+	SshURL     string `json:"ssh_url"`
+	HtmlURL    string `json:"html_url"`
+	GitHttpURL string `json:"git_http_url"`
+	GitSshURL  string `json:"git_ssh_url"`
+	WebURL     string `json:"web_url"`
+	URL        string `json:"url"`
+}
+
+// payload covers push events of GitHub, GitLab and Gitea, generic payload only sets url and ref or branch
+type payload struct {
+	Ref        string     `json:"ref"`
+	Branch     string     `json:"branch"`
+	URL        string     `json:"url"`
+	Repository repository `json:"repository"`
+	Project    repository `json:"project"`
+}
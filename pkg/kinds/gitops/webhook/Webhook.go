@@ -0,0 +1,134 @@
+package webhook
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"crypto/subtle"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"github.com/simplecontainer/smr/pkg/packer/trust"
+	"net/http"
+	"slices"
+	"strings"
+)
+
+var ERROR_SIGNATURE = errors.New("webhook signature is invalid")
+var ERROR_IGNORED = errors.New("webhook event is not a branch push")
+var ERROR_INSECURE = errors.New("webhook token can't be sent over plain http")
+
+// Parse verifies the payload with the shared secret and extracts pushed repository and branch, secure is true if
+// the request came over TLS
+func Parse(header http.Header, body []byte, secret string, secure bool) (*Push, error) {
+	provider, event := Provider(header)
+
+	if err := Verify(provider, header, body, secret, secure); err != nil {
+		return nil, err
+	}
+
+	if event != "" && event != "push" && event != "Push Hook" {
+		return nil, fmt.Errorf("%w: %s", ERROR_IGNORED, event)
+	}
+
+	data := payload{}
+
+	if err := json.Unmarshal(body, &data); err != nil {
+		return nil, fmt.Errorf("invalid webhook payload: %w", err)
+	}
+
+	branch := data.Branch
+
+	if data.Ref != "" {
+		var found bool
+		branch, found = strings.CutPrefix(data.Ref, "refs/heads/")
+
+		if !found {
+			return nil, fmt.Errorf("%w: %s", ERROR_IGNORED, data.Ref)
+		}
+	}
+
+	push := &Push{
+		Provider:     provider,
+		Repositories: make([]string, 0),
+		Branch:       branch,
+	}
+
+	for _, url := range []string{
+		data.URL,
+		data.Repository.CloneURL, data.Repository.SshURL, data.Repository.HtmlURL,
+		data.Repository.GitHttpURL, data.Repository.GitSshURL, data.Repository.URL,
+		data.Project.GitHttpURL, data.Project.GitSshURL, data.Project.WebURL,
+	} {
+		if url != "" && !slices.Contains(push.Repositories, trust.Source(url)) {
+			push.Repositories = append(push.Repositories, trust.Source(url))
+		}
+	}
+
+	if push.Branch == "" || len(push.Repositories) == 0 {
+		return nil, errors.New("webhook payload is missing repository or branch")
+	}
+
+	return push, nil
+}
+
+// Provider is detected from event headers, anything else is handled as generic payload
+func Provider(header http.Header) (string, string) {
+	switch {
+	case header.Get("X-Gitea-Event") != "":
+		return PROVIDER_GITEA, header.Get("X-Gitea-Event")
+	case header.Get("X-Gitlab-Event") != "":
+		return PROVIDER_GITLAB, header.Get("X-Gitlab-Event")
+	case header.Get("X-GitHub-Event") != "":
+		return PROVIDER_GITHUB, header.Get("X-GitHub-Event")
+	default:
+		return PROVIDER_GENERIC, ""
+	}
+}
+
+// Verify checks HMAC-SHA256 of the body, GitLab only sends the secret token so it is compared instead and is
+// refused over plain http where the token could be sniffed and replayed
+func Verify(provider string, header http.Header, body []byte, secret string, secure bool) error {
+	if secret == "" {
+		return fmt.Errorf("%w: secret is not configured", ERROR_SIGNATURE)
+	}
+
+	var signature string
+
+	switch provider {
+	case PROVIDER_GITLAB:
+		if !secure {
+			return ERROR_INSECURE
+		}
+
+		if subtle.ConstantTimeCompare([]byte(header.Get("X-Gitlab-Token")), []byte(secret)) != 1 {
+			return ERROR_SIGNATURE
+		}
+
+		return nil
+	case PROVIDER_GITEA:
+		signature = header.Get("X-Gitea-Signature")
+	default:
+		signature = strings.TrimPrefix(header.Get("X-Hub-Signature-256"), "sha256=")
+	}
+
+	expected, err := hex.DecodeString(signature)
+
+	if err != nil || len(expected) == 0 {
+		return ERROR_SIGNATURE
+	}
+
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write(body)
+
+	if !hmac.Equal(mac.Sum(nil), expected) {
+		return ERROR_SIGNATURE
+	}
+
+	return nil
+}
+
+// Matches compares normalized remotes so https and ssh urls of the same repository match
+func (push *Push) Matches(repository string, revision string) bool {
+	return revision == push.Branch && slices.Contains(push.Repositories, trust.Source(repository))
+}
@@ -0,0 +1,68 @@
+package webhook
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"github.com/stretchr/testify/assert"
+	"net/http"
+	"testing"
+)
+
+func sign(secret string, body []byte) string {
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write(body)
+
+	return hex.EncodeToString(mac.Sum(nil))
+}
+
+func TestParse(t *testing.T) {
+	github := []byte(`{"ref":"refs/heads/main","repository":{"clone_url":"https://github.com/org/app.git","ssh_url":"git@github.com:org/app.git"}}`)
+	gitlab := []byte(`{"ref":"refs/heads/main","project":{"git_http_url":"https://gitlab.com/org/app.git","git_ssh_url":"git@gitlab.com:org/app.git"}}`)
+	generic := []byte(`{"url":"https://git.example.com/org/app","branch":"main"}`)
+	tag := []byte(`{"ref":"refs/tags/v1","repository":{"clone_url":"https://github.com/org/app.git"}}`)
+
+	tests := []struct {
+		name          string
+		header        http.Header
+		body          []byte
+		repository    string
+		expectedError error
+	}{
+		{"GitHub", http.Header{"X-Github-Event": {"push"}, "X-Hub-Signature-256": {"sha256=" + sign("secret", github)}}, github, "git@github.com:org/app.git", nil},
+		{"GitHub wrong secret", http.Header{"X-Github-Event": {"push"}, "X-Hub-Signature-256": {"sha256=" + sign("other", github)}}, github, "", ERROR_SIGNATURE},
+		{"GitHub ping", http.Header{"X-Github-Event": {"ping"}, "X-Hub-Signature-256": {"sha256=" + sign("secret", github)}}, github, "", ERROR_IGNORED},
+		{"GitHub tag push", http.Header{"X-Github-Event": {"push"}, "X-Hub-Signature-256": {"sha256=" + sign("secret", tag)}}, tag, "", ERROR_IGNORED},
+		{"GitLab", http.Header{"X-Gitlab-Event": {"Push Hook"}, "X-Gitlab-Token": {"secret"}}, gitlab, "https://gitlab.com/org/app", nil},
+		{"GitLab wrong token", http.Header{"X-Gitlab-Event": {"Push Hook"}, "X-Gitlab-Token": {"other"}}, gitlab, "", ERROR_SIGNATURE},
+		{"Gitea", http.Header{"X-Gitea-Event": {"push"}, "X-Gitea-Signature": {sign("secret", github)}}, github, "https://github.com/org/app", nil},
+		{"Generic", http.Header{"X-Hub-Signature-256": {"sha256=" + sign("secret", generic)}}, generic, "https://git.example.com/org/app.git", nil},
+		{"Generic unsigned", http.Header{}, generic, "", ERROR_SIGNATURE},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			push, err := Parse(tc.header, tc.body, "secret", true)
+
+			if tc.expectedError != nil {
+				assert.ErrorIs(t, err, tc.expectedError)
+				return
+			}
+
+			assert.NoError(t, err)
+			assert.True(t, push.Matches(tc.repository, "main"))
+			assert.False(t, push.Matches(tc.repository, "develop"))
+			assert.False(t, push.Matches("https://github.com/org/other.git", "main"))
+		})
+	}
+}
+
+func TestVerify(t *testing.T) {
+	body := []byte(`{}`)
+
+	assert.ErrorIs(t, Verify(PROVIDER_GITHUB, http.Header{"X-Hub-Signature-256": {"sha256=" + sign("", body)}}, body, "", false), ERROR_SIGNATURE)
+	assert.ErrorIs(t, Verify(PROVIDER_GITHUB, http.Header{"X-Hub-Signature-256": {"sha256=zz"}}, body, "secret", false), ERROR_SIGNATURE)
+	assert.NoError(t, Verify(PROVIDER_GITHUB, http.Header{"X-Hub-Signature-256": {"sha256=" + sign("secret", body)}}, body, "secret", false))
+	assert.ErrorIs(t, Verify(PROVIDER_GITLAB, http.Header{"X-Gitlab-Token": {"secret"}}, body, "secret", false), ERROR_INSECURE)
+	assert.NoError(t, Verify(PROVIDER_GITLAB, http.Header{"X-Gitlab-Token": {"secret"}}, body, "secret", true))
+}
@@ -1,11 +1,17 @@
 package node
 
 import (
+	"encoding/json"
 	"github.com/simplecontainer/smr/pkg/authentication"
 	"github.com/simplecontainer/smr/pkg/contracts/ievents"
 	"github.com/simplecontainer/smr/pkg/contracts/iresponse"
 	"github.com/simplecontainer/smr/pkg/contracts/ishared"
+	"github.com/simplecontainer/smr/pkg/events/events"
 	"github.com/simplecontainer/smr/pkg/kinds/common"
+	"github.com/simplecontainer/smr/pkg/logger"
+	smrnode "github.com/simplecontainer/smr/pkg/node"
+	"github.com/simplecontainer/smr/pkg/static"
+	"go.uber.org/zap"
 	"net/http"
 )
 
@@ -30,5 +36,94 @@ func (node *Node) Delete(user *authentication.User, definition []byte, agent str
 }
 
 func (node *Node) Event(event ievents.Event) error {
+	switch event.GetType() {
+	case events.EVENT_CLUSTER_STARTED, events.EVENT_NODE_LABELED, events.EVENT_NODE_RESOURCES:
+		// Keep labels and capacity of cluster members in sync since scheduling decisions depend on them
+		n := smrnode.NewNode()
+
+		if err := json.Unmarshal(event.GetData(), n); err != nil {
+			return err
+		}
+
+		if node.Shared.Manager.Cluster == nil {
+			return nil
+		}
+
+		existing := node.Shared.Manager.Cluster.Cluster.FindById(n.NodeID)
+
+		if existing != nil {
+			existing.Labels = n.Labels
+
+			// Capacity is measured by the node itself on start, nodes running older version don't send it
+			if n.Resources != (smrnode.Resources{}) {
+				existing.Resources = n.Resources
+			}
+		}
+
+		// Started node restored reachability and capacity of other members from its own config so leader tells it
+		// which members are unreachable and every member sends its current capacity
+		if event.GetType() == events.EVENT_CLUSTER_STARTED && n.NodeID != node.Shared.Manager.Cluster.Node.NodeID {
+			node.announceUnreachable()
+			node.announceResources()
+		}
+	case events.EVENT_NODE_UNREACHABLE, events.EVENT_NODE_REACHABLE:
+		n := smrnode.NewNode()
+
+		if err := json.Unmarshal(event.GetData(), n); err != nil {
+			return err
+		}
+
+		if node.Shared.Manager.Cluster == nil {
+			return nil
+		}
+
+		existing := node.Shared.Manager.Cluster.Cluster.FindById(n.NodeID)
+
+		if existing != nil {
+			existing.State.Health.Unreachable = event.GetType() == events.EVENT_NODE_UNREACHABLE
+		}
+
+		// Replicas are placed by the containers kind so it needs to know membership changed
+		if containers, ok := node.Shared.Manager.KindsRegistry[static.KIND_CONTAINERS]; ok {
+			return containers.Event(event)
+		}
+	}
+
 	return nil
 }
+
+func (node *Node) announceUnreachable() {
+	cluster := node.Shared.Manager.Cluster
+
+	if cluster.RaftNode == nil || !cluster.RaftNode.IsLeader.Load() {
+		return
+	}
+
+	for _, n := range cluster.Cluster.Nodes {
+		if n.Reachable() {
+			continue
+		}
+
+		event, err := events.NewNodeEvent(events.EVENT_NODE_UNREACHABLE, n)
+
+		if err != nil {
+			logger.Log.Error("failed to create node event", zap.String("event", events.EVENT_NODE_UNREACHABLE), zap.Error(err))
+			continue
+		}
+
+		events.Dispatch(event, node.Shared, cluster.Node.NodeID)
+	}
+}
+
+func (node *Node) announceResources() {
+	cluster := node.Shared.Manager.Cluster
+
+	event, err := events.NewNodeEvent(events.EVENT_NODE_RESOURCES, cluster.Node)
+
+	if err != nil {
+		logger.Log.Error("failed to create node event", zap.String("event", events.EVENT_NODE_RESOURCES), zap.Error(err))
+		return
+	}
+
+	events.Dispatch(event, node.Shared, cluster.Node.NodeID)
+}
@@ -0,0 +1,133 @@
+package notification
+
+import (
+	"encoding/json"
+	"github.com/simplecontainer/smr/pkg/authentication"
+	"github.com/simplecontainer/smr/pkg/contracts/ievents"
+	"github.com/simplecontainer/smr/pkg/contracts/iresponse"
+	"github.com/simplecontainer/smr/pkg/contracts/ishared"
+	v1 "github.com/simplecontainer/smr/pkg/definitions/v1"
+	"github.com/simplecontainer/smr/pkg/events/events"
+	"github.com/simplecontainer/smr/pkg/f"
+	"github.com/simplecontainer/smr/pkg/kinds/common"
+	"github.com/simplecontainer/smr/pkg/notifications"
+	"github.com/simplecontainer/smr/pkg/objects"
+	"github.com/simplecontainer/smr/pkg/static"
+	"net/http"
+)
+
+func (notification *Notification) Start() error {
+	notification.Started = true
+	notifications.Notifier = notifications.New(notification.local, notification.load)
+	return nil
+}
+func (notification *Notification) GetShared() ishared.Shared {
+	return notification.Shared
+}
+
+func (notification *Notification) Apply(user *authentication.User, definition []byte, agent string) (iresponse.Response, error) {
+	request, err := common.NewRequestFromJson(static.KIND_NOTIFICATION, definition)
+
+	if err != nil {
+		return common.Response(http.StatusBadRequest, "invalid definition sent", err, nil), err
+	}
+
+	_, err = notifications.NewNotification(request.Definition.Definition.(*v1.NotificationDefinition))
+
+	if err != nil {
+		return common.Response(http.StatusBadRequest, "invalid notification", err, nil), err
+	}
+
+	_, err = request.Apply(notification.Shared.Client, user)
+
+	if err != nil {
+		return common.Response(http.StatusBadRequest, "", err, nil), err
+	} else {
+		events.DispatchGroup([]events.Event{
+			events.NewKindEvent(events.EVENT_CHANGED, request.Definition, nil),
+			events.NewKindEvent(events.EVENT_INSPECT, request.Definition, nil),
+		}, notification.Shared, request.Definition.GetRuntime().GetNode())
+
+		return common.Response(http.StatusOK, "object applied", nil, nil), nil
+	}
+}
+func (notification *Notification) State(user *authentication.User, definition []byte, agent string) (iresponse.Response, error) {
+	request, err := common.NewRequestFromJson(static.KIND_NOTIFICATION, definition)
+
+	if err != nil {
+		return common.Response(http.StatusBadRequest, "invalid definition sent", err, nil), err
+	}
+
+	_, err = request.Apply(notification.Shared.Client, user)
+
+	if err != nil {
+		return common.Response(http.StatusBadRequest, "", err, nil), err
+	} else {
+		return common.Response(http.StatusOK, "", err, nil), err
+	}
+}
+func (notification *Notification) Delete(user *authentication.User, definition []byte, agent string) (iresponse.Response, error) {
+	request, err := common.NewRequestFromJson(static.KIND_NOTIFICATION, definition)
+
+	if err != nil {
+		return common.Response(http.StatusBadRequest, "invalid definition sent", err, nil), err
+	}
+
+	_, err = request.Remove(notification.Shared.Client, user)
+
+	if err != nil {
+		return common.Response(http.StatusInternalServerError, "", err, nil), err
+	} else {
+		events.DispatchGroup([]events.Event{
+			events.NewKindEvent(events.EVENT_DELETED, request.Definition, nil),
+			events.NewKindEvent(events.EVENT_INSPECT, request.Definition, nil),
+		}, notification.Shared, request.Definition.GetRuntime().GetNode())
+
+		return common.Response(http.StatusOK, "object in sync", nil, nil), nil
+	}
+}
+
+// Event is received on every node so each of them reloads notifications applied on another node
+func (notification *Notification) Event(event ievents.Event) error {
+	switch event.GetType() {
+	case events.EVENT_CHANGED, events.EVENT_DELETED:
+		if notifications.Notifier != nil {
+			notifications.Notifier.Invalidate()
+		}
+	}
+
+	return nil
+}
+
+func (notification *Notification) local() uint64 {
+	if notification.Shared.Manager.Cluster == nil || notification.Shared.Manager.Cluster.Node == nil {
+		return 0
+	}
+
+	return notification.Shared.Manager.Cluster.Node.NodeID
+}
+
+func (notification *Notification) load() ([]*v1.NotificationDefinition, error) {
+	user := notification.Shared.Manager.User
+	obj := objects.New(notification.Shared.Client.Clients[user.Username], user)
+
+	objs, err := obj.FindMany(f.New(static.SMR_PREFIX, static.CATEGORY_KIND, static.KIND_NOTIFICATION))
+
+	if err != nil {
+		return nil, err
+	}
+
+	definitions := make([]*v1.NotificationDefinition, 0)
+
+	for _, o := range objs {
+		definition := v1.NewNotification()
+
+		if err = json.Unmarshal(o.GetDefinitionByte(), definition); err != nil {
+			return nil, err
+		}
+
+		definitions = append(definitions, definition)
+	}
+
+	return definitions, nil
+}
@@ -0,0 +1,12 @@
+package notification
+
+import "github.com/simplecontainer/smr/pkg/manager"
+
+func New(mgr *manager.Manager) *Notification {
+	return &Notification{
+		Shared: &Shared{
+			Manager: mgr,
+			Client:  mgr.Http,
+		},
+	}
+}
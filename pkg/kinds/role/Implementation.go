@@ -0,0 +1,91 @@
+package role
+
+import (
+	"github.com/simplecontainer/smr/pkg/authentication"
+	"github.com/simplecontainer/smr/pkg/contracts/ievents"
+	"github.com/simplecontainer/smr/pkg/contracts/iresponse"
+	"github.com/simplecontainer/smr/pkg/contracts/ishared"
+	v1 "github.com/simplecontainer/smr/pkg/definitions/v1"
+	"github.com/simplecontainer/smr/pkg/events/events"
+	"github.com/simplecontainer/smr/pkg/kinds/common"
+	"github.com/simplecontainer/smr/pkg/rbac"
+	"github.com/simplecontainer/smr/pkg/static"
+	"net/http"
+)
+
+func (role *Role) Start() error {
+	role.Started = true
+	return nil
+}
+func (role *Role) GetShared() ishared.Shared {
+	return role.Shared
+}
+
+func (role *Role) Apply(user *authentication.User, definition []byte, agent string) (iresponse.Response, error) {
+	request, err := common.NewRequestFromJson(static.KIND_ROLE, definition)
+
+	if err != nil {
+		return common.Response(http.StatusBadRequest, "invalid definition sent", err, nil), err
+	}
+
+	err = rbac.Validate(request.Definition.Definition.(*v1.RoleDefinition).Spec.Rules)
+
+	if err != nil {
+		return common.Response(http.StatusBadRequest, "invalid role", err, nil), err
+	}
+
+	_, err = request.Apply(role.Shared.Client, user)
+	rbac.Policies.Invalidate()
+
+	if err != nil {
+		return common.Response(http.StatusBadRequest, "", err, nil), err
+	} else {
+		events.DispatchGroup([]events.Event{
+			events.NewKindEvent(events.EVENT_CHANGED, request.Definition, nil),
+			events.NewKindEvent(events.EVENT_INSPECT, request.Definition, nil),
+		}, role.Shared, request.Definition.GetRuntime().GetNode())
+
+		return common.Response(http.StatusOK, "object applied", nil, nil), nil
+	}
+}
+func (role *Role) State(user *authentication.User, definition []byte, agent string) (iresponse.Response, error) {
+	request, err := common.NewRequestFromJson(static.KIND_ROLE, definition)
+
+	if err != nil {
+		return common.Response(http.StatusBadRequest, "invalid definition sent", err, nil), err
+	}
+
+	_, err = request.Apply(role.Shared.Client, user)
+	rbac.Policies.Invalidate()
+
+	if err != nil {
+		return common.Response(http.StatusBadRequest, "", err, nil), err
+	} else {
+		return common.Response(http.StatusOK, "", err, nil), err
+	}
+}
+func (role *Role) Delete(user *authentication.User, definition []byte, agent string) (iresponse.Response, error) {
+	request, err := common.NewRequestFromJson(static.KIND_ROLE, definition)
+
+	if err != nil {
+		return common.Response(http.StatusBadRequest, "invalid definition sent", err, nil), err
+	}
+
+	_, err = request.Remove(role.Shared.Client, user)
+	rbac.Policies.Invalidate()
+
+	if err != nil {
+		return common.Response(http.StatusInternalServerError, "", err, nil), err
+	} else {
+		events.DispatchGroup([]events.Event{
+			events.NewKindEvent(events.EVENT_DELETED, request.Definition, nil),
+			events.NewKindEvent(events.EVENT_INSPECT, request.Definition, nil),
+		}, role.Shared, request.Definition.GetRuntime().GetNode())
+
+		return common.Response(http.StatusOK, "object in sync", nil, nil), nil
+	}
+}
+
+func (role *Role) Event(event ievents.Event) error {
+	return nil
+}
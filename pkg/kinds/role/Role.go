@@ -0,0 +1,12 @@
+package role
+
+import "github.com/simplecontainer/smr/pkg/manager"
+
+func New(mgr *manager.Manager) *Role {
+	return &Role{
+		Shared: &Shared{
+			Manager: mgr,
+			Client:  mgr.Http,
+		},
+	}
+}
@@ -0,0 +1,84 @@
+package rolebinding
+
+import (
+	"github.com/simplecontainer/smr/pkg/authentication"
+	"github.com/simplecontainer/smr/pkg/contracts/ievents"
+	"github.com/simplecontainer/smr/pkg/contracts/iresponse"
+	"github.com/simplecontainer/smr/pkg/contracts/ishared"
+	"github.com/simplecontainer/smr/pkg/events/events"
+	"github.com/simplecontainer/smr/pkg/kinds/common"
+	"github.com/simplecontainer/smr/pkg/rbac"
+	"github.com/simplecontainer/smr/pkg/static"
+	"net/http"
+)
+
+func (rolebinding *Rolebinding) Start() error {
+	rolebinding.Started = true
+	return nil
+}
+func (rolebinding *Rolebinding) GetShared() ishared.Shared {
+	return rolebinding.Shared
+}
+
+func (rolebinding *Rolebinding) Apply(user *authentication.User, definition []byte, agent string) (iresponse.Response, error) {
+	request, err := common.NewRequestFromJson(static.KIND_ROLEBINDING, definition)
+
+	if err != nil {
+		return common.Response(http.StatusBadRequest, "invalid definition sent", err, nil), err
+	}
+
+	_, err = request.Apply(rolebinding.Shared.Client, user)
+	rbac.Policies.Invalidate()
+
+	if err != nil {
+		return common.Response(http.StatusBadRequest, "", err, nil), err
+	} else {
+		events.DispatchGroup([]events.Event{
+			events.NewKindEvent(events.EVENT_CHANGED, request.Definition, nil),
+			events.NewKindEvent(events.EVENT_INSPECT, request.Definition, nil),
+		}, rolebinding.Shared, request.Definition.GetRuntime().GetNode())
+
+		return common.Response(http.StatusOK, "object applied", nil, nil), nil
+	}
+}
+func (rolebinding *Rolebinding) State(user *authentication.User, definition []byte, agent string) (iresponse.Response, error) {
+	request, err := common.NewRequestFromJson(static.KIND_ROLEBINDING, definition)
+
+	if err != nil {
+		return common.Response(http.StatusBadRequest, "invalid definition sent", err, nil), err
+	}
+
+	_, err = request.Apply(rolebinding.Shared.Client, user)
+	rbac.Policies.Invalidate()
+
+	if err != nil {
+		return common.Response(http.StatusBadRequest, "", err, nil), err
+	} else {
+		return common.Response(http.StatusOK, "", err, nil), err
+	}
+}
+func (rolebinding *Rolebinding) Delete(user *authentication.User, definition []byte, agent string) (iresponse.Response, error) {
+	request, err := common.NewRequestFromJson(static.KIND_ROLEBINDING, definition)
+
+	if err != nil {
+		return common.Response(http.StatusBadRequest, "invalid definition sent", err, nil), err
+	}
+
+	_, err = request.Remove(rolebinding.Shared.Client, user)
+	rbac.Policies.Invalidate()
+
+	if err != nil {
+		return common.Response(http.StatusInternalServerError, "", err, nil), err
+	} else {
+		events.DispatchGroup([]events.Event{
+			events.NewKindEvent(events.EVENT_DELETED, request.Definition, nil),
+			events.NewKindEvent(events.EVENT_INSPECT, request.Definition, nil),
+		}, rolebinding.Shared, request.Definition.GetRuntime().GetNode())
+
+		return common.Response(http.StatusOK, "object in sync", nil, nil), nil
+	}
+}
+
+func (rolebinding *Rolebinding) Event(event ievents.Event) error {
+	return nil
+}
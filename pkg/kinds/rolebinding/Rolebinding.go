@@ -0,0 +1,12 @@
+package rolebinding
+
+import "github.com/simplecontainer/smr/pkg/manager"
+
+func New(mgr *manager.Manager) *Rolebinding {
+	return &Rolebinding{
+		Shared: &Shared{
+			Manager: mgr,
+			Client:  mgr.Http,
+		},
+	}
+}
@@ -27,15 +27,22 @@ func (secret *Secret) Apply(user *authentication.User, definition []byte, agent
 		return common.Response(http.StatusBadRequest, "invalid definition sent", err, nil), err
 	}
 
-	_, err = request.Apply(secret.Shared.Client, user)
+	obj, err := request.Apply(secret.Shared.Client, user)
 
 	if err != nil {
 		return common.Response(http.StatusBadRequest, "", err, nil), err
 	} else {
-		events.DispatchGroup([]events.Event{
+		group := []events.Event{
 			events.NewKindEvent(events.EVENT_CHANGED, request.Definition, nil),
 			events.NewKindEvent(events.EVENT_INSPECT, request.Definition, nil),
-		}, secret.Shared, request.Definition.GetRuntime().GetNode())
+		}
+
+		// Containers mounting the secret rewrite their files
+		if obj.ChangeDetected() {
+			group = append(group, events.NewKindEvent(events.EVENT_CHANGE, request.Definition, nil))
+		}
+
+		events.DispatchGroup(group, secret.Shared, request.Definition.GetRuntime().GetNode())
 
 		return common.Response(http.StatusOK, "object applied", nil, nil), nil
 	}
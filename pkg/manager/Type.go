@@ -9,6 +9,7 @@ import (
 	"github.com/simplecontainer/smr/pkg/distributed"
 	"github.com/simplecontainer/smr/pkg/dns"
 	"github.com/simplecontainer/smr/pkg/keys"
+	"github.com/simplecontainer/smr/pkg/packer/trust"
 	"github.com/simplecontainer/smr/pkg/relations"
 	"github.com/simplecontainer/smr/pkg/version"
 	"github.com/simplecontainer/smr/pkg/wss"
@@ -28,4 +29,5 @@ type Manager struct {
 	Wss           *wss.WebSockets
 	LogLevel      zapcore.Level
 	Version       *version.Version
+	Trust         *trust.Policies
 }
@@ -10,3 +10,13 @@ var DockerVersion = NewCounter("docker_version", "Docker daemon version", []stri
 var SmrVersion = NewCounter("smr_version", "Simplecontainer version", []string{"smr_version"})
 var Containers = NewGauge("containers", "Total containers running", []string{"container", "status"})
 var ContainersHistory = NewGauge("containers_history", "Total containers running", []string{"container", "status"})
+
+var ContainerCPU = NewGauge("container_cpu_usage_percent", "Container CPU usage in percents of single core", []string{"group", "name", "replica", "node"})
+var ContainerMemoryUsage = NewGauge("container_memory_usage_bytes", "Container memory usage without page cache", []string{"group", "name", "replica", "node"})
+var ContainerMemoryLimit = NewGauge("container_memory_limit_bytes", "Container memory limit", []string{"group", "name", "replica", "node"})
+var ContainerNetworkRx = NewGauge("container_network_receive_bytes", "Container bytes received over all networks", []string{"group", "name", "replica", "node"})
+var ContainerNetworkTx = NewGauge("container_network_transmit_bytes", "Container bytes transmitted over all networks", []string{"group", "name", "replica", "node"})
+var ContainerBlockRead = NewGauge("container_blkio_read_bytes", "Container bytes read from block devices", []string{"group", "name", "replica", "node"})
+var ContainerBlockWrite = NewGauge("container_blkio_write_bytes", "Container bytes written to block devices", []string{"group", "name", "replica", "node"})
+
+var CertificateExpiry = NewGauge("certificate_expiry_timestamp_seconds", "Unix time at which the node certificate expires", []string{"node", "type", "name", "status"})
@@ -30,6 +30,10 @@ func (g *Gauge) Set(value float64, labels ...string) {
 	g.metric.WithLabelValues(labels...).Set(value)
 }
 
+func (g *Gauge) Delete(labels ...string) bool {
+	return g.metric.DeleteLabelValues(labels...)
+}
+
 func (g *Gauge) Get() *prometheus.GaugeVec {
 	return g.metric
 }
@@ -13,6 +13,7 @@ func NewNode() *Node {
 		API:      "",
 		URL:      "",
 		State:    NewState(),
+		Labels:   make(map[string]string),
 		Version:  version.New("", ""),
 	}
 }
@@ -21,12 +22,14 @@ func NewNodeDefinition(cluster []*Node, nodeId uint64) *Node {
 	for _, n := range cluster {
 		if n.NodeID == nodeId {
 			return &Node{
-				NodeID:   n.NodeID,
-				NodeName: n.NodeName,
-				API:      n.API,
-				URL:      n.URL,
-				State:    n.State,
-				Version:  n.Version,
+				NodeID:    n.NodeID,
+				NodeName:  n.NodeName,
+				API:       n.API,
+				URL:       n.URL,
+				State:     n.State,
+				Resources: n.Resources,
+				Labels:    n.Labels,
+				Version:   n.Version,
 			}
 		}
 	}
@@ -42,6 +45,11 @@ func (node *Node) Accepting() bool {
 	}
 }
 
+// Reachable is false when the leader stopped hearing from the node and its replicas were rescheduled
+func (node *Node) Reachable() bool {
+	return !node.State.Health.Unreachable
+}
+
 func (node *Node) Parse(change raftpb.ConfChange) error {
 	node.NodeID = change.NodeID
 	node.ConfChange = change
@@ -15,6 +15,7 @@ func (nodes *Nodes) NewNode(nodeName string, url string, API string) *Node {
 	n.NodeName = nodeName
 	n.API = API
 	n.URL = url
+	n.Resources = NewResources()
 
 	return n
 }
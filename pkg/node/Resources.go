@@ -0,0 +1,41 @@
+package node
+
+import (
+	"bufio"
+	"os"
+	"runtime"
+	"strconv"
+	"strings"
+)
+
+func NewResources() Resources {
+	return Resources{
+		CPU:    int64(runtime.NumCPU()) * 1000,
+		Memory: memoryTotal(),
+	}
+}
+
+// memoryTotal reads MemTotal from /proc/meminfo, returns 0 if unknown
+func memoryTotal() int64 {
+	file, err := os.Open("/proc/meminfo")
+	if err != nil {
+		return 0
+	}
+	defer file.Close()
+
+	scanner := bufio.NewScanner(file)
+	for scanner.Scan() {
+		fields := strings.Fields(scanner.Text())
+
+		if len(fields) >= 2 && fields[0] == "MemTotal:" {
+			kb, err := strconv.ParseInt(fields[1], 10, 64)
+			if err != nil {
+				return 0
+			}
+
+			return kb * 1024
+		}
+	}
+
+	return 0
+}
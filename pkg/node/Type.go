@@ -16,9 +16,17 @@ type Node struct {
 	URL        string
 	ConfChange raftpb.ConfChange `yaml:"-" json:"-"`
 	State      State
+	Resources  Resources
+	Labels     map[string]string
 	Version    *version.Version
 }
 
+// Resources describes node capacity: CPU in millicores and memory in bytes
+type Resources struct {
+	CPU    int64
+	Memory int64
+}
+
 type ControlStatus string
 
 const (
@@ -34,6 +42,7 @@ type Health struct {
 	Running        bool
 	MemoryPressure bool
 	CPUPressure    bool
+	Unreachable    bool
 }
 
 type Control struct {
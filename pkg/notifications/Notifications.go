@@ -0,0 +1,276 @@
+package notifications
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"github.com/simplecontainer/smr/pkg/contracts/ievents"
+	v1 "github.com/simplecontainer/smr/pkg/definitions/v1"
+	"github.com/simplecontainer/smr/pkg/logger"
+	"go.uber.org/zap"
+	"net/http"
+	"slices"
+	"strings"
+	"text/template"
+	"time"
+)
+
+var ERROR_INVALID_NOTIFICATION = errors.New("invalid notification")
+
+// New starts worker delivering queued events so raft event listener is never blocked by loading or sending
+func New(local func() uint64, load func() ([]*v1.NotificationDefinition, error)) *Notifications {
+	notifications := &Notifications{
+		Notifications: make(map[string]*Notification),
+		Client:        &http.Client{Timeout: DEFAULT_TIMEOUT},
+		Backoff:       DEFAULT_BACKOFF,
+		Local:         local,
+		Load:          load,
+		queue:         make(chan queued, DEFAULT_QUEUE),
+		states:        make(map[string]string),
+		limited:       make(map[string]time.Time),
+	}
+
+	go notifications.process()
+
+	return notifications
+}
+
+// NewNotification compiles the template and fills the defaults of the definition
+func NewNotification(definition *v1.NotificationDefinition) (*Notification, error) {
+	notification := &Notification{
+		Group:     definition.Meta.Group,
+		Name:      definition.Meta.Name,
+		Type:      definition.Spec.Type,
+		URL:       definition.Spec.URL,
+		Headers:   definition.Spec.Headers,
+		Kinds:     definition.Spec.Kinds,
+		Groups:    definition.Spec.Groups,
+		Events:    definition.Spec.Events,
+		Retries:   definition.Spec.Retries,
+		RateLimit: DEFAULT_RATE_LIMIT,
+	}
+
+	if len(notification.Kinds) == 0 {
+		notification.Kinds = DEFAULT_KINDS
+	}
+
+	if len(notification.Events) == 0 {
+		notification.Events = DEFAULT_EVENTS
+	}
+
+	if notification.Retries == 0 {
+		notification.Retries = DEFAULT_RETRIES
+	}
+
+	if definition.Spec.RateLimit != "" {
+		limit, err := time.ParseDuration(definition.Spec.RateLimit)
+
+		if err != nil || limit < 0 {
+			return nil, fmt.Errorf("%w: rate limit %s is not a valid duration", ERROR_INVALID_NOTIFICATION, definition.Spec.RateLimit)
+		}
+
+		notification.RateLimit = limit
+	}
+
+	text := definition.Spec.Template
+
+	if text == "" {
+		text = DEFAULT_TEMPLATE
+	}
+
+	tmpl, err := template.New(notification.Name).Option("missingkey=error").Parse(text)
+
+	if err != nil {
+		return nil, fmt.Errorf("%w: %s", ERROR_INVALID_NOTIFICATION, err.Error())
+	}
+
+	notification.Template = tmpl
+
+	return notification, nil
+}
+
+func (notifications *Notifications) Add(notification *Notification) {
+	notifications.lock.Lock()
+	defer notifications.lock.Unlock()
+
+	notifications.Notifications[fmt.Sprintf("%s/%s", notification.Group, notification.Name)] = notification
+}
+
+// Invalidate makes the next event reload notifications from the store since they can be applied on any node
+func (notifications *Notifications) Invalidate() {
+	notifications.lock.Lock()
+	defer notifications.lock.Unlock()
+
+	notifications.Loaded = false
+}
+
+// Forget drops the last known state of the deleted object, it is queued so it can't overtake earlier events
+func (notifications *Notifications) Forget(event ievents.Event) {
+	notifications.enqueue(queued{event: event, forget: true})
+}
+
+// Handle queues the event if it was proposed by this node, full queue drops the event instead of blocking the caller
+func (notifications *Notifications) Handle(event ievents.Event, node uint64) {
+	if notifications.Local == nil || notifications.Local() != node {
+		return
+	}
+
+	notifications.enqueue(queued{event: event, node: node})
+}
+
+func (notifications *Notifications) enqueue(q queued) {
+	select {
+	case notifications.queue <- q:
+	default:
+		logger.Log.Warn("notification queue is full, event dropped", zap.String("object", key(q.event)), zap.String("event", q.event.GetType()))
+	}
+}
+
+func (notifications *Notifications) process() {
+	for q := range notifications.queue {
+		if q.forget {
+			notifications.lock.Lock()
+			delete(notifications.states, key(q.event))
+			notifications.lock.Unlock()
+			continue
+		}
+
+		notifications.reload()
+		notifications.deliver(q.event, q.node)
+	}
+}
+
+// deliver sends the event only when the object transitioned to the new state, repeated state of the reconcile loop
+// or the same event inside the rate limit is dropped
+func (notifications *Notifications) deliver(event ievents.Event, node uint64) {
+	notifications.lock.Lock()
+	defer notifications.lock.Unlock()
+
+	now := time.Now()
+
+	for limit, until := range notifications.limited {
+		if !now.Before(until) {
+			delete(notifications.limited, limit)
+		}
+	}
+
+	// Only states of watched kinds are kept, transition of other objects can't be delivered anyway
+	if !notifications.watched(event.GetKind()) {
+		return
+	}
+
+	previous := notifications.states[key(event)]
+	notifications.states[key(event)] = event.GetType()
+
+	if previous == event.GetType() {
+		return
+	}
+
+	message := Message{
+		Kind:     event.GetKind(),
+		Group:    event.GetGroup(),
+		Name:     event.GetName(),
+		Event:    event.GetType(),
+		Previous: previous,
+		Node:     node,
+		Time:     now,
+	}
+
+	for identifier, notification := range notifications.Notifications {
+		if !notification.Matches(message) {
+			continue
+		}
+
+		limit := fmt.Sprintf("%s/%s/%s", identifier, key(event), event.GetType())
+
+		if until, ok := notifications.limited[limit]; ok && message.Time.Before(until) {
+			continue
+		}
+
+		if notification.RateLimit > 0 {
+			notifications.limited[limit] = message.Time.Add(notification.RateLimit)
+		}
+
+		go func(notification *Notification) {
+			err := notifications.Send(notification, message)
+
+			if err != nil {
+				logger.Log.Error("failed to send notification", zap.String("notification", identifier), zap.Error(err))
+			}
+		}(notification)
+	}
+}
+
+func (notifications *Notifications) watched(kind string) bool {
+	for _, notification := range notifications.Notifications {
+		if slices.Contains(notification.Kinds, kind) {
+			return true
+		}
+	}
+
+	return false
+}
+
+// Matches is true when kind, group and event of the message are watched by the notification
+func (notification *Notification) Matches(message Message) bool {
+	return slices.Contains(notification.Kinds, message.Kind) &&
+		(len(notification.Groups) == 0 || slices.Contains(notification.Groups, message.Group)) &&
+		slices.Contains(notification.Events, message.Event)
+}
+
+func (notification *Notification) Render(message Message) (string, error) {
+	var buffer bytes.Buffer
+
+	if err := notification.Template.Execute(&buffer, message); err != nil {
+		return "", err
+	}
+
+	return buffer.String(), nil
+}
+
+// reload loads notifications from the store outside of the lock since it is http round trip
+func (notifications *Notifications) reload() {
+	notifications.lock.Lock()
+	loaded := notifications.Loaded || notifications.Load == nil
+	notifications.lock.Unlock()
+
+	if loaded {
+		return
+	}
+
+	definitions, err := notifications.Load()
+
+	if err != nil {
+		logger.Log.Error("failed to load notifications", zap.Error(err))
+		return
+	}
+
+	compiled := make(map[string]*Notification)
+
+	for _, definition := range definitions {
+		notification, err := NewNotification(definition)
+
+		if err != nil {
+			logger.Log.Error("skipping notification", zap.String("notification", definition.Meta.Name), zap.Error(err))
+			continue
+		}
+
+		compiled[fmt.Sprintf("%s/%s", notification.Group, notification.Name)] = notification
+	}
+
+	notifications.lock.Lock()
+	defer notifications.lock.Unlock()
+
+	notifications.Notifications = compiled
+	notifications.Loaded = true
+
+	for object := range notifications.states {
+		if kind, _, _ := strings.Cut(object, "/"); !notifications.watched(kind) {
+			delete(notifications.states, object)
+		}
+	}
+}
+
+func key(event ievents.Event) string {
+	return fmt.Sprintf("%s/%s/%s", event.GetKind(), event.GetGroup(), event.GetName())
+}
@@ -0,0 +1,168 @@
+package notifications_test
+
+import (
+	"encoding/json"
+	"github.com/simplecontainer/smr/pkg/definitions/commonv1"
+	v1 "github.com/simplecontainer/smr/pkg/definitions/v1"
+	"github.com/simplecontainer/smr/pkg/events/events"
+	"github.com/simplecontainer/smr/pkg/logger"
+	"github.com/simplecontainer/smr/pkg/notifications"
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/zap"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func definition(kind string, url string, rateLimit string) *v1.NotificationDefinition {
+	return &v1.NotificationDefinition{
+		Meta: &commonv1.Meta{Group: "alerts", Name: kind},
+		Spec: v1.NotificationSpec{Type: kind, URL: url, Groups: []string{"app"}, RateLimit: rateLimit},
+	}
+}
+
+func TestHandle(t *testing.T) {
+	logger.Log = zap.NewNop()
+
+	gitops := func(state string) events.Event {
+		return events.New(state, "gitops", "simplecontainer.io/v1", "gitops", "app", "web", nil)
+	}
+
+	tests := []struct {
+		name      string
+		events    []events.Event
+		node      uint64
+		rateLimit string
+		expected  []string
+	}{
+		{"Transition is delivered", []events.Event{gitops("insync"), gitops("drifted")}, 1, "", []string{"gitops app/web is drifted (was insync)"}},
+		{"Repeated state is delivered once", []events.Event{gitops("drifted"), gitops("drifted"), gitops("drifted")}, 1, "", []string{"gitops app/web is drifted"}},
+		{"Event of another node", []events.Event{gitops("drifted")}, 2, "", []string{}},
+		{"Event not watched", []events.Event{gitops("insync")}, 1, "", []string{}},
+		{"Group not watched", []events.Event{events.New("drifted", "gitops", "simplecontainer.io/v1", "gitops", "infra", "web", nil)}, 1, "", []string{}},
+		{"Rate limited", []events.Event{gitops("drifted"), gitops("insync"), gitops("drifted")}, 1, "", []string{"gitops app/web is drifted"}},
+		{"Rate limit passed", []events.Event{gitops("drifted"), gitops("insync"), gitops("drifted")}, 1, "0s", []string{"gitops app/web is drifted", "gitops app/web is drifted (was insync)"}},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			received := make(chan string, 10)
+
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				payload := make(map[string]string)
+				json.NewDecoder(r.Body).Decode(&payload)
+				received <- payload["text"]
+			}))
+			defer server.Close()
+
+			notifier := notifications.New(func() uint64 { return 1 }, func() ([]*v1.NotificationDefinition, error) {
+				return []*v1.NotificationDefinition{definition(notifications.TYPE_SLACK, server.URL, tc.rateLimit)}, nil
+			})
+
+			for _, event := range tc.events {
+				notifier.Handle(event, tc.node)
+			}
+
+			messages := make([]string, 0)
+
+			for {
+				select {
+				case message := <-received:
+					messages = append(messages, message)
+					continue
+				case <-time.After(200 * time.Millisecond):
+				}
+
+				break
+			}
+
+			assert.ElementsMatch(t, tc.expected, messages)
+		})
+	}
+}
+
+func TestSend(t *testing.T) {
+	message := notifications.Message{Kind: "containers", Group: "app", Name: "app-web-1", Event: "backoff", Node: 1}
+
+	tests := []struct {
+		name     string
+		kind     string
+		statuses []int
+		attempts int32
+		payload  string
+		wantErr  bool
+	}{
+		{"Slack", notifications.TYPE_SLACK, []int{http.StatusOK}, 1, `{"text":"containers app/app-web-1 is backoff"}`, false},
+		{"Matrix", notifications.TYPE_MATRIX, []int{http.StatusOK}, 1, `{"body":"containers app/app-web-1 is backoff","msgtype":"m.text","text":"containers app/app-web-1 is backoff"}`, false},
+		{"Retried server error", notifications.TYPE_SLACK, []int{http.StatusInternalServerError, http.StatusTooManyRequests, http.StatusOK}, 3, `{"text":"containers app/app-web-1 is backoff"}`, false},
+		{"Client error is not retried", notifications.TYPE_SLACK, []int{http.StatusBadRequest}, 1, `{"text":"containers app/app-web-1 is backoff"}`, true},
+		{"Retries exhausted", notifications.TYPE_SLACK, []int{http.StatusBadGateway, http.StatusBadGateway, http.StatusBadGateway, http.StatusBadGateway}, 4, `{"text":"containers app/app-web-1 is backoff"}`, true},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			var attempts int32
+			var payload []byte
+
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				attempt := atomic.AddInt32(&attempts, 1)
+				payload, _ = io.ReadAll(r.Body)
+
+				assert.Equal(t, "token", r.Header.Get("Authorization"))
+				w.WriteHeader(tc.statuses[attempt-1])
+			}))
+			defer server.Close()
+
+			spec := definition(tc.kind, server.URL, "")
+			spec.Spec.Headers = map[string]string{"Authorization": "token"}
+
+			notification, err := notifications.NewNotification(spec)
+			assert.NoError(t, err)
+
+			notifier := notifications.New(nil, nil)
+			notifier.Backoff = time.Millisecond
+
+			err = notifier.Send(notification, message)
+
+			if tc.wantErr {
+				assert.Error(t, err)
+			} else {
+				assert.NoError(t, err)
+			}
+
+			assert.Equal(t, tc.attempts, atomic.LoadInt32(&attempts))
+			assert.JSONEq(t, tc.payload, string(payload))
+		})
+	}
+}
+
+func TestHandleDoesNotBlock(t *testing.T) {
+	logger.Log = zap.NewNop()
+
+	release := make(chan struct{})
+	defer close(release)
+
+	notifier := notifications.New(func() uint64 { return 1 }, func() ([]*v1.NotificationDefinition, error) {
+		<-release
+		return nil, nil
+	})
+
+	done := make(chan struct{})
+
+	go func() {
+		for i := 0; i < notifications.DEFAULT_QUEUE*2; i++ {
+			notifier.Handle(events.New("drifted", "gitops", "simplecontainer.io/v1", "gitops", "app", "web", nil), 1)
+		}
+
+		close(done)
+	}()
+
+	select {
+	case <-done:
+	case <-time.After(time.Second):
+		assert.Fail(t, "handle blocked while notifications were loading")
+	}
+}
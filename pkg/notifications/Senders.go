@@ -0,0 +1,106 @@
+package notifications
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"github.com/simplecontainer/smr/pkg/secrets"
+	"io"
+	"net/http"
+	"time"
+)
+
+// Send renders the message and posts it to the endpoint, failed requests are retried with exponential backoff
+// except client errors which won't succeed on retry
+func (notifications *Notifications) Send(notification *Notification, message Message) error {
+	text, err := notification.Render(message)
+
+	if err != nil {
+		return err
+	}
+
+	message.Text = text
+
+	body, err := notification.Payload(message)
+
+	if err != nil {
+		return err
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), DEFAULT_TIMEOUT)
+	defer cancel()
+
+	// Incoming webhooks carry the token in the url so both url and headers can be secret references
+	url, err := secrets.Value(ctx, notification.URL)
+
+	if err != nil {
+		return err
+	}
+
+	headers := make(map[string]string)
+
+	for name, value := range notification.Headers {
+		headers[name], err = secrets.Value(ctx, value)
+
+		if err != nil {
+			return err
+		}
+	}
+
+	for attempt := 0; ; attempt++ {
+		var retry bool
+		retry, err = notifications.post(url, headers, body)
+
+		if err == nil || !retry || attempt >= notification.Retries {
+			return err
+		}
+
+		time.Sleep(notifications.Backoff * time.Duration(1<<attempt))
+	}
+}
+
+// Payload formats the message for the endpoint type, generic webhook receives the whole message
+func (notification *Notification) Payload(message Message) ([]byte, error) {
+	switch notification.Type {
+	case TYPE_SLACK:
+		return json.Marshal(map[string]string{"text": message.Text})
+	case TYPE_MATRIX:
+		return json.Marshal(map[string]string{"msgtype": "m.text", "body": message.Text, "text": message.Text})
+	case TYPE_WEBHOOK:
+		return json.Marshal(message)
+	default:
+		return nil, fmt.Errorf("%w: unknown type %s", ERROR_INVALID_NOTIFICATION, notification.Type)
+	}
+}
+
+func (notifications *Notifications) post(url string, headers map[string]string, body []byte) (bool, error) {
+	req, err := http.NewRequest(http.MethodPost, url, bytes.NewReader(body))
+
+	if err != nil {
+		return false, err
+	}
+
+	req.Header.Set("Content-Type", "application/json")
+
+	for name, value := range headers {
+		req.Header.Set(name, value)
+	}
+
+	resp, err := notifications.Client.Do(req)
+
+	if err != nil {
+		return true, err
+	}
+
+	defer resp.Body.Close()
+	io.Copy(io.Discard, resp.Body)
+
+	if resp.StatusCode >= http.StatusOK && resp.StatusCode < http.StatusMultipleChoices {
+		return false, nil
+	}
+
+	retry := resp.StatusCode == http.StatusTooManyRequests || resp.StatusCode >= http.StatusInternalServerError
+
+	return retry, fmt.Errorf("notification endpoint responded with %d", resp.StatusCode)
+}
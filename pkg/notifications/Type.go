@@ -0,0 +1,77 @@
+package notifications
+
+import (
+	"github.com/simplecontainer/smr/pkg/contracts/ievents"
+	v1 "github.com/simplecontainer/smr/pkg/definitions/v1"
+	containers "github.com/simplecontainer/smr/pkg/kinds/containers/status"
+	gitops "github.com/simplecontainer/smr/pkg/kinds/gitops/status"
+	"github.com/simplecontainer/smr/pkg/static"
+	"net/http"
+	"sync"
+	"text/template"
+	"time"
+)
+
+const TYPE_SLACK = "slack"
+const TYPE_MATRIX = "matrix"
+const TYPE_WEBHOOK = "webhook"
+
+const DEFAULT_TEMPLATE = "{{ .Kind }} {{ .Group }}/{{ .Name }} is {{ .Event }}{{ if .Previous }} (was {{ .Previous }}){{ end }}"
+const DEFAULT_RETRIES = 3
+const DEFAULT_RATE_LIMIT = time.Minute
+const DEFAULT_BACKOFF = time.Second
+const DEFAULT_TIMEOUT = 10 * time.Second
+const DEFAULT_QUEUE = 1024
+
+var DEFAULT_KINDS = []string{static.KIND_GITOPS, static.KIND_CONTAINERS}
+var DEFAULT_EVENTS = []string{
+	gitops.DRIFTED, gitops.INVALID_GIT, gitops.INVALID_DEFINITIONS, gitops.ANOTHER_OWNER,
+	containers.BACKOFF, containers.READINESS_FAILED, containers.INIT_FAILED, containers.DEPENDS_FAILED, containers.DAEMON_FAILURE,
+}
+
+// Notifier is configured on start by the notification kind, when nil nothing is delivered
+var Notifier *Notifications
+
+type Notifications struct {
+	Notifications map[string]*Notification
+	Client        *http.Client
+	Backoff       time.Duration
+	Local         func() uint64
+	Load          func() ([]*v1.NotificationDefinition, error)
+	Loaded        bool
+	queue         chan queued
+	states        map[string]string
+	limited       map[string]time.Time
+	lock          sync.Mutex
+}
+
+type queued struct {
+	event  ievents.Event
+	node   uint64
+	forget bool
+}
+
+type Notification struct {
+	Group     string
+	Name      string
+	Type      string
+	URL       string
+	Headers   map[string]string
+	Kinds     []string
+	Groups    []string
+	Events    []string
+	Template  *template.Template
+	Retries   int
+	RateLimit time.Duration
+}
+
+type Message struct {
+	Kind     string    `json:"kind"`
+	Group    string    `json:"group"`
+	Name     string    `json:"name"`
+	Event    string    `json:"event"`
+	Previous string    `json:"previous"`
+	Node     uint64    `json:"node"`
+	Time     time.Time `json:"time"`
+	Text     string    `json:"text"`
+}
@@ -0,0 +1,37 @@
+package packer
+
+import (
+	"bytes"
+	"github.com/simplecontainer/smr/pkg/packer/signature"
+	"github.com/stretchr/testify/assert"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestArchive(t *testing.T) {
+	source := t.TempDir()
+
+	assert.NoError(t, os.MkdirAll(filepath.Join(source, "definitions"), 0755))
+	assert.NoError(t, os.MkdirAll(filepath.Join(source, ".git"), 0755))
+	assert.NoError(t, os.WriteFile(filepath.Join(source, PackageMetadataFile), []byte("name: app\n"), 0644))
+	assert.NoError(t, os.WriteFile(filepath.Join(source, "definitions", "app.yaml"), []byte(base), 0644))
+	assert.NoError(t, os.WriteFile(filepath.Join(source, ".git", "HEAD"), []byte("ref: refs/heads/main\n"), 0644))
+	assert.NoError(t, WriteOrigin(source, "registry.example.com/team/app"))
+
+	var archive bytes.Buffer
+	assert.NoError(t, Archive(source, &archive))
+
+	destination := t.TempDir()
+	assert.NoError(t, Extract(&archive, destination))
+
+	expected, err := signature.Digest(source)
+	assert.NoError(t, err)
+
+	digest, err := signature.Digest(destination)
+	assert.NoError(t, err)
+
+	assert.Equal(t, expected, digest)
+	assert.Equal(t, "registry.example.com/team/app", Origin(destination))
+	assert.NoDirExists(t, filepath.Join(destination, ".git"))
+}
@@ -114,6 +114,14 @@ func (c *Client) CreatePackage(ctx context.Context, signer *signature.Signer, re
 		return nil, fmt.Errorf("failed to create output directory: %w", err)
 	}
 
+	// Pack content is signed as well so the signature travels inside the layer and verifies after extraction
+	if signer.PrivateKeyPath != "" {
+		_, err = signature.Sign(filepath.Base(repository), signer)
+		if err != nil {
+			return nil, fmt.Errorf("failed to sign pack: %w", err)
+		}
+	}
+
 	layerDigest, layerSize, err := c.createLayer(filepath.Base(repository), blobsDir)
 	if err != nil {
 		return nil, fmt.Errorf("failed to create layer: %w", err)
@@ -0,0 +1,412 @@
+package packer
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	jsonpatch "github.com/evanphx/json-patch"
+	"github.com/simplecontainer/smr/pkg/definitions"
+	"github.com/simplecontainer/smr/pkg/relations"
+	"gopkg.in/yaml.v3"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+)
+
+var ERROR_PATCH_TARGET = errors.New("patch target not found")
+var ERROR_PATCH_IMMUTABLE = errors.New("patch can't change kind, group or name of the definition")
+
+// ReadLayers reads the base pack and applies overlays on top of it in order: variables are merged, definitions with
+// the same kind, group and name are replaced and patches of every layer are applied before definitions are ordered,
+// layer of the definition is the last one that defined or patched it
+func ReadLayers(paths []string, set []string, relations *relations.RelationRegistry) (*Pack, error) {
+	pack := New()
+
+	if len(paths) == 0 {
+		return pack, errors.New("no pack directories to read")
+	}
+
+	packID, err := ReadYAMLFile(filepath.Join(filepath.Clean(paths[0]), PackageMetadataFile))
+
+	if err != nil {
+		return pack, err
+	}
+
+	err = yaml.Unmarshal(packID, pack)
+
+	if err != nil {
+		return pack, err
+	}
+
+	values, err := Variables(paths)
+
+	if err != nil {
+		return pack, err
+	}
+
+	layered := make([]*Definition, 0)
+
+	for k, path := range paths {
+		entries, err := os.ReadDir(filepath.Join(filepath.Clean(path), "definitions"))
+
+		if err != nil {
+			// Overlay can consist only of variables or patches
+			if k > 0 && errors.Is(err, fs.ErrNotExist) {
+				continue
+			}
+
+			return pack, err
+		}
+
+		for _, e := range entries {
+			if filepath.Ext(e.Name()) != ".yaml" || e.Name() == "variables.yaml" {
+				continue
+			}
+
+			var definition []byte
+			definition, err = ReadYAMLFile(filepath.Join(path, "definitions", e.Name()))
+
+			if err != nil {
+				return pack, err
+			}
+
+			var requests []*Definition
+			requests, err = Parse(e.Name(), definition, values, set)
+
+			if err != nil {
+				return pack, err
+			}
+
+			for _, request := range requests {
+				request.Layer = k
+				layered = replace(layered, request)
+			}
+		}
+	}
+
+	for k, path := range paths {
+		err = ApplyPatches(layered, k, path, values, set)
+
+		if err != nil {
+			return pack, err
+		}
+	}
+
+	ordered := make([]*Definition, 0)
+
+	for _, request := range layered {
+		ordered = order(ordered, request, relations)
+	}
+
+	pack.Definitions = ordered
+
+	return pack, nil
+}
+
+// Variables merges variables.yaml of every layer, keys of the later layers override the earlier ones
+func Variables(paths []string) ([]byte, error) {
+	merged := make(map[string]interface{})
+
+	for _, path := range paths {
+		data, err := os.ReadFile(filepath.Join(filepath.Clean(path), "definitions", "variables.yaml"))
+
+		if err != nil {
+			if errors.Is(err, fs.ErrNotExist) {
+				continue
+			}
+
+			return nil, err
+		}
+
+		values := make(map[string]interface{})
+
+		if err = yaml.Unmarshal(data, &values); err != nil {
+			return nil, fmt.Errorf("invalid variables in %s: %w", path, err)
+		}
+
+		mergeValues(merged, values)
+	}
+
+	if len(merged) == 0 {
+		return nil, nil
+	}
+
+	return yaml.Marshal(merged)
+}
+
+// ApplyPatches applies patches from the patches directory of the layer to the definitions
+func ApplyPatches(layered []*Definition, layer int, path string, values []byte, set []string) error {
+	entries, err := os.ReadDir(filepath.Join(filepath.Clean(path), "patches"))
+
+	if err != nil {
+		if errors.Is(err, fs.ErrNotExist) {
+			return nil
+		}
+
+		return err
+	}
+
+	for _, e := range entries {
+		if filepath.Ext(e.Name()) != ".yaml" {
+			continue
+		}
+
+		data, err := ReadYAMLFile(filepath.Join(path, "patches", e.Name()))
+
+		if err != nil {
+			return err
+		}
+
+		documents, err := ParseYAML(e.Name(), data, values, set)
+
+		if err != nil {
+			return err
+		}
+
+		for _, document := range documents {
+			patch := &Patch{}
+
+			if err = json.Unmarshal(document, patch); err != nil {
+				return fmt.Errorf("invalid patch in %s: %w", e.Name(), err)
+			}
+
+			if err = patch.Apply(layered, layer); err != nil {
+				return fmt.Errorf("%s: %w", e.Name(), err)
+			}
+		}
+	}
+
+	return nil
+}
+
+// Apply patches every definition matched by the target, merge patch is applied before the JSON patch
+func (patch *Patch) Apply(layered []*Definition, layer int) error {
+	matched := false
+
+	for _, request := range layered {
+		if !patch.Target.Matches(request.Definition.Definition) {
+			continue
+		}
+
+		matched = true
+
+		current, err := request.Definition.Definition.ToJSON()
+
+		if err != nil {
+			return err
+		}
+
+		patched := current
+
+		if len(patch.Merge) > 0 {
+			patched, err = Merge(patched, patch.Merge)
+
+			if err != nil {
+				return err
+			}
+		}
+
+		if len(patch.JSON) > 0 {
+			decoded, err := jsonpatch.DecodePatch(patch.JSON)
+
+			if err != nil {
+				return err
+			}
+
+			patched, err = decoded.Apply(patched)
+
+			if err != nil {
+				return err
+			}
+		}
+
+		// Fresh definition so fields removed by the patch don't survive unmarshal
+		definition := definitions.New(request.Definition.Definition.GetKind())
+
+		if err = definition.FromJson(patched); err != nil {
+			return err
+		}
+
+		if !definition.IsOf(request.Definition.Definition) {
+			return fmt.Errorf("%w: %s/%s/%s", ERROR_PATCH_IMMUTABLE, request.Definition.Definition.GetKind(), request.Definition.Definition.GetMeta().Group, request.Definition.Definition.GetMeta().Name)
+		}
+
+		request.Definition.Definition = definition
+		request.Layer = layer
+	}
+
+	if !matched {
+		return fmt.Errorf("%w: %s/%s/%s", ERROR_PATCH_TARGET, patch.Target.Kind, patch.Target.Group, patch.Target.Name)
+	}
+
+	return nil
+}
+
+// Merge applies merge patch (RFC 7386) except that lists are merged by key instead of being replaced: objects by
+// name (container for ports) and KEY=value strings like envs by key, other lists are still replaced
+func Merge(document []byte, patch []byte) ([]byte, error) {
+	var current, changes interface{}
+
+	if err := json.Unmarshal(document, &current); err != nil {
+		return nil, err
+	}
+
+	if err := json.Unmarshal(patch, &changes); err != nil {
+		return nil, err
+	}
+
+	return json.Marshal(merge(current, changes))
+}
+
+func merge(current interface{}, patch interface{}) interface{} {
+	switch changes := patch.(type) {
+	case map[string]interface{}:
+		object, ok := current.(map[string]interface{})
+
+		if !ok {
+			object = make(map[string]interface{})
+		}
+
+		for key, value := range changes {
+			if value == nil {
+				delete(object, key)
+				continue
+			}
+
+			object[key] = merge(object[key], value)
+		}
+
+		return object
+	case []interface{}:
+		list, ok := current.([]interface{})
+
+		if !ok {
+			return changes
+		}
+
+		key, ok := mergeKey(list, changes)
+
+		if !ok {
+			return changes
+		}
+
+		for _, element := range changes {
+			index := slices.IndexFunc(list, func(existing interface{}) bool {
+				return key(existing) == key(element)
+			})
+
+			if index == -1 {
+				list = append(list, element)
+			} else {
+				list[index] = merge(list[index], element)
+			}
+		}
+
+		return list
+	default:
+		return patch
+	}
+}
+
+// mergeKey finds key every element of both lists has, lists without common key are replaced
+func mergeKey(lists ...[]interface{}) (func(interface{}) string, bool) {
+	keys := []func(interface{}) string{field("name"), field("container"), func(element interface{}) string {
+		value, _ := element.(string)
+		name, _, found := strings.Cut(value, "=")
+
+		if !found {
+			return ""
+		}
+
+		return name
+	}}
+
+	for _, key := range keys {
+		if keyed(key, lists...) {
+			return key, true
+		}
+	}
+
+	return nil, false
+}
+
+func field(name string) func(interface{}) string {
+	return func(element interface{}) string {
+		object, _ := element.(map[string]interface{})
+		value, _ := object[name].(string)
+		return value
+	}
+}
+
+func keyed(key func(interface{}) string, lists ...[]interface{}) bool {
+	for _, list := range lists {
+		for _, element := range list {
+			if key(element) == "" {
+				return false
+			}
+		}
+	}
+
+	return true
+}
+
+// Matches compares non-empty fields of the target to the definition
+func (target PatchTarget) Matches(definition *definitions.Definition) bool {
+	return (target.Kind == "" || target.Kind == definition.GetKind()) &&
+		(target.Group == "" || target.Group == definition.GetMeta().Group) &&
+		(target.Name == "" || target.Name == definition.GetMeta().Name)
+}
+
+func replace(layered []*Definition, request *Definition) []*Definition {
+	for k, existing := range layered {
+		if existing.Definition.Definition.IsOf(request.Definition.Definition) {
+			layered[k] = request
+			return layered
+		}
+	}
+
+	return append(layered, request)
+}
+
+// order places definition before the definitions depending on its kind
+func order(ordered []*Definition, request *Definition, relations *relations.RelationRegistry) []*Definition {
+	position := -1
+
+	for index, element := range ordered {
+		deps := relations.GetDependencies(element.Definition.Kind)
+
+		for _, dp := range deps {
+			if request.Definition.Definition.GetKind() == dp {
+				position = index
+			}
+		}
+	}
+
+	if request.Definition.Definition.GetKind() == "" {
+		return ordered
+	}
+
+	if position != -1 {
+		ordered = append(ordered[:position+1], ordered[position:]...)
+		ordered[position] = request
+	} else {
+		ordered = append(ordered, request)
+	}
+
+	return ordered
+}
+
+func mergeValues(destination map[string]interface{}, source map[string]interface{}) {
+	for key, value := range source {
+		if nested, ok := value.(map[string]interface{}); ok {
+			if existing, ok := destination[key].(map[string]interface{}); ok {
+				mergeValues(existing, nested)
+				continue
+			}
+		}
+
+		destination[key] = value
+	}
+}
@@ -0,0 +1,187 @@
+package packer
+
+import (
+	"encoding/json"
+	"github.com/simplecontainer/smr/pkg/relations"
+	"github.com/stretchr/testify/assert"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+const base = `kind: configuration
+prefix: simplecontainer.io/v1
+meta:
+  group: app
+  name: settings
+spec:
+  data:
+    environment: "{{ .environment }}"
+    replicas: "{{ .api.replicas }}"
+    image: "{{ .api.image }}"
+---
+kind: configuration
+prefix: simplecontainer.io/v1
+meta:
+  group: app
+  name: feature
+spec:
+  data:
+    enabled: "false"
+`
+
+func layer(t *testing.T, files map[string]string) string {
+	directory := t.TempDir()
+
+	for name, content := range files {
+		path := filepath.Join(directory, name)
+
+		assert.NoError(t, os.MkdirAll(filepath.Dir(path), 0755))
+		assert.NoError(t, os.WriteFile(path, []byte(content), 0644))
+	}
+
+	return directory
+}
+
+func TestReadLayers(t *testing.T) {
+	registry := relations.NewDefinitionRelationRegistry()
+
+	basePack := layer(t, map[string]string{
+		"Pack.yaml":                  "name: app\nversion: 0.0.1\n",
+		"definitions/app.yaml":       base,
+		"definitions/variables.yaml": "environment: dev\napi:\n  replicas: 1\n  image: api\n",
+	})
+
+	tests := []struct {
+		name     string
+		overlays []map[string]string
+		expected map[string]map[string]string
+		wantErr  error
+	}{
+		{
+			"Base without overlays",
+			nil,
+			map[string]map[string]string{
+				"settings": {"environment": "dev", "replicas": "1", "image": "api"},
+				"feature":  {"enabled": "false"},
+			},
+			nil,
+		},
+		{
+			"Variables are merged",
+			[]map[string]string{
+				{"definitions/variables.yaml": "environment: staging\napi:\n  replicas: 2\n"},
+				{"definitions/variables.yaml": "environment: prod\n"},
+			},
+			map[string]map[string]string{
+				"settings": {"environment": "prod", "replicas": "2", "image": "api"},
+				"feature":  {"enabled": "false"},
+			},
+			nil,
+		},
+		{
+			"Definition is replaced and patched",
+			[]map[string]string{
+				{
+					"definitions/feature.yaml": "kind: configuration\nprefix: simplecontainer.io/v1\nmeta:\n  group: app\n  name: feature\nspec:\n  data:\n    enabled: \"true\"\n",
+					"patches/settings.yaml":    "target:\n  kind: configuration\n  name: settings\nmerge:\n  spec:\n    data:\n      image: null\n      region: \"{{ .environment }}-eu\"\n---\ntarget:\n  name: feature\njson:\n  - op: add\n    path: /spec/data/rollout\n    value: \"10\"\n",
+				},
+			},
+			map[string]map[string]string{
+				"settings": {"environment": "dev", "replicas": "1", "region": "dev-eu"},
+				"feature":  {"enabled": "true", "rollout": "10"},
+			},
+			nil,
+		},
+		{
+			"Patch without target",
+			[]map[string]string{{"patches/missing.yaml": "target:\n  name: missing\nmerge:\n  spec: {}\n"}},
+			nil,
+			ERROR_PATCH_TARGET,
+		},
+		{
+			"Patch renaming the definition",
+			[]map[string]string{{"patches/rename.yaml": "target:\n  name: feature\nmerge:\n  meta:\n    name: renamed\n"}},
+			nil,
+			ERROR_PATCH_IMMUTABLE,
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			paths := []string{basePack}
+
+			for _, overlay := range tc.overlays {
+				paths = append(paths, layer(t, overlay))
+			}
+
+			pack, err := ReadLayers(paths, nil, registry)
+
+			if tc.wantErr != nil {
+				assert.ErrorIs(t, err, tc.wantErr)
+				return
+			}
+
+			assert.NoError(t, err)
+			assert.Equal(t, "app", pack.Name)
+			assert.Len(t, pack.Definitions, len(tc.expected))
+
+			for _, definition := range pack.Definitions {
+				data := struct {
+					Spec struct {
+						Data map[string]string `json:"data"`
+					} `json:"spec"`
+				}{}
+
+				bytes, err := definition.Definition.Definition.ToJSON()
+				assert.NoError(t, err)
+				assert.NoError(t, json.Unmarshal(bytes, &data))
+
+				assert.Equal(t, tc.expected[definition.Definition.Definition.GetMeta().Name], data.Spec.Data)
+			}
+		})
+	}
+}
+
+func TestMerge(t *testing.T) {
+	tests := []struct {
+		name     string
+		document string
+		patch    string
+		expected string
+	}{
+		{
+			"Envs are merged by key",
+			`{"envs":["A=1","B=2"]}`,
+			`{"envs":["B=3","C=4"]}`,
+			`{"envs":["A=1","B=3","C=4"]}`,
+		},
+		{
+			"Volumes are merged by name",
+			`{"volumes":[{"name":"data","hostPath":"/data"},{"name":"logs","hostPath":"/logs"}]}`,
+			`{"volumes":[{"name":"data","hostPath":"/mnt/data"}]}`,
+			`{"volumes":[{"name":"data","hostPath":"/mnt/data"},{"name":"logs","hostPath":"/logs"}]}`,
+		},
+		{
+			"Ports are merged by container port",
+			`{"ports":[{"container":"80","host":"8080"}]}`,
+			`{"ports":[{"container":"80","host":"9090"},{"container":"443","host":"8443"}]}`,
+			`{"ports":[{"container":"80","host":"9090"},{"container":"443","host":"8443"}]}`,
+		},
+		{
+			"Lists without key are replaced",
+			`{"args":["--verbose"],"keep":true}`,
+			`{"args":["--quiet"],"keep":null}`,
+			`{"args":["--quiet"]}`,
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			merged, err := Merge([]byte(tc.document), []byte(tc.patch))
+
+			assert.NoError(t, err)
+			assert.JSONEq(t, tc.expected, string(merged))
+		})
+	}
+}
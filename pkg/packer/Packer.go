@@ -163,6 +163,22 @@ func ParseYAML(name string, yamlBytes []byte, variablesBytes []byte, set []strin
 	return data, nil
 }
 
+// WriteOrigin records where the pack was pulled from so trust policy of the registry applies when it is applied
+func WriteOrigin(path string, source string) error {
+	return os.WriteFile(filepath.Join(path, PackageOriginFile), []byte(source), 0644)
+}
+
+// Origin returns source recorded by WriteOrigin, empty for packs created locally
+func Origin(path string) string {
+	origin, err := os.ReadFile(filepath.Join(path, PackageOriginFile))
+
+	if err != nil {
+		return ""
+	}
+
+	return strings.TrimSpace(string(origin))
+}
+
 func ReadYAMLFile(path string) ([]byte, error) {
 	YAML, err := os.ReadFile(path)
 
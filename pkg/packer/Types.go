@@ -1,13 +1,18 @@
 package packer
 
-import "github.com/simplecontainer/smr/pkg/kinds/common"
+import (
+	"encoding/json"
+	"github.com/simplecontainer/smr/pkg/kinds/common"
+)
 
 const (
 	PackageMetadataFile = "Pack.yaml"
+	PackageOriginFile   = ".origin"
 )
 
 type Definition struct {
 	File       string
+	Layer      int
 	Definition *common.Request
 }
 
@@ -17,3 +22,17 @@ type Pack struct {
 	Definitions []*Definition `yaml:"-"`
 	Variables   []byte        `yaml:"-"`
 }
+
+// Patch is read from the patches directory of the layer, merge is JSON merge patch with lists merged by key (see
+// Merge) and json is RFC 6902 patch which can also remove list elements
+type Patch struct {
+	Target PatchTarget     `json:"target"`
+	Merge  json.RawMessage `json:"merge"`
+	JSON   json.RawMessage `json:"json"`
+}
+
+type PatchTarget struct {
+	Kind  string `json:"kind"`
+	Group string `json:"group"`
+	Name  string `json:"name"`
+}
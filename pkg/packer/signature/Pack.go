@@ -0,0 +1,211 @@
+package signature
+
+import (
+	"bytes"
+	"crypto/ecdsa"
+	"crypto/sha256"
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/hex"
+	"encoding/json"
+	"encoding/pem"
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+)
+
+var ERROR_UNSIGNED = errors.New("pack is not signed")
+var ERROR_UNTRUSTED_KEY = errors.New("pack is signed with untrusted key")
+var ERROR_INVALID_SIGNATURE = errors.New("pack signature is invalid")
+
+// Digest hashes every file of the pack except hidden ones and the signature itself, same files end up in the OCI layer
+func Digest(directory string) (string, error) {
+	directory = filepath.Clean(directory)
+	lines := make([]string, 0)
+
+	err := filepath.WalkDir(directory, func(path string, entry fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+
+		if path != directory && strings.HasPrefix(entry.Name(), ".") {
+			if entry.IsDir() {
+				return filepath.SkipDir
+			}
+
+			return nil
+		}
+
+		if entry.IsDir() {
+			return nil
+		}
+
+		relative, err := filepath.Rel(directory, path)
+		if err != nil {
+			return err
+		}
+
+		if relative == SignatureFile {
+			return nil
+		}
+
+		// Symlinks are followed so the content that gets applied is what gets hashed
+		content, err := os.ReadFile(path)
+		if err != nil {
+			return err
+		}
+
+		lines = append(lines, fmt.Sprintf("%x  %s\n", sha256.Sum256(content), filepath.ToSlash(relative)))
+		return nil
+	})
+
+	if err != nil {
+		return "", err
+	}
+
+	sort.Strings(lines)
+
+	return fmt.Sprintf("sha256:%x", sha256.Sum256([]byte(strings.Join(lines, "")))), nil
+}
+
+// Sign writes signature of the pack content to the Pack.sig in the pack root
+func Sign(directory string, signer *Signer) (*Signature, error) {
+	digest, err := Digest(directory)
+	if err != nil {
+		return nil, fmt.Errorf("failed to digest pack: %w", err)
+	}
+
+	sig, err := SignPackage(digest, signer)
+	if err != nil {
+		return nil, err
+	}
+
+	if sig == nil {
+		return nil, errors.New("private key for signing is not set")
+	}
+
+	data, err := json.MarshalIndent(sig, "", "  ")
+	if err != nil {
+		return nil, err
+	}
+
+	err = os.WriteFile(filepath.Join(directory, SignatureFile), data, 0644)
+	if err != nil {
+		return nil, err
+	}
+
+	return sig, nil
+}
+
+func Read(directory string) (*Signature, error) {
+	data, err := os.ReadFile(filepath.Join(directory, SignatureFile))
+	if err != nil {
+		if errors.Is(err, fs.ErrNotExist) {
+			return nil, ERROR_UNSIGNED
+		}
+
+		return nil, err
+	}
+
+	sig := &Signature{}
+
+	if err = json.Unmarshal(data, sig); err != nil {
+		return nil, fmt.Errorf("%w: %s", ERROR_INVALID_SIGNATURE, err)
+	}
+
+	return sig, nil
+}
+
+// Verify checks that the pack is signed by one of the trusted keys and that content wasn't changed since
+func Verify(directory string, trusted []string) (*Signature, error) {
+	sig, err := Read(directory)
+	if err != nil {
+		return nil, err
+	}
+
+	if !IsTrusted(sig.PublicKey, trusted) {
+		return sig, fmt.Errorf("%w: %s", ERROR_UNTRUSTED_KEY, Fingerprint(sig.PublicKey))
+	}
+
+	digest, err := Digest(directory)
+	if err != nil {
+		return sig, fmt.Errorf("failed to digest pack: %w", err)
+	}
+
+	if err = VerifyPackage(digest, sig); err != nil {
+		return sig, fmt.Errorf("%w: %s", ERROR_INVALID_SIGNATURE, err)
+	}
+
+	return sig, nil
+}
+
+// IsTrusted compares decoded keys so encoding differences like padding or whitespace don't matter
+func IsTrusted(publicKey string, trusted []string) bool {
+	key, err := base64.StdEncoding.DecodeString(strings.TrimSpace(publicKey))
+	if err != nil {
+		return false
+	}
+
+	for _, t := range trusted {
+		candidate, err := base64.StdEncoding.DecodeString(strings.TrimSpace(t))
+
+		if err == nil && bytes.Equal(key, candidate) {
+			return true
+		}
+	}
+
+	return false
+}
+
+// PublicKey converts PEM encoded ECDSA public key to the form used in signatures and trust policies
+func PublicKey(data []byte) (string, error) {
+	block, _ := pem.Decode(data)
+	if block == nil {
+		return "", fmt.Errorf("failed to decode PEM block")
+	}
+
+	key, err := x509.ParsePKIXPublicKey(block.Bytes)
+	if err != nil {
+		return "", fmt.Errorf("failed to parse public key: %w", err)
+	}
+
+	if _, ok := key.(*ecdsa.PublicKey); !ok {
+		return "", fmt.Errorf("not an ECDSA public key")
+	}
+
+	return base64.StdEncoding.EncodeToString(block.Bytes), nil
+}
+
+// ValidatePublicKey checks that the base64 encoded key is ECDSA public key
+func ValidatePublicKey(publicKey string) error {
+	data, err := base64.StdEncoding.DecodeString(strings.TrimSpace(publicKey))
+	if err != nil {
+		return fmt.Errorf("failed to decode public key: %w", err)
+	}
+
+	key, err := x509.ParsePKIXPublicKey(data)
+	if err != nil {
+		return fmt.Errorf("failed to parse public key: %w", err)
+	}
+
+	if _, ok := key.(*ecdsa.PublicKey); !ok {
+		return fmt.Errorf("not an ECDSA public key")
+	}
+
+	return nil
+}
+
+// Fingerprint is short form of the key shown to the users
+func Fingerprint(publicKey string) string {
+	data, err := base64.StdEncoding.DecodeString(strings.TrimSpace(publicKey))
+	if err != nil {
+		return "invalid key"
+	}
+
+	sum := sha256.Sum256(data)
+	return fmt.Sprintf("SHA256:%s", hex.EncodeToString(sum[:8]))
+}
@@ -0,0 +1,108 @@
+package signature
+
+import (
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/pem"
+	"github.com/stretchr/testify/assert"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func newKey(t *testing.T) (string, string) {
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	assert.NoError(t, err)
+
+	private, err := x509.MarshalECPrivateKey(key)
+	assert.NoError(t, err)
+
+	path := filepath.Join(t.TempDir(), "key.pem")
+	assert.NoError(t, os.WriteFile(path, pem.EncodeToMemory(&pem.Block{Type: "EC PRIVATE KEY", Bytes: private}), 0600))
+
+	public, err := x509.MarshalPKIXPublicKey(&key.PublicKey)
+	assert.NoError(t, err)
+
+	return path, base64.StdEncoding.EncodeToString(public)
+}
+
+func newPack(t *testing.T) string {
+	directory := t.TempDir()
+
+	assert.NoError(t, os.MkdirAll(filepath.Join(directory, "definitions"), 0755))
+	assert.NoError(t, os.MkdirAll(filepath.Join(directory, ".git"), 0755))
+	assert.NoError(t, os.WriteFile(filepath.Join(directory, "Pack.yaml"), []byte("name: app\nversion: 0.0.1\n"), 0644))
+	assert.NoError(t, os.WriteFile(filepath.Join(directory, "definitions", "app.yaml"), []byte("kind: containers\n"), 0644))
+	assert.NoError(t, os.WriteFile(filepath.Join(directory, ".git", "HEAD"), []byte("ref: refs/heads/main\n"), 0644))
+
+	return directory
+}
+
+func TestVerify(t *testing.T) {
+	privateKey, publicKey := newKey(t)
+	_, otherKey := newKey(t)
+
+	tests := []struct {
+		name    string
+		sign    bool
+		trusted []string
+		modify  func(directory string)
+		err     error
+	}{
+		{name: "Trusted", sign: true, trusted: []string{otherKey, publicKey}},
+		{name: "Hidden files are ignored", sign: true, trusted: []string{publicKey}, modify: func(directory string) {
+			os.WriteFile(filepath.Join(directory, ".git", "HEAD"), []byte("ref: refs/heads/other\n"), 0644)
+			os.WriteFile(filepath.Join(directory, ".origin"), []byte("registry.example.com/app"), 0644)
+		}},
+		{name: "Unsigned", sign: false, trusted: []string{publicKey}, err: ERROR_UNSIGNED},
+		{name: "Untrusted key", sign: true, trusted: []string{otherKey}, err: ERROR_UNTRUSTED_KEY},
+		{name: "Modified definition", sign: true, trusted: []string{publicKey}, err: ERROR_INVALID_SIGNATURE, modify: func(directory string) {
+			os.WriteFile(filepath.Join(directory, "definitions", "app.yaml"), []byte("kind: containers\nimage: evil\n"), 0644)
+		}},
+		{name: "Added definition", sign: true, trusted: []string{publicKey}, err: ERROR_INVALID_SIGNATURE, modify: func(directory string) {
+			os.WriteFile(filepath.Join(directory, "definitions", "extra.yaml"), []byte("kind: containers\n"), 0644)
+		}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			directory := newPack(t)
+
+			if tt.sign {
+				sig, err := Sign(directory, &Signer{PrivateKeyPath: privateKey, SignerName: "alice"})
+				assert.NoError(t, err)
+				assert.Equal(t, publicKey, sig.PublicKey)
+			}
+
+			if tt.modify != nil {
+				tt.modify(directory)
+			}
+
+			_, err := Verify(directory, tt.trusted)
+
+			if tt.err != nil {
+				assert.ErrorIs(t, err, tt.err)
+			} else {
+				assert.NoError(t, err)
+			}
+		})
+	}
+}
+
+func TestPublicKey(t *testing.T) {
+	_, publicKey := newKey(t)
+
+	der, err := base64.StdEncoding.DecodeString(publicKey)
+	assert.NoError(t, err)
+
+	key, err := PublicKey(pem.EncodeToMemory(&pem.Block{Type: "PUBLIC KEY", Bytes: der}))
+	assert.NoError(t, err)
+	assert.Equal(t, publicKey, key)
+	assert.NoError(t, ValidatePublicKey(key))
+
+	_, err = PublicKey([]byte("not a key"))
+	assert.Error(t, err)
+}
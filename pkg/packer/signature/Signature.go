@@ -29,7 +29,10 @@ func SignPackage(manifestDigest string, signer *Signer) (*Signature, error) {
 		return nil, fmt.Errorf("failed to sign: %w", err)
 	}
 
-	signatureBytes := append(r.Bytes(), s.Bytes()...)
+	// Both halves are padded to 32 bytes since VerifyPackage splits the signature in the middle
+	signatureBytes := make([]byte, 64)
+	r.FillBytes(signatureBytes[:32])
+	s.FillBytes(signatureBytes[32:])
 	signatureB64 := base64.StdEncoding.EncodeToString(signatureBytes)
 
 	publicBytes, err := x509.MarshalPKIXPublicKey(&privateKey.PublicKey)
@@ -2,6 +2,7 @@ package signature
 
 const (
 	SignatureMediaType = "application/vnd.simplecontainer.signature.v1+json"
+	SignatureFile      = "Pack.sig"
 )
 
 type Signer struct {
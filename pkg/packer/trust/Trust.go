@@ -0,0 +1,144 @@
+package trust
+
+import (
+	"encoding/base64"
+	"fmt"
+	"github.com/simplecontainer/smr/pkg/packer/signature"
+	"net/url"
+	"sort"
+	"strings"
+)
+
+func New() *Policies {
+	return &Policies{
+		Policies: make(map[string]*Policy),
+	}
+}
+
+// Key is the name policy is stored under since scope contains slashes
+func Key(scope string) string {
+	return base64.RawURLEncoding.EncodeToString([]byte(Source(scope)))
+}
+
+// Source normalizes registry repositories and git remotes to host/path eg. git@github.com:org/app.git to github.com/org/app
+func Source(source string) string {
+	source = strings.TrimSpace(source)
+
+	if source == SCOPE_ALL || source == "" {
+		return source
+	}
+
+	if strings.Contains(source, "://") {
+		if u, err := url.Parse(source); err == nil {
+			source = u.Host + u.Path
+		}
+	} else if user, rest, found := strings.Cut(source, "@"); found && !strings.Contains(user, "/") {
+		// scp-like git remote user@host:path
+		source = strings.Replace(rest, ":", "/", 1)
+	}
+
+	source = strings.TrimSuffix(strings.Trim(source, "/"), ".git")
+
+	host, path, _ := strings.Cut(source, "/")
+
+	if path == "" {
+		return strings.ToLower(host)
+	}
+
+	return fmt.Sprintf("%s/%s", strings.ToLower(host), path)
+}
+
+func (policies *Policies) Add(policy *Policy) {
+	policies.lock.Lock()
+	defer policies.lock.Unlock()
+
+	policy.Scope = Source(policy.Scope)
+	policies.Policies[policy.Scope] = policy
+}
+
+func (policies *Policies) Remove(scope string) {
+	policies.lock.Lock()
+	defer policies.lock.Unlock()
+
+	delete(policies.Policies, Source(scope))
+}
+
+func (policies *Policies) List() []*Policy {
+	policies.lock.RLock()
+	defer policies.lock.RUnlock()
+
+	list := make([]*Policy, 0, len(policies.Policies))
+
+	for _, policy := range policies.Policies {
+		list = append(list, policy)
+	}
+
+	sort.Slice(list, func(i, j int) bool {
+		return list[i].Scope < list[j].Scope
+	})
+
+	return list
+}
+
+// Match returns the most specific policy covering the source, nil means packs from the source aren't verified
+func (policies *Policies) Match(source string) *Policy {
+	policies.lock.RLock()
+	defer policies.lock.RUnlock()
+
+	source = Source(source)
+
+	var matched *Policy
+
+	for scope, policy := range policies.Policies {
+		if scope == SCOPE_ALL {
+			continue
+		}
+
+		if source == scope || strings.HasPrefix(source, scope+"/") {
+			if matched == nil || len(scope) > len(matched.Scope) {
+				matched = policy
+			}
+		}
+	}
+
+	if matched == nil {
+		matched = policies.Policies[SCOPE_ALL]
+	}
+
+	return matched
+}
+
+// Enforced is true once any policy exists
+func (policies *Policies) Enforced() bool {
+	policies.lock.RLock()
+	defer policies.lock.RUnlock()
+
+	return len(policies.Policies) > 0
+}
+
+// Require is Verify for packs whose source is reported by the client, the source isn't covered by the signature so
+// once any policy exists source without matching policy is rejected instead of skipping verification
+func (policies *Policies) Require(source string, directory string) error {
+	if policies.Enforced() && policies.Match(source) == nil {
+		return fmt.Errorf("%w: no trust policy covers origin %q", signature.ERROR_UNSIGNED, source)
+	}
+
+	return policies.Verify(source, directory)
+}
+
+// Verify rejects the pack in the directory unless it is signed by key trusted for the source
+func (policies *Policies) Verify(source string, directory string) error {
+	policy := policies.Match(source)
+
+	if policy == nil {
+		return nil
+	}
+
+	_, err := signature.Verify(directory, policy.Keys)
+
+	if err != nil {
+		return fmt.Errorf("%w (trust policy %s)", err, policy.Scope)
+	}
+
+	return nil
+}
@@ -0,0 +1,88 @@
+package trust
+
+import (
+	"github.com/simplecontainer/smr/pkg/packer/signature"
+	"github.com/stretchr/testify/assert"
+	"testing"
+)
+
+func TestSource(t *testing.T) {
+	tests := []struct {
+		source   string
+		expected string
+	}{
+		{source: "https://github.com/Org/app.git", expected: "github.com/Org/app"},
+		{source: "git@GitHub.com:Org/app.git", expected: "github.com/Org/app"},
+		{source: "ssh://git@github.com/org/app", expected: "github.com/org/app"},
+		{source: "registry.example.com/team/app/", expected: "registry.example.com/team/app"},
+		{source: "localhost:5000/team/app", expected: "localhost:5000/team/app"},
+		{source: "*", expected: "*"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.source, func(t *testing.T) {
+			assert.Equal(t, tt.expected, Source(tt.source))
+		})
+	}
+}
+
+func TestMatch(t *testing.T) {
+	policies := New()
+
+	policies.Add(&Policy{Scope: "registry.example.com/team", Keys: []string{"team"}})
+	policies.Add(&Policy{Scope: "https://registry.example.com/team/payments", Keys: []string{"payments"}})
+	policies.Add(&Policy{Scope: "github.com/org", Keys: []string{"org"}})
+
+	tests := []struct {
+		name   string
+		source string
+		scope  string
+	}{
+		{name: "Namespace", source: "registry.example.com/team/app", scope: "registry.example.com/team"},
+		{name: "Most specific", source: "registry.example.com/team/payments", scope: "registry.example.com/team/payments"},
+		{name: "Git remote", source: "git@github.com:org/app.git", scope: "github.com/org"},
+		{name: "Prefix is not a segment", source: "registry.example.com/teams/app", scope: ""},
+		{name: "No policy", source: "docker.io/app", scope: ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			policy := policies.Match(tt.source)
+
+			if tt.scope == "" {
+				assert.Nil(t, policy)
+			} else {
+				assert.Equal(t, tt.scope, policy.Scope)
+			}
+		})
+	}
+
+	policies.Add(&Policy{Scope: SCOPE_ALL, Keys: []string{"all"}})
+	assert.Equal(t, SCOPE_ALL, policies.Match("docker.io/app").Scope)
+	assert.Equal(t, "github.com/org", policies.Match("github.com/org/app").Scope)
+
+	policies.Remove("https://github.com/org")
+	assert.Equal(t, SCOPE_ALL, policies.Match("github.com/org/app").Scope)
+}
+
+func TestVerify(t *testing.T) {
+	policies := New()
+	policies.Add(&Policy{Scope: "github.com/org", Keys: []string{"org"}})
+
+	// Sources without policy are not verified
+	assert.NoError(t, policies.Verify("github.com/other/app", t.TempDir()))
+	assert.ErrorContains(t, policies.Verify("github.com/org/app", t.TempDir()), "pack is not signed (trust policy github.com/org)")
+}
+
+func TestRequire(t *testing.T) {
+	policies := New()
+
+	// Nothing is enforced until the first policy is added
+	assert.NoError(t, policies.Require("", t.TempDir()))
+
+	policies.Add(&Policy{Scope: "github.com/org", Keys: []string{"org"}})
+
+	assert.ErrorIs(t, policies.Require("", t.TempDir()), signature.ERROR_UNSIGNED)
+	assert.ErrorIs(t, policies.Require("github.com/other/app", t.TempDir()), signature.ERROR_UNSIGNED)
+	assert.ErrorContains(t, policies.Require("github.com/org/app", t.TempDir()), "trust policy github.com/org")
+}
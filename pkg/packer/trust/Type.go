@@ -0,0 +1,25 @@
+package trust
+
+import (
+	"sync"
+	"time"
+)
+
+const KIND_TRUST = "packtrust"
+const GROUP_INTERNAL = "internal"
+
+// SCOPE_ALL matches every source which isn't matched by more specific scope
+const SCOPE_ALL = "*"
+
+type Policies struct {
+	Policies map[string]*Policy
+	lock     sync.RWMutex
+}
+
+// Policy lists public keys allowed to sign packs coming from the registry or namespace eg. registry.example.com/team
+type Policy struct {
+	Scope string    `json:"scope"`
+	Keys  []string  `json:"keys"` // base64 encoded PKIX public keys
+	By    string    `json:"by"`
+	Time  time.Time `json:"time"`
+}
@@ -386,7 +386,7 @@ func (rc *RaftNode) startRaft(keys *keys.Keys, tlsConfig *tls.Config) {
 			ClientCertAuth: true,
 			KeyFile:        keys.Server.PrivateKeyPath,
 			CertFile:       keys.Server.CertificatePath,
-			TrustedCAFile:  keys.CA.CertificatePath,
+			TrustedCAFile:  keys.TrustPath,
 			HandshakeFailure: func(conn *tls.Conn, err error) {
 				fmt.Println(err.Error())
 				conn.Close()
@@ -547,6 +547,11 @@ func (rc *RaftNode) TransferLeadership(ctx context.Context, nodeID uint64) {
 	rc.node.TransferLeadership(ctx, uint64(rc.id), nodeID)
 }
 
+// ActiveSince returns since when the peer is reachable over raft transport, zero time if it is not
+func (rc *RaftNode) ActiveSince(nodeID uint64) time.Time {
+	return rc.transport.ActiveSince(types.ID(nodeID))
+}
+
 func (rc *RaftNode) OnLeadershipChange(isLeader bool) {
 	if isLeader {
 		log.Printf("node %d is now the leader", rc.id)
@@ -0,0 +1,57 @@
+package rbac
+
+import (
+	v1 "github.com/simplecontainer/smr/pkg/definitions/v1"
+	"sync"
+)
+
+// Policies caches roles and bindings for the API, role and rolebinding kinds invalidate it on every change
+var Policies = NewCache()
+
+type Cache struct {
+	Roles      []*v1.RoleDefinition
+	Bindings   []*v1.RoleBindingDefinition
+	Loaded     bool
+	generation uint64
+	lock       sync.RWMutex
+}
+
+func NewCache() *Cache {
+	return &Cache{}
+}
+
+// Get returns cached policies or loads them, result of the load is dropped if cache was invalidated meanwhile
+func (cache *Cache) Get(load func() ([]*v1.RoleDefinition, []*v1.RoleBindingDefinition, error)) ([]*v1.RoleDefinition, []*v1.RoleBindingDefinition, error) {
+	cache.lock.RLock()
+
+	if cache.Loaded {
+		defer cache.lock.RUnlock()
+		return cache.Roles, cache.Bindings, nil
+	}
+
+	generation := cache.generation
+	cache.lock.RUnlock()
+
+	roles, bindings, err := load()
+
+	if err != nil {
+		return nil, nil, err
+	}
+
+	cache.lock.Lock()
+	defer cache.lock.Unlock()
+
+	if cache.generation == generation {
+		cache.Roles, cache.Bindings, cache.Loaded = roles, bindings, true
+	}
+
+	return roles, bindings, nil
+}
+
+func (cache *Cache) Invalidate() {
+	cache.lock.Lock()
+	defer cache.lock.Unlock()
+
+	cache.Loaded = false
+	cache.generation++
+}
@@ -0,0 +1,89 @@
+package rbac
+
+import (
+	"errors"
+	"fmt"
+	v1 "github.com/simplecontainer/smr/pkg/definitions/v1"
+	"github.com/simplecontainer/smr/pkg/static"
+	"slices"
+)
+
+var VERBS = []string{VERB_GET, VERB_LIST, VERB_APPLY, VERB_DELETE, VERB_LOGS, VERB_EXEC, WILDCARD}
+
+var ERROR_FORBIDDEN = errors.New("forbidden")
+
+// Validate rejects rules with verbs that are never checked so typos don't silently grant nothing
+func Validate(rules []v1.RoleRule) error {
+	for _, rule := range rules {
+		for _, verb := range rule.Verbs {
+			if !slices.Contains(VERBS, verb) {
+				return errors.New(fmt.Sprintf("unknown verb %s, allowed verbs are %v", verb, VERBS))
+			}
+		}
+	}
+
+	return nil
+}
+
+// Authorize returns error wrapping ERROR_FORBIDDEN unless any role bound to the user grants the request
+func Authorize(username string, request Request, roles []*v1.RoleDefinition, bindings []*v1.RoleBindingDefinition) error {
+	for _, binding := range bindings {
+		if !slices.Contains(binding.Spec.Users, username) && !slices.Contains(binding.Spec.Users, WILDCARD) {
+			continue
+		}
+
+		for _, role := range roles {
+			if role.Meta.Group != binding.Spec.Role.Group || role.Meta.Name != binding.Spec.Role.Name {
+				continue
+			}
+
+			for _, rule := range role.Spec.Rules {
+				if Matches(rule, request) {
+					return nil
+				}
+			}
+		}
+	}
+
+	return fmt.Errorf("%w: user %s cannot %s", ERROR_FORBIDDEN, username, request.String())
+}
+
+// Resource is the kind rules are matched against, categories other than kind are resources of their own eg. state of
+// containers is containers/state so granting the kind doesn't grant its state, revisions or raw keys
+func Resource(kind string, category string) string {
+	if kind == "" || category == "" || category == static.CATEGORY_KIND {
+		return kind
+	}
+
+	return fmt.Sprintf("%s/%s", kind, category)
+}
+
+func Matches(rule v1.RoleRule, request Request) bool {
+	return match(rule.Verbs, request.Verb) && match(rule.Kinds, request.Kind) && match(rule.Groups, request.Group)
+}
+
+func (request Request) String() string {
+	kind := request.Kind
+	group := request.Group
+
+	if kind == "" {
+		kind = "all kinds"
+	}
+
+	if group == "" {
+		group = "all groups"
+	} else {
+		group = fmt.Sprintf("group %s", group)
+	}
+
+	return fmt.Sprintf("%s %s in %s", request.Verb, kind, group)
+}
+
+// match treats empty value as request spanning everything which only wildcard can grant
+func match(allowed []string, value string) bool {
+	if slices.Contains(allowed, WILDCARD) {
+		return true
+	}
+
+	return value != "" && slices.Contains(allowed, value)
+}
@@ -0,0 +1,110 @@
+package rbac
+
+import (
+	"github.com/simplecontainer/smr/pkg/definitions/commonv1"
+	v1 "github.com/simplecontainer/smr/pkg/definitions/v1"
+	"github.com/stretchr/testify/assert"
+	"testing"
+)
+
+func role(group string, name string, rules ...v1.RoleRule) *v1.RoleDefinition {
+	return &v1.RoleDefinition{
+		Meta: &commonv1.Meta{Group: group, Name: name},
+		Spec: v1.RoleSpec{Rules: rules},
+	}
+}
+
+func binding(group string, name string, users ...string) *v1.RoleBindingDefinition {
+	return &v1.RoleBindingDefinition{
+		Meta: &commonv1.Meta{Group: "rbac", Name: name},
+		Spec: v1.RoleBindingSpec{
+			Role:  v1.RoleBindingRoleRef{Group: group, Name: name},
+			Users: users,
+		},
+	}
+}
+
+func TestAuthorize(t *testing.T) {
+	roles := []*v1.RoleDefinition{
+		role("rbac", "contractor", v1.RoleRule{
+			Verbs:  []string{VERB_GET, VERB_LIST, VERB_LOGS},
+			Kinds:  []string{"containers"},
+			Groups: []string{"production", "staging"},
+		}),
+		role("rbac", "admin", v1.RoleRule{
+			Verbs:  []string{WILDCARD},
+			Kinds:  []string{WILDCARD},
+			Groups: []string{WILDCARD},
+		}),
+	}
+
+	bindings := []*v1.RoleBindingDefinition{
+		binding("rbac", "contractor", "alice"),
+		binding("rbac", "admin", "bob"),
+	}
+
+	tests := []struct {
+		name     string
+		user     string
+		request  Request
+		expected bool
+	}{
+		{name: "Contractor tails logs", user: "alice", request: Request{Verb: VERB_LOGS, Kind: "containers", Group: "production"}, expected: true},
+		{name: "Contractor can't delete", user: "alice", request: Request{Verb: VERB_DELETE, Kind: "containers", Group: "production"}, expected: false},
+		{name: "Contractor can't exec", user: "alice", request: Request{Verb: VERB_EXEC, Kind: "containers", Group: "staging"}, expected: false},
+		{name: "Contractor can't read secrets", user: "alice", request: Request{Verb: VERB_GET, Kind: "secret", Group: "production"}, expected: false},
+		{name: "Contractor can't list all groups", user: "alice", request: Request{Verb: VERB_LIST, Kind: "containers"}, expected: false},
+		{name: "Admin does anything", user: "bob", request: Request{Verb: VERB_DELETE, Kind: KIND_CLUSTER}, expected: true},
+		{name: "Unbound user", user: "mallory", request: Request{Verb: VERB_GET, Kind: "containers", Group: "production"}, expected: false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := Authorize(tt.user, tt.request, roles, bindings)
+
+			if tt.expected {
+				assert.NoError(t, err)
+			} else {
+				assert.ErrorIs(t, err, ERROR_FORBIDDEN)
+			}
+		})
+	}
+}
+
+func TestValidate(t *testing.T) {
+	assert.NoError(t, Validate([]v1.RoleRule{{Verbs: []string{VERB_GET, WILDCARD}}}))
+	assert.Error(t, Validate([]v1.RoleRule{{Verbs: []string{"remove"}}}))
+}
+
+func TestCache(t *testing.T) {
+	cache := NewCache()
+	loads := 0
+
+	load := func() ([]*v1.RoleDefinition, []*v1.RoleBindingDefinition, error) {
+		loads++
+		return []*v1.RoleDefinition{role("rbac", "admin")}, []*v1.RoleBindingDefinition{binding("rbac", "admin", "alice")}, nil
+	}
+
+	for i := 0; i < 3; i++ {
+		roles, bindings, err := cache.Get(load)
+		assert.NoError(t, err)
+		assert.Len(t, roles, 1)
+		assert.Len(t, bindings, 1)
+	}
+
+	assert.Equal(t, 1, loads)
+
+	cache.Invalidate()
+	_, _, _ = cache.Get(load)
+
+	assert.Equal(t, 2, loads)
+
+	// Invalidation while loading keeps the cache empty so stale policies aren't served
+	cache.Invalidate()
+	_, _, _ = cache.Get(func() ([]*v1.RoleDefinition, []*v1.RoleBindingDefinition, error) {
+		cache.Invalidate()
+		return load()
+	})
+
+	assert.False(t, cache.Loaded)
+}
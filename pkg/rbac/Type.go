@@ -0,0 +1,29 @@
+package rbac
+
+// Request is what authenticated user is trying to do, empty kind or group means across all of them
+type Request struct {
+	Verb  string
+	Kind  string
+	Group string
+}
+
+const VERB_GET = "get"
+const VERB_LIST = "list"
+const VERB_APPLY = "apply"
+const VERB_DELETE = "delete"
+const VERB_LOGS = "logs"
+const VERB_EXEC = "exec"
+
+const WILDCARD = "*"
+
+// Endpoints not backed by any kind are authorized against these
+const KIND_CLUSTER = "cluster"
+const KIND_KEY = "key"
+const KIND_USER = "user"
+const KIND_EVENT = "event"
+const KIND_AUDIT = "audit"
+const KIND_TRUST = "trust"
+
+// Admin role and binding are seeded when cluster has no bindings
+const ADMIN_GROUP = "rbac"
+const ADMIN_NAME = "admin"
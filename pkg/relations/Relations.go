@@ -12,7 +12,7 @@ func NewDefinitionRelationRegistry() *RelationRegistry {
 
 func (defRegistry *RelationRegistry) InTree() {
 	defRegistry.Register("network", emptyDependencies)
-	defRegistry.Register("containers", []string{"network", "volume", "resource", "configuration", "certkey"})
+	defRegistry.Register("containers", []string{"network", "volume", "resource", "configuration", "certkey", "secret"})
 	defRegistry.Register("gitops", []string{"certkey", "httpauth"})
 	defRegistry.Register("configuration", []string{"secret"})
 	defRegistry.Register("resource", []string{"configuration"})
@@ -22,6 +22,9 @@ func (defRegistry *RelationRegistry) InTree() {
 	defRegistry.Register("secret", emptyDependencies)
 	defRegistry.Register("node", emptyDependencies)
 	defRegistry.Register("volume", emptyDependencies)
+	defRegistry.Register("role", emptyDependencies)
+	defRegistry.Register("rolebinding", []string{"role"})
+	defRegistry.Register("notification", emptyDependencies)
 }
 
 func (defRegistry *RelationRegistry) Register(kind string, dependencies []string) {
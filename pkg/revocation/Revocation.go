@@ -0,0 +1,80 @@
+package revocation
+
+import (
+	"crypto/x509"
+	"errors"
+	"fmt"
+	"time"
+)
+
+var ERROR_REVOKED = errors.New("certificate is revoked")
+
+func New() *List {
+	return &List{
+		Revoked: make(map[string]*Revoked),
+	}
+}
+
+// Serial is the form certificate serial number is stored and compared in
+func Serial(certificate *x509.Certificate) string {
+	return certificate.SerialNumber.Text(16)
+}
+
+func (list *List) Add(revoked *Revoked) {
+	list.lock.Lock()
+	defer list.lock.Unlock()
+
+	list.Revoked[revoked.Serial] = revoked
+}
+
+func (list *List) Remove(serial string) {
+	list.lock.Lock()
+	defer list.lock.Unlock()
+
+	delete(list.Revoked, serial)
+}
+
+func (list *List) IsRevoked(serial string) bool {
+	list.lock.RLock()
+	defer list.lock.RUnlock()
+
+	_, ok := list.Revoked[serial]
+	return ok
+}
+
+// Check rejects the certificate if its serial is on the list
+func (list *List) Check(certificate *x509.Certificate) error {
+	if certificate != nil && list.IsRevoked(Serial(certificate)) {
+		return fmt.Errorf("%w: %s", ERROR_REVOKED, certificate.Subject.CommonName)
+	}
+
+	return nil
+}
+
+// VerifyPeerCertificate plugs into tls.Config and runs after the chain is verified against the CA
+func (list *List) VerifyPeerCertificate(rawCerts [][]byte, verifiedChains [][]*x509.Certificate) error {
+	for _, chain := range verifiedChains {
+		if len(chain) == 0 {
+			continue
+		}
+
+		err := list.Check(chain[0])
+
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func (certificate *Certificate) Resolve(list *List) {
+	switch {
+	case list.IsRevoked(certificate.Serial):
+		certificate.Status = STATUS_REVOKED
+	case time.Now().After(certificate.NotAfter):
+		certificate.Status = STATUS_EXPIRED
+	default:
+		certificate.Status = STATUS_ACTIVE
+	}
+}
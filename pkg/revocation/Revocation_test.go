@@ -0,0 +1,65 @@
+package revocation
+
+import (
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"github.com/stretchr/testify/assert"
+	"math/big"
+	"testing"
+	"time"
+)
+
+func TestVerifyPeerCertificate(t *testing.T) {
+	list := New()
+
+	alice := &x509.Certificate{SerialNumber: big.NewInt(0xa11ce), Subject: pkix.Name{CommonName: "alice"}}
+	bob := &x509.Certificate{SerialNumber: big.NewInt(0xb0b), Subject: pkix.Name{CommonName: "bob"}}
+
+	list.Add(&Revoked{Serial: Serial(alice), Username: "alice"})
+
+	tests := []struct {
+		name        string
+		certificate *x509.Certificate
+		revoked     bool
+	}{
+		{name: "Revoked", certificate: alice, revoked: true},
+		{name: "Valid", certificate: bob, revoked: false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := list.VerifyPeerCertificate(nil, [][]*x509.Certificate{{tt.certificate}})
+
+			if tt.revoked {
+				assert.ErrorIs(t, err, ERROR_REVOKED)
+			} else {
+				assert.NoError(t, err)
+			}
+		})
+	}
+
+	list.Remove(Serial(alice))
+	assert.NoError(t, list.VerifyPeerCertificate(nil, [][]*x509.Certificate{{alice}}))
+}
+
+func TestResolve(t *testing.T) {
+	list := New()
+	list.Add(&Revoked{Serial: "a11ce"})
+
+	tests := []struct {
+		name        string
+		certificate Certificate
+		expected    string
+	}{
+		{name: "Active", certificate: Certificate{Serial: "b0b", NotAfter: time.Now().Add(time.Hour)}, expected: STATUS_ACTIVE},
+		{name: "Expired", certificate: Certificate{Serial: "b0b", NotAfter: time.Now().Add(-time.Hour)}, expected: STATUS_EXPIRED},
+		{name: "Revoked", certificate: Certificate{Serial: "a11ce", NotAfter: time.Now().Add(time.Hour)}, expected: STATUS_REVOKED},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.certificate.Resolve(list)
+			assert.Equal(t, tt.expected, tt.certificate.Status)
+		})
+	}
+}
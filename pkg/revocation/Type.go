@@ -0,0 +1,36 @@
+package revocation
+
+import (
+	"sync"
+	"time"
+)
+
+const KIND_REVOCATION = "revocation"
+const KIND_USERS = "users"
+const GROUP_INTERNAL = "internal"
+
+const STATUS_ACTIVE = "active"
+const STATUS_REVOKED = "revoked"
+const STATUS_EXPIRED = "expired"
+
+type List struct {
+	Revoked map[string]*Revoked
+	lock    sync.RWMutex
+}
+
+type Revoked struct {
+	Serial   string    `json:"serial"`
+	Username string    `json:"username"`
+	By       string    `json:"by"`
+	Time     time.Time `json:"time"`
+}
+
+type Certificate struct {
+	Username  string    `json:"username"`
+	Serial    string    `json:"serial"`
+	Domain    string    `json:"domain"`
+	Node      string    `json:"node"`
+	NotBefore time.Time `json:"notBefore"`
+	NotAfter  time.Time `json:"notAfter"`
+	Status    string    `json:"status,omitempty"`
+}
@@ -0,0 +1,87 @@
+package secrets
+
+import (
+	"context"
+	"fmt"
+	"gopkg.in/yaml.v3"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+// Fetch reads the file from the secrets directory, YAML or JSON files expose their keys joined with a dot
+// and the raw content is kept under empty key so file://path without a key resolves to the whole file
+func (file *File) Fetch(ctx context.Context, path string) (map[string]string, error) {
+	path, err := contained(file.Directory, path)
+
+	if err != nil {
+		return nil, err
+	}
+
+	content, err := os.ReadFile(path)
+
+	if err != nil {
+		return nil, err
+	}
+
+	data := map[string]string{"": strings.TrimSpace(string(content))}
+	document := map[string]interface{}{}
+
+	if yaml.Unmarshal(content, &document) == nil {
+		flatten("", document, data)
+	}
+
+	return data, nil
+}
+
+func flatten(prefix string, value interface{}, data map[string]string) {
+	switch v := value.(type) {
+	case map[string]interface{}:
+		for k, item := range v {
+			flatten(join(prefix, k), item, data)
+		}
+	case []interface{}:
+		for i, item := range v {
+			flatten(join(prefix, fmt.Sprint(i)), item, data)
+		}
+	case nil:
+		data[prefix] = ""
+	default:
+		data[prefix] = fmt.Sprint(v)
+	}
+}
+
+// contained resolves path relative to the directory and rejects anything outside of it, node keys must not leak
+func contained(directory string, path string) (string, error) {
+	if directory == "" {
+		return "", fmt.Errorf("%w: secrets directory is not set", ERROR_NOT_CONFIGURED)
+	}
+
+	directory, err := filepath.Abs(directory)
+
+	if err != nil {
+		return "", err
+	}
+
+	if !filepath.IsAbs(path) {
+		path = filepath.Join(directory, path)
+	}
+
+	path = filepath.Clean(path)
+
+	if !strings.HasPrefix(path, directory+string(filepath.Separator)) {
+		return "", fmt.Errorf("%w: %s is outside of %s", ERROR_FORBIDDEN_PATH, path, directory)
+	}
+
+	if real, err := filepath.EvalSymlinks(directory); err == nil {
+		directory = real
+	}
+
+	resolved, err := filepath.EvalSymlinks(path)
+
+	if err == nil && !strings.HasPrefix(resolved, directory+string(filepath.Separator)) {
+		return "", fmt.Errorf("%w: %s is outside of %s", ERROR_FORBIDDEN_PATH, resolved, directory)
+	}
+
+	return path, nil
+}
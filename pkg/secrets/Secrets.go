@@ -0,0 +1,167 @@
+package secrets
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"github.com/simplecontainer/smr/pkg/configuration"
+	"github.com/simplecontainer/smr/pkg/logger"
+	"go.uber.org/zap"
+	"net/url"
+	"strings"
+	"time"
+)
+
+var ERROR_NOT_CONFIGURED = errors.New("secret provider is not configured")
+var ERROR_UNKNOWN_PROVIDER = errors.New("unknown secret provider")
+var ERROR_NOT_FOUND = errors.New("secret not found")
+var ERROR_MISSING_KEY = errors.New("missing key in the secret")
+var ERROR_FORBIDDEN_PATH = errors.New("secret path is not allowed")
+
+func New(config *configuration.Secrets) (*Providers, error) {
+	providers := NewProviders(config.Refresh)
+
+	vault, err := NewVault(config.Vault)
+
+	if err != nil {
+		return nil, err
+	}
+
+	sops, err := NewSops(config.Directory, config.AgeKeyFile)
+
+	if err != nil {
+		return nil, err
+	}
+
+	providers.Register(SCHEME_VAULT, vault)
+	providers.Register(SCHEME_SOPS, sops)
+	providers.Register(SCHEME_FILE, &File{Directory: config.Directory})
+
+	return providers, nil
+}
+
+func NewProviders(refresh time.Duration) *Providers {
+	if refresh <= 0 {
+		refresh = configuration.DEFAULT_SECRETS_REFRESH
+	}
+
+	return &Providers{
+		Providers: make(map[string]Provider),
+		Refresh:   refresh,
+		cache:     make(map[string]*Entry),
+	}
+}
+
+func (providers *Providers) Register(scheme string, provider Provider) {
+	providers.lock.Lock()
+	defer providers.lock.Unlock()
+
+	providers.Providers[scheme] = provider
+}
+
+// Parse returns reference if the value points to one of the providers eg. vault://secret/app/db#password
+func Parse(value string) (*Reference, bool) {
+	scheme, _, found := strings.Cut(value, "://")
+
+	if !found || (scheme != SCHEME_VAULT && scheme != SCHEME_SOPS && scheme != SCHEME_FILE) {
+		return nil, false
+	}
+
+	parsed, err := url.Parse(value)
+
+	if err != nil {
+		return nil, false
+	}
+
+	reference := &Reference{
+		Scheme: parsed.Scheme,
+		Path:   parsed.Host + parsed.Path,
+		Key:    parsed.Fragment,
+	}
+
+	if parsed.Query().Get("refresh") != "" {
+		reference.Refresh, err = time.ParseDuration(parsed.Query().Get("refresh"))
+
+		if err != nil {
+			return nil, false
+		}
+	}
+
+	return reference, true
+}
+
+// Value resolves the value through the configured providers, values which are not references are returned as is
+func Value(ctx context.Context, value string) (string, error) {
+	if _, ok := Parse(value); !ok {
+		return value, nil
+	}
+
+	if Resolver == nil {
+		return "", fmt.Errorf("%w: %s", ERROR_NOT_CONFIGURED, value)
+	}
+
+	return Resolver.Resolve(ctx, value)
+}
+
+// Resolve returns the value as is unless it is a reference, in that case value is fetched from the provider
+func (providers *Providers) Resolve(ctx context.Context, value string) (string, error) {
+	reference, ok := Parse(value)
+
+	if !ok {
+		return value, nil
+	}
+
+	data, err := providers.Get(ctx, reference)
+
+	if err != nil {
+		return "", err
+	}
+
+	resolved, ok := data[reference.Key]
+
+	if !ok {
+		return "", fmt.Errorf("%w: %s", ERROR_MISSING_KEY, value)
+	}
+
+	return resolved, nil
+}
+
+// Get returns cached data of the path, data is fetched again once refresh interval passed and previous data is
+// served if provider is unreachable so containers can still start
+func (providers *Providers) Get(ctx context.Context, reference *Reference) (map[string]string, error) {
+	providers.lock.RLock()
+	provider, ok := providers.Providers[reference.Scheme]
+	entry := providers.cache[fmt.Sprintf("%s://%s", reference.Scheme, reference.Path)]
+	providers.lock.RUnlock()
+
+	if !ok {
+		return nil, fmt.Errorf("%w: %s", ERROR_UNKNOWN_PROVIDER, reference.Scheme)
+	}
+
+	refresh := providers.Refresh
+
+	if reference.Refresh > 0 {
+		refresh = reference.Refresh
+	}
+
+	if entry != nil && time.Since(entry.Fetched) < refresh {
+		return entry.Data, nil
+	}
+
+	data, err := provider.Fetch(ctx, reference.Path)
+
+	if err != nil {
+		if entry != nil && !errors.Is(err, ERROR_NOT_FOUND) {
+			logger.Log.Warn("failed to refresh secret, serving cached value", zap.String("path", reference.Path), zap.Error(err))
+			return entry.Data, nil
+		}
+
+		return nil, err
+	}
+
+	providers.lock.Lock()
+	providers.cache[fmt.Sprintf("%s://%s", reference.Scheme, reference.Path)] = &Entry{Data: data, Fetched: time.Now()}
+	providers.lock.Unlock()
+
+	return data, nil
+}
@@ -0,0 +1,278 @@
+package secrets
+
+import (
+	"bytes"
+	"context"
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/rand"
+	"crypto/sha512"
+	"encoding/base64"
+	"filippo.io/age"
+	"filippo.io/age/armor"
+	"fmt"
+	"github.com/simplecontainer/smr/pkg/logger"
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/zap"
+	"gopkg.in/yaml.v3"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func random(t *testing.T, size int) []byte {
+	b := make([]byte, size)
+	_, err := rand.Read(b)
+	assert.NoError(t, err)
+
+	return b
+}
+
+func encryptAge(t *testing.T, recipient *age.X25519Identity, plaintext []byte) string {
+	buffer := &bytes.Buffer{}
+	armored := armor.NewWriter(buffer)
+
+	writer, err := age.Encrypt(armored, recipient.Recipient())
+	assert.NoError(t, err)
+
+	_, err = writer.Write(plaintext)
+	assert.NoError(t, err)
+	assert.NoError(t, writer.Close())
+	assert.NoError(t, armored.Close())
+
+	return buffer.String()
+}
+
+func encryptSops(t *testing.T, key []byte, value string, additional string) string {
+	block, err := aes.NewCipher(key)
+	assert.NoError(t, err)
+
+	gcm, err := cipher.NewGCMWithNonceSize(block, 32)
+	assert.NoError(t, err)
+
+	iv := random(t, 32)
+	sealed := gcm.Seal(nil, iv, []byte(value), []byte(additional))
+
+	return fmt.Sprintf("ENC[AES256_GCM,data:%s,iv:%s,tag:%s,type:str]",
+		base64.StdEncoding.EncodeToString(sealed[:len(sealed)-16]),
+		base64.StdEncoding.EncodeToString(iv),
+		base64.StdEncoding.EncodeToString(sealed[len(sealed)-16:]),
+	)
+}
+
+func TestParse(t *testing.T) {
+	tests := []struct {
+		name      string
+		value     string
+		reference *Reference
+	}{
+		{name: "Vault", value: "vault://secret/app/db#password", reference: &Reference{Scheme: SCHEME_VAULT, Path: "secret/app/db", Key: "password"}},
+		{name: "Refresh", value: "sops://app.yaml?refresh=30s#db.password", reference: &Reference{Scheme: SCHEME_SOPS, Path: "app.yaml", Key: "db.password", Refresh: 30 * time.Second}},
+		{name: "Absolute file", value: "file:///run/secrets/token", reference: &Reference{Scheme: SCHEME_FILE, Path: "/run/secrets/token"}},
+		{name: "Plain value", value: "hunter2", reference: nil},
+		{name: "Other scheme", value: "https://example.com#password", reference: nil},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			reference, ok := Parse(tt.value)
+
+			assert.Equal(t, tt.reference != nil, ok)
+			assert.Equal(t, tt.reference, reference)
+		})
+	}
+}
+
+func TestVault(t *testing.T) {
+	logger.Log = zap.NewNop()
+
+	var requests atomic.Int32
+	var down atomic.Bool
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests.Add(1)
+
+		switch {
+		case down.Load():
+			w.WriteHeader(http.StatusServiceUnavailable)
+			w.Write([]byte(`{"errors":["Vault is sealed"]}`))
+		case r.Header.Get("X-Vault-Token") != "root":
+			w.WriteHeader(http.StatusForbidden)
+			w.Write([]byte(`{"errors":["permission denied"]}`))
+		case r.URL.Path == "/v1/secret/data/app/db":
+			w.Write([]byte(`{"data":{"data":{"password":"hunter2","port":5432},"metadata":{"version":3}}}`))
+		default:
+			w.WriteHeader(http.StatusNotFound)
+			w.Write([]byte(`{"errors":[]}`))
+		}
+	}))
+	defer server.Close()
+
+	t.Setenv("VAULT_TOKEN", "root")
+
+	vault, err := NewVault(nil)
+	assert.NoError(t, err)
+
+	vault.Address = server.URL
+
+	providers := NewProviders(time.Hour)
+	providers.Register(SCHEME_VAULT, vault)
+
+	value, err := providers.Resolve(context.Background(), "vault://secret/app/db#password")
+	assert.NoError(t, err)
+	assert.Equal(t, "hunter2", value)
+
+	value, err = providers.Resolve(context.Background(), "vault://secret/app/db#port")
+	assert.NoError(t, err)
+	assert.Equal(t, "5432", value)
+	assert.Equal(t, int32(1), requests.Load())
+
+	_, err = providers.Resolve(context.Background(), "vault://secret/app/db#username")
+	assert.ErrorIs(t, err, ERROR_MISSING_KEY)
+
+	_, err = providers.Resolve(context.Background(), "vault://secret/app/missing#password")
+	assert.ErrorIs(t, err, ERROR_NOT_FOUND)
+
+	// Refresh interval passed but Vault is unavailable so cached value is served
+	down.Store(true)
+
+	value, err = providers.Resolve(context.Background(), "vault://secret/app/db?refresh=1ns#password")
+	assert.NoError(t, err)
+	assert.Equal(t, "hunter2", value)
+	assert.Equal(t, int32(3), requests.Load())
+
+	t.Setenv("VAULT_TOKEN", "invalid")
+	down.Store(false)
+
+	_, err = providers.Resolve(context.Background(), "vault://secret/app/other#password")
+	assert.ErrorContains(t, err, "permission denied")
+}
+
+func TestFile(t *testing.T) {
+	directory := t.TempDir()
+
+	assert.NoError(t, os.WriteFile(filepath.Join(directory, "token"), []byte("s3cr3t\n"), 0600))
+	assert.NoError(t, os.WriteFile(filepath.Join(directory, "app.yaml"), []byte("db:\n  password: hunter2\n"), 0600))
+
+	providers := NewProviders(0)
+	providers.Register(SCHEME_FILE, &File{Directory: directory})
+
+	tests := []struct {
+		name     string
+		value    string
+		expected string
+		err      error
+	}{
+		{name: "Whole file", value: "file://token", expected: "s3cr3t"},
+		{name: "Nested key", value: "file://app.yaml#db.password", expected: "hunter2"},
+		{name: "Absolute inside directory", value: fmt.Sprintf("file://%s/token", directory), expected: "s3cr3t"},
+		{name: "Traversal", value: "file://../../etc/passwd", err: ERROR_FORBIDDEN_PATH},
+		{name: "Absolute outside directory", value: "file:///etc/passwd", err: ERROR_FORBIDDEN_PATH},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			value, err := providers.Resolve(context.Background(), tt.value)
+
+			if tt.err != nil {
+				assert.ErrorIs(t, err, tt.err)
+			} else {
+				assert.NoError(t, err)
+				assert.Equal(t, tt.expected, value)
+			}
+		})
+	}
+}
+
+func TestSops(t *testing.T) {
+	identity, err := age.GenerateX25519Identity()
+	assert.NoError(t, err)
+
+	other, err := age.GenerateX25519Identity()
+	assert.NoError(t, err)
+
+	parsed, err := age.ParseIdentities(strings.NewReader(fmt.Sprintf("# created: now\n%s\n", identity.String())))
+	assert.NoError(t, err)
+	assert.Len(t, parsed, 1)
+
+	key := random(t, 32)
+	modified := time.Now().UTC().Format(time.RFC3339)
+
+	document := func(tree map[string]interface{}, values ...string) []byte {
+		mac := sha512.New()
+
+		for _, value := range values {
+			mac.Write([]byte(value))
+		}
+
+		tree["sops"] = map[string]interface{}{
+			"age": []map[string]string{
+				{"recipient": other.Recipient().String(), "enc": encryptAge(t, other, key)},
+				{"recipient": identity.Recipient().String(), "enc": encryptAge(t, identity, key)},
+			},
+			"lastmodified": modified,
+			"mac":          encryptSops(t, key, fmt.Sprintf("%X", mac.Sum(nil)), modified),
+		}
+
+		marshaled, err := yaml.Marshal(tree)
+		assert.NoError(t, err)
+
+		return marshaled
+	}
+
+	password := encryptSops(t, key, "hunter2", "db:password:")
+	host := encryptSops(t, key, "10.0.0.1", "hosts:")
+
+	// Keys are marshaled sorted, MAC covers values in that order
+	valid := document(map[string]interface{}{
+		"db":               map[string]interface{}{"password": password},
+		"hosts":            []string{host},
+		"user_unencrypted": "admin",
+	}, "hunter2", "10.0.0.1", "admin")
+
+	directory := t.TempDir()
+	assert.NoError(t, os.WriteFile(filepath.Join(directory, "app.enc.yaml"), valid, 0600))
+
+	providers := NewProviders(0)
+	providers.Register(SCHEME_SOPS, &Sops{Directory: directory, Identities: parsed})
+
+	for reference, expected := range map[string]string{
+		"sops://app.enc.yaml#db.password":      "hunter2",
+		"sops://app.enc.yaml#hosts.0":          "10.0.0.1",
+		"sops://app.enc.yaml#user_unencrypted": "admin",
+	} {
+		value, err := providers.Resolve(context.Background(), reference)
+		assert.NoError(t, err)
+		assert.Equal(t, expected, value)
+	}
+
+	sops := &Sops{Identities: parsed}
+
+	// Value moved to another key fails authentication
+	_, err = sops.Decrypt([]byte(strings.Replace(string(valid), "db:", "cache:", 1)))
+	assert.ErrorIs(t, err, ERROR_SOPS_INVALID)
+
+	// Key removed from the file is caught by the MAC
+	_, err = sops.Decrypt(document(map[string]interface{}{
+		"db":               map[string]interface{}{"password": password},
+		"user_unencrypted": "admin",
+	}, "hunter2", "10.0.0.1", "admin"))
+	assert.ErrorIs(t, err, ERROR_SOPS_MAC)
+
+	// Changed unencrypted value is caught by the MAC
+	_, err = sops.Decrypt([]byte(strings.Replace(string(valid), "user_unencrypted: admin", "user_unencrypted: root", 1)))
+	assert.ErrorIs(t, err, ERROR_SOPS_MAC)
+
+	stranger, err := age.GenerateX25519Identity()
+	assert.NoError(t, err)
+
+	_, err = (&Sops{Identities: []age.Identity{stranger}}).Decrypt(valid)
+
+	var mismatch *age.NoIdentityMatchError
+	assert.ErrorAs(t, err, &mismatch)
+}
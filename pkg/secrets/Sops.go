@@ -0,0 +1,340 @@
+package secrets
+
+import (
+	"context"
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/sha512"
+	"encoding/base64"
+	"errors"
+	"filippo.io/age"
+	"filippo.io/age/armor"
+	"fmt"
+	"gopkg.in/yaml.v3"
+	"hash"
+	"io"
+	"os"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+)
+
+var ERROR_SOPS_INVALID = errors.New("invalid sops file")
+var ERROR_SOPS_NO_IDENTITY = errors.New("no age identity is configured for sops, set ageKeyFile or SOPS_AGE_KEY_FILE")
+var ERROR_SOPS_MAC = errors.New("sops file mac mismatch, file was tampered with")
+
+var sopsValue = regexp.MustCompile(`^ENC\[AES256_GCM,data:(.*),iv:(.+),tag:(.+),type:(.+)\]$`)
+
+type sopsFile struct {
+	Sops struct {
+		Age []struct {
+			Recipient string `yaml:"recipient"`
+			Enc       string `yaml:"enc"`
+		} `yaml:"age"`
+		LastModified     string `yaml:"lastmodified"`
+		MAC              string `yaml:"mac"`
+		MACOnlyEncrypted bool   `yaml:"mac_only_encrypted"`
+	} `yaml:"sops"`
+}
+
+func NewSops(directory string, keyFile string) (*Sops, error) {
+	sops := &Sops{Directory: directory}
+
+	content := os.Getenv("SOPS_AGE_KEY")
+
+	if keyFile == "" {
+		keyFile = os.Getenv("SOPS_AGE_KEY_FILE")
+	}
+
+	if keyFile != "" {
+		bytes, err := os.ReadFile(keyFile)
+
+		if err != nil {
+			return nil, err
+		}
+
+		content = fmt.Sprintf("%s\n%s", content, bytes)
+	}
+
+	if strings.TrimSpace(content) == "" {
+		return sops, nil
+	}
+
+	identities, err := age.ParseIdentities(strings.NewReader(content))
+
+	if err != nil {
+		return nil, err
+	}
+
+	sops.Identities = identities
+	return sops, nil
+}
+
+// Fetch decrypts YAML or JSON file encrypted by SOPS with age, nested keys are joined with a dot
+func (sops *Sops) Fetch(ctx context.Context, path string) (map[string]string, error) {
+	path, err := contained(sops.Directory, path)
+
+	if err != nil {
+		return nil, err
+	}
+
+	content, err := os.ReadFile(path)
+
+	if err != nil {
+		return nil, err
+	}
+
+	return sops.Decrypt(content)
+}
+
+// Decrypt values of the SOPS document, each value is authenticated by GCM with its path as additional data
+// and the whole document by the MAC over the values in document order, so removed or reordered keys are detected
+func (sops *Sops) Decrypt(content []byte) (map[string]string, error) {
+	if len(sops.Identities) == 0 {
+		return nil, ERROR_SOPS_NO_IDENTITY
+	}
+
+	meta := sopsFile{}
+	document := yaml.Node{}
+
+	if err := yaml.Unmarshal(content, &meta); err != nil {
+		return nil, fmt.Errorf("%w: %s", ERROR_SOPS_INVALID, err)
+	}
+
+	if err := yaml.Unmarshal(content, &document); err != nil {
+		return nil, fmt.Errorf("%w: %s", ERROR_SOPS_INVALID, err)
+	}
+
+	if len(meta.Sops.Age) == 0 {
+		return nil, fmt.Errorf("%w: file is not encrypted with age", ERROR_SOPS_INVALID)
+	}
+
+	if len(document.Content) != 1 || document.Content[0].Kind != yaml.MappingNode {
+		return nil, fmt.Errorf("%w: document is not a map", ERROR_SOPS_INVALID)
+	}
+
+	var key []byte
+	errs := make([]error, 0)
+
+	for _, recipient := range meta.Sops.Age {
+		unwrapped, err := sops.unwrap(recipient.Enc)
+
+		if err == nil {
+			key = unwrapped
+			break
+		}
+
+		errs = append(errs, err)
+	}
+
+	if key == nil {
+		return nil, errors.Join(errs...)
+	}
+
+	tree := &decryption{
+		key:           key,
+		data:          map[string]string{},
+		mac:           sha512.New(),
+		onlyEncrypted: meta.Sops.MACOnlyEncrypted,
+	}
+
+	root := document.Content[0]
+
+	for i := 0; i+1 < len(root.Content); i += 2 {
+		if root.Content[i].Value == "sops" {
+			continue
+		}
+
+		err := tree.walk([]string{root.Content[i].Value}, root.Content[i].Value, root.Content[i+1])
+
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	err := verifyMAC(key, meta, fmt.Sprintf("%X", tree.mac.Sum(nil)))
+
+	if err != nil {
+		return nil, err
+	}
+
+	return tree.data, nil
+}
+
+func (sops *Sops) unwrap(enc string) ([]byte, error) {
+	reader, err := age.Decrypt(armor.NewReader(strings.NewReader(enc)), sops.Identities...)
+
+	if err != nil {
+		return nil, err
+	}
+
+	return io.ReadAll(reader)
+}
+
+// verifyMAC compares MAC stored in metadata, encrypted with lastmodified as additional data, with one computed over values
+func verifyMAC(key []byte, meta sopsFile, computed string) error {
+	if meta.Sops.MAC == "" {
+		return fmt.Errorf("%w: mac is missing", ERROR_SOPS_MAC)
+	}
+
+	modified, err := time.Parse(time.RFC3339, meta.Sops.LastModified)
+
+	if err != nil {
+		return fmt.Errorf("%w: invalid lastmodified", ERROR_SOPS_INVALID)
+	}
+
+	stored, _, err := decryptValue(key, meta.Sops.MAC, modified.Format(time.RFC3339))
+
+	if err != nil {
+		return fmt.Errorf("%w: %s", ERROR_SOPS_MAC, err)
+	}
+
+	if stored != computed {
+		return ERROR_SOPS_MAC
+	}
+
+	return nil
+}
+
+type decryption struct {
+	key           []byte
+	data          map[string]string
+	mac           hash.Hash
+	onlyEncrypted bool
+}
+
+// walk decrypts the tree in document order, list items share path of the list as additional data same as SOPS does
+func (tree *decryption) walk(path []string, flat string, node *yaml.Node) error {
+	switch node.Kind {
+	case yaml.AliasNode:
+		return tree.walk(path, flat, node.Alias)
+	case yaml.MappingNode:
+		for i := 0; i+1 < len(node.Content); i += 2 {
+			k := node.Content[i].Value
+			err := tree.walk(append(path[:len(path):len(path)], k), join(flat, k), node.Content[i+1])
+
+			if err != nil {
+				return err
+			}
+		}
+	case yaml.SequenceNode:
+		for i, item := range node.Content {
+			err := tree.walk(path, join(flat, fmt.Sprint(i)), item)
+
+			if err != nil {
+				return err
+			}
+		}
+	case yaml.ScalarNode:
+		plaintext, encrypted, err := decryptValue(tree.key, node.Value, strings.Join(path, ":")+":")
+
+		if err != nil {
+			return fmt.Errorf("%w: %s", err, flat)
+		}
+
+		if encrypted {
+			tree.mac.Write([]byte(plaintext))
+			tree.data[flat] = formatValue(node.Value, plaintext)
+
+			return nil
+		}
+
+		var value interface{}
+
+		if err = node.Decode(&value); err != nil {
+			return fmt.Errorf("%w: %s", ERROR_SOPS_INVALID, flat)
+		}
+
+		if !tree.onlyEncrypted {
+			tree.mac.Write(toBytes(value))
+		}
+
+		if value == nil {
+			tree.data[flat] = ""
+		} else {
+			tree.data[flat] = fmt.Sprint(value)
+		}
+	}
+
+	return nil
+}
+
+// decryptValue returns plaintext and true for values encrypted by SOPS, other values are returned as they are
+func decryptValue(key []byte, value string, additional string) (string, bool, error) {
+	match := sopsValue.FindStringSubmatch(value)
+
+	if match == nil {
+		// Unencrypted values, eg. keys with _unencrypted suffix
+		return value, false, nil
+	}
+
+	parts := make([][]byte, 3)
+
+	for i := range parts {
+		decoded, err := base64.StdEncoding.DecodeString(match[i+1])
+
+		if err != nil {
+			return "", false, fmt.Errorf("%w: malformed value", ERROR_SOPS_INVALID)
+		}
+
+		parts[i] = decoded
+	}
+
+	block, err := aes.NewCipher(key)
+
+	if err != nil {
+		return "", false, err
+	}
+
+	gcm, err := cipher.NewGCMWithNonceSize(block, len(parts[1]))
+
+	if err != nil {
+		return "", false, err
+	}
+
+	plaintext, err := gcm.Open(nil, parts[1], append(parts[0], parts[2]...), []byte(additional))
+
+	if err != nil {
+		return "", false, fmt.Errorf("%w: value authentication failed", ERROR_SOPS_INVALID)
+	}
+
+	return string(plaintext), true, nil
+}
+
+// formatValue lowercases booleans which SOPS stores as True and False
+func formatValue(encrypted string, plaintext string) string {
+	if sopsValue.FindStringSubmatch(encrypted)[4] == "bool" {
+		return strings.ToLower(plaintext)
+	}
+
+	return plaintext
+}
+
+// toBytes mirrors how SOPS serializes unencrypted values for the MAC
+func toBytes(value interface{}) []byte {
+	switch v := value.(type) {
+	case string:
+		return []byte(v)
+	case float64:
+		return []byte(strconv.FormatFloat(v, 'f', -1, 64))
+	case bool:
+		if v {
+			return []byte("True")
+		}
+
+		return []byte("False")
+	case nil:
+		return nil
+	default:
+		return []byte(fmt.Sprint(v))
+	}
+}
+
+func join(prefix string, key string) string {
+	if prefix == "" {
+		return key
+	}
+
+	return fmt.Sprintf("%s.%s", prefix, key)
+}
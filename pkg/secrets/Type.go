@@ -0,0 +1,58 @@
+package secrets
+
+import (
+	"context"
+	"filippo.io/age"
+	"net/http"
+	"sync"
+	"time"
+)
+
+const SCHEME_VAULT = "vault"
+const SCHEME_SOPS = "sops"
+const SCHEME_FILE = "file"
+
+// Resolver is configured on start, when nil provider references can't be resolved
+var Resolver *Providers
+
+// Provider fetches every key stored under the path, keys are resolved from the cached result
+type Provider interface {
+	Fetch(ctx context.Context, path string) (map[string]string, error)
+}
+
+type Providers struct {
+	Providers map[string]Provider
+	Refresh   time.Duration
+	cache     map[string]*Entry
+	lock      sync.RWMutex
+}
+
+type Entry struct {
+	Data    map[string]string
+	Fetched time.Time
+}
+
+// Reference to the secret kept outside of the cluster, eg. vault://secret/app/db?refresh=1m#password
+type Reference struct {
+	Scheme  string
+	Path    string
+	Key     string
+	Refresh time.Duration
+}
+
+type Vault struct {
+	Address   string
+	Namespace string
+	TokenFile string
+	Client    *http.Client
+}
+
+type File struct {
+	Directory string
+}
+
+// Sops decrypts files encrypted by SOPS, data keys are unwrapped with age identities
+type Sops struct {
+	Directory  string
+	Identities []age.Identity
+}
@@ -0,0 +1,146 @@
+package secrets
+
+import (
+	"context"
+	"crypto/tls"
+	"crypto/x509"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"github.com/simplecontainer/smr/pkg/configuration"
+	"net/http"
+	"net/url"
+	"os"
+	"strings"
+	"time"
+)
+
+func NewVault(config *configuration.Vault) (*Vault, error) {
+	vault := &Vault{
+		Address:   os.Getenv("VAULT_ADDR"),
+		Namespace: os.Getenv("VAULT_NAMESPACE"),
+		Client:    &http.Client{Timeout: 10 * time.Second},
+	}
+
+	if config == nil {
+		return vault, nil
+	}
+
+	if config.Address != "" {
+		vault.Address = config.Address
+	}
+
+	if config.Namespace != "" {
+		vault.Namespace = config.Namespace
+	}
+
+	vault.TokenFile = config.TokenFile
+
+	if config.CACert != "" {
+		pem, err := os.ReadFile(config.CACert)
+
+		if err != nil {
+			return nil, err
+		}
+
+		pool, err := x509.SystemCertPool()
+
+		if err != nil {
+			pool = x509.NewCertPool()
+		}
+
+		if !pool.AppendCertsFromPEM(pem) {
+			return nil, fmt.Errorf("no certificates found in %s", config.CACert)
+		}
+
+		vault.Client.Transport = &http.Transport{TLSClientConfig: &tls.Config{RootCAs: pool}}
+	}
+
+	return vault, nil
+}
+
+// Fetch reads the latest version from KV v2 engine, first path segment is the mount eg. secret/app/db
+func (vault *Vault) Fetch(ctx context.Context, path string) (map[string]string, error) {
+	if vault.Address == "" {
+		return nil, fmt.Errorf("%w: vault address is not set", ERROR_NOT_CONFIGURED)
+	}
+
+	mount, name, _ := strings.Cut(strings.Trim(path, "/"), "/")
+
+	if name == "" {
+		return nil, fmt.Errorf("vault path must be in the form mount/path: %s", path)
+	}
+
+	token, err := vault.token()
+
+	if err != nil {
+		return nil, err
+	}
+
+	request, err := http.NewRequestWithContext(ctx, http.MethodGet, fmt.Sprintf("%s/v1/%s/data/%s", strings.TrimSuffix(vault.Address, "/"), url.PathEscape(mount), name), nil)
+
+	if err != nil {
+		return nil, err
+	}
+
+	request.Header.Set("X-Vault-Token", token)
+
+	if vault.Namespace != "" {
+		request.Header.Set("X-Vault-Namespace", vault.Namespace)
+	}
+
+	response, err := vault.Client.Do(request)
+
+	if err != nil {
+		return nil, err
+	}
+
+	defer response.Body.Close()
+
+	body := struct {
+		Errors []string `json:"errors"`
+		Data   struct {
+			Data map[string]interface{} `json:"data"`
+		} `json:"data"`
+	}{}
+
+	err = json.NewDecoder(response.Body).Decode(&body)
+
+	switch {
+	case response.StatusCode == http.StatusNotFound:
+		return nil, fmt.Errorf("%w: vault://%s", ERROR_NOT_FOUND, path)
+	case response.StatusCode != http.StatusOK:
+		return nil, fmt.Errorf("vault responded with %d: %s", response.StatusCode, strings.Join(body.Errors, ", "))
+	case err != nil:
+		return nil, err
+	}
+
+	data := map[string]string{}
+
+	for k, v := range body.Data.Data {
+		flatten(k, v, data)
+	}
+
+	return data, nil
+}
+
+// token is read on every fetch so token file renewed by vault agent is picked up
+func (vault *Vault) token() (string, error) {
+	if vault.TokenFile != "" {
+		token, err := os.ReadFile(vault.TokenFile)
+
+		if err != nil {
+			return "", err
+		}
+
+		return strings.TrimSpace(string(token)), nil
+	}
+
+	token := os.Getenv("VAULT_TOKEN")
+
+	if token == "" {
+		return "", errors.New("vault token is not set, set tokenFile or VAULT_TOKEN")
+	}
+
+	return token, nil
+}
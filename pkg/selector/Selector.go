@@ -0,0 +1,213 @@
+package selector
+
+import (
+	"errors"
+	"fmt"
+	"regexp"
+	"strings"
+)
+
+var validToken = regexp.MustCompile(`^[A-Za-z0-9]([A-Za-z0-9._/-]*[A-Za-z0-9])?$`)
+
+// Parse accepts kubernetes style selectors: app=web,tier!=db,env in (prod,staging),!canary
+func Parse(selector string) (*Selector, error) {
+	s := &Selector{
+		Requirements: make([]Requirement, 0),
+	}
+
+	if strings.TrimSpace(selector) == "" {
+		return s, nil
+	}
+
+	terms, err := split(selector)
+
+	if err != nil {
+		return nil, err
+	}
+
+	for _, term := range terms {
+		requirement, err := parseRequirement(term)
+
+		if err != nil {
+			return nil, err
+		}
+
+		s.Requirements = append(s.Requirements, requirement)
+	}
+
+	return s, nil
+}
+
+func (s *Selector) IsEmpty() bool {
+	return s == nil || len(s.Requirements) == 0
+}
+
+// Matches returns true if labels satisfy every requirement - empty selector matches everything
+func (s *Selector) Matches(labels map[string]string) bool {
+	if s.IsEmpty() {
+		return true
+	}
+
+	for _, requirement := range s.Requirements {
+		if !requirement.Matches(labels) {
+			return false
+		}
+	}
+
+	return true
+}
+
+func (r Requirement) Matches(labels map[string]string) bool {
+	value, exists := labels[r.Key]
+
+	switch r.Operator {
+	case OPERATOR_EXISTS:
+		return exists
+	case OPERATOR_NOT_EXISTS:
+		return !exists
+	case OPERATOR_EQUALS:
+		return exists && value == r.Values[0]
+	case OPERATOR_NOT_EQUALS:
+		return !exists || value != r.Values[0]
+	case OPERATOR_IN:
+		return exists && contains(r.Values, value)
+	case OPERATOR_NOT_IN:
+		return !exists || !contains(r.Values, value)
+	default:
+		return false
+	}
+}
+
+func (s *Selector) String() string {
+	terms := make([]string, 0)
+
+	for _, r := range s.Requirements {
+		switch r.Operator {
+		case OPERATOR_EXISTS:
+			terms = append(terms, r.Key)
+		case OPERATOR_NOT_EXISTS:
+			terms = append(terms, fmt.Sprintf("!%s", r.Key))
+		case OPERATOR_IN, OPERATOR_NOT_IN:
+			terms = append(terms, fmt.Sprintf("%s %s (%s)", r.Key, r.Operator, strings.Join(r.Values, ",")))
+		default:
+			terms = append(terms, fmt.Sprintf("%s%s%s", r.Key, r.Operator, r.Values[0]))
+		}
+	}
+
+	return strings.Join(terms, ",")
+}
+
+// split breaks selector on commas that are not inside of the value set parentheses
+func split(selector string) ([]string, error) {
+	terms := make([]string, 0)
+	depth := 0
+	start := 0
+
+	for i, c := range selector {
+		switch c {
+		case '(':
+			depth++
+		case ')':
+			depth--
+
+			if depth < 0 {
+				return nil, errors.New(fmt.Sprintf("unbalanced parentheses in selector: %s", selector))
+			}
+		case ',':
+			if depth == 0 {
+				terms = append(terms, selector[start:i])
+				start = i + 1
+			}
+		}
+	}
+
+	if depth != 0 {
+		return nil, errors.New(fmt.Sprintf("unbalanced parentheses in selector: %s", selector))
+	}
+
+	return append(terms, selector[start:]), nil
+}
+
+func parseRequirement(term string) (Requirement, error) {
+	term = strings.TrimSpace(term)
+
+	if term == "" {
+		return Requirement{}, errors.New("empty requirement in selector")
+	}
+
+	if strings.HasPrefix(term, "!") && !strings.Contains(term, "=") {
+		return newRequirement(strings.TrimSpace(term[1:]), OPERATOR_NOT_EXISTS, nil)
+	}
+
+	if index := strings.Index(term, "("); index != -1 {
+		fields := strings.Fields(term[:index])
+
+		if len(fields) != 2 || !strings.HasSuffix(term, ")") {
+			return Requirement{}, errors.New(fmt.Sprintf("invalid set requirement: %s", term))
+		}
+
+		operator := strings.ToLower(fields[1])
+
+		if operator != OPERATOR_IN && operator != OPERATOR_NOT_IN {
+			return Requirement{}, errors.New(fmt.Sprintf("invalid set operator %s in: %s", fields[1], term))
+		}
+
+		values := make([]string, 0)
+
+		for _, value := range strings.Split(term[index+1:len(term)-1], ",") {
+			if value = strings.TrimSpace(value); value != "" {
+				values = append(values, value)
+			}
+		}
+
+		return newRequirement(fields[0], operator, values)
+	}
+
+	for _, operator := range []string{"!=", "==", "="} {
+		if index := strings.Index(term, operator); index != -1 {
+			key := strings.TrimSpace(term[:index])
+			value := strings.TrimSpace(term[index+len(operator):])
+
+			if operator == "==" {
+				operator = OPERATOR_EQUALS
+			}
+
+			return newRequirement(key, operator, []string{value})
+		}
+	}
+
+	return newRequirement(term, OPERATOR_EXISTS, nil)
+}
+
+func newRequirement(key string, operator string, values []string) (Requirement, error) {
+	if !validToken.MatchString(key) {
+		return Requirement{}, errors.New(fmt.Sprintf("invalid label key in selector: %q", key))
+	}
+
+	for _, value := range values {
+		// Empty value is valid label value for equality checks
+		if value != "" && !validToken.MatchString(value) {
+			return Requirement{}, errors.New(fmt.Sprintf("invalid label value in selector: %q", value))
+		}
+	}
+
+	if (operator == OPERATOR_IN || operator == OPERATOR_NOT_IN) && len(values) == 0 {
+		return Requirement{}, errors.New(fmt.Sprintf("set requirement for %s needs at least one value", key))
+	}
+
+	return Requirement{
+		Key:      key,
+		Operator: operator,
+		Values:   values,
+	}, nil
+}
+
+func contains(values []string, value string) bool {
+	for _, v := range values {
+		if v == value {
+			return true
+		}
+	}
+
+	return false
+}
@@ -0,0 +1,98 @@
+package selector
+
+import (
+	"github.com/stretchr/testify/assert"
+	"testing"
+)
+
+func TestParse(t *testing.T) {
+	tests := []struct {
+		name         string
+		selector     string
+		requirements []Requirement
+		expectError  bool
+	}{
+		{name: "Empty", selector: "", requirements: []Requirement{}},
+		{name: "Equality", selector: "app=web", requirements: []Requirement{{Key: "app", Operator: OPERATOR_EQUALS, Values: []string{"web"}}}},
+		{name: "Double equality", selector: "app==web", requirements: []Requirement{{Key: "app", Operator: OPERATOR_EQUALS, Values: []string{"web"}}}},
+		{
+			name:     "Multiple",
+			selector: "app=web, tier!=db",
+			requirements: []Requirement{
+				{Key: "app", Operator: OPERATOR_EQUALS, Values: []string{"web"}},
+				{Key: "tier", Operator: OPERATOR_NOT_EQUALS, Values: []string{"db"}},
+			},
+		},
+		{
+			name:     "Set based",
+			selector: "env in (prod,staging),zone notin (eu)",
+			requirements: []Requirement{
+				{Key: "env", Operator: OPERATOR_IN, Values: []string{"prod", "staging"}},
+				{Key: "zone", Operator: OPERATOR_NOT_IN, Values: []string{"eu"}},
+			},
+		},
+		{
+			name:     "Existence",
+			selector: "app,!canary",
+			requirements: []Requirement{
+				{Key: "app", Operator: OPERATOR_EXISTS},
+				{Key: "canary", Operator: OPERATOR_NOT_EXISTS},
+			},
+		},
+		{name: "Unbalanced parentheses", selector: "env in (prod,staging", expectError: true},
+		{name: "Invalid set operator", selector: "env within (prod)", expectError: true},
+		{name: "Empty set", selector: "env in ()", expectError: true},
+		{name: "Empty term", selector: "app=web,,tier=db", expectError: true},
+		{name: "Invalid key", selector: "=web", expectError: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			s, err := Parse(tt.selector)
+
+			if tt.expectError {
+				assert.Error(t, err)
+				return
+			}
+
+			assert.NoError(t, err)
+			assert.Equal(t, tt.requirements, s.Requirements)
+		})
+	}
+}
+
+func TestMatches(t *testing.T) {
+	labels := map[string]string{
+		"app":  "web",
+		"tier": "frontend",
+		"env":  "prod",
+	}
+
+	tests := []struct {
+		selector string
+		expected bool
+	}{
+		{selector: "", expected: true},
+		{selector: "app=web", expected: true},
+		{selector: "app=api", expected: false},
+		{selector: "app=web,tier!=db", expected: true},
+		{selector: "tier!=frontend", expected: false},
+		{selector: "missing!=value", expected: true},
+		{selector: "env in (prod,staging)", expected: true},
+		{selector: "env in (dev,staging)", expected: false},
+		{selector: "env notin (dev)", expected: true},
+		{selector: "missing notin (dev)", expected: true},
+		{selector: "app", expected: true},
+		{selector: "!app", expected: false},
+		{selector: "!canary", expected: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.selector, func(t *testing.T) {
+			s, err := Parse(tt.selector)
+
+			assert.NoError(t, err)
+			assert.Equal(t, tt.expected, s.Matches(labels))
+		})
+	}
+}
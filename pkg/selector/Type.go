@@ -0,0 +1,18 @@
+package selector
+
+type Selector struct {
+	Requirements []Requirement
+}
+
+type Requirement struct {
+	Key      string
+	Operator string
+	Values   []string
+}
+
+const OPERATOR_EQUALS = "="
+const OPERATOR_NOT_EQUALS = "!="
+const OPERATOR_IN = "in"
+const OPERATOR_NOT_IN = "notin"
+const OPERATOR_EXISTS = "exists"
+const OPERATOR_NOT_EXISTS = "!"
@@ -50,14 +50,15 @@ const (
 
 // Category Constants
 const (
-	CATEGORY_KIND    = "kind"
-	CATEGORY_STATE   = "state"
-	CATEGORY_ETCD    = "etcd"
-	CATEGORY_PLAIN   = "plain"
-	CATEGORY_EVENT   = "event"
-	CATEGORY_SECRET  = "secret"
-	CATEGORY_DNS     = "dns"
-	CATEGORY_INVALID = "invalid"
+	CATEGORY_KIND     = "kind"
+	CATEGORY_STATE    = "state"
+	CATEGORY_ETCD     = "etcd"
+	CATEGORY_PLAIN    = "plain"
+	CATEGORY_EVENT    = "event"
+	CATEGORY_SECRET   = "secret"
+	CATEGORY_DNS      = "dns"
+	CATEGORY_REVISION = "revision"
+	CATEGORY_INVALID  = "invalid"
 )
 
 // Signal Constants
@@ -79,6 +80,9 @@ const (
 	KIND_NETWORK       = "network"
 	KIND_SECRET        = "secret"
 	KIND_CUSTOM        = "custom"
+	KIND_ROLE          = "role"
+	KIND_ROLEBINDING   = "rolebinding"
+	KIND_NOTIFICATION  = "notification"
 )
 
 // State Constants
@@ -1,6 +1,7 @@
 package template
 
 import (
+	"context"
 	"encoding/json"
 	"errors"
 	"fmt"
@@ -10,8 +11,10 @@ import (
 	v1 "github.com/simplecontainer/smr/pkg/definitions/v1"
 	"github.com/simplecontainer/smr/pkg/f"
 	"github.com/simplecontainer/smr/pkg/objects"
+	"github.com/simplecontainer/smr/pkg/secrets"
 	"github.com/simplecontainer/smr/pkg/smaps"
 	"github.com/simplecontainer/smr/pkg/static"
+	"time"
 )
 
 func Lookup(placeholder string, client *clients.Http, user *authentication.User, runtime *smaps.Smap, dependencies []f.Format, depth int) (string, error) {
@@ -54,7 +57,7 @@ func Lookup(placeholder string, client *clients.Http, user *authentication.User,
 			return placeholder, err
 		}
 
-		_, ok := secret.Spec.Data[key]
+		value, ok := secret.Spec.Data[key]
 
 		if !ok {
 			return placeholder, errors.New(
@@ -62,7 +65,17 @@ func Lookup(placeholder string, client *clients.Http, user *authentication.User,
 			)
 		}
 
-		return secret.Spec.Data[key], nil
+		// Secret can point to external provider eg. vault://secret/app/db#password, resolved only when used
+		ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+		defer cancel()
+
+		value, err = secrets.Value(ctx, value)
+
+		if err != nil {
+			return placeholder, err
+		}
+
+		return value, nil
 	case "configuration":
 		name, key, err := Extract(format.GetName())
 
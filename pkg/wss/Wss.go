@@ -2,8 +2,11 @@ package wss
 
 import (
 	"context"
+	"encoding/json"
+	"errors"
 	"github.com/gorilla/websocket"
 	"github.com/simplecontainer/smr/pkg/contracts/ievents"
+	"github.com/simplecontainer/smr/pkg/contracts/iresponse"
 	"net/http"
 	"strings"
 	"sync"
@@ -28,9 +31,19 @@ func Request(ctx context.Context, client *http.Client, headers http.Header, url
 
 	connCtx, cancel := context.WithCancel(ctx)
 
-	conn, _, err := dialer.DialContext(connCtx, wsURL, headers)
+	conn, resp, err := dialer.DialContext(connCtx, wsURL, headers)
 	if err != nil {
 		cancel() // Clean up if connection fails
+
+		// Handshake rejected by the server carries explanation in the body (eg. RBAC denial)
+		if resp != nil && resp.StatusCode == http.StatusForbidden {
+			var response iresponse.Response
+
+			if json.NewDecoder(resp.Body).Decode(&response) == nil && response.ErrorExplanation != "" {
+				return nil, nil, errors.New(response.ErrorExplanation)
+			}
+		}
+
 		return nil, nil, err
 	}
 